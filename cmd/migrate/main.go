@@ -0,0 +1,62 @@
+// Command migrate applies pending database migrations.
+// With -dry-run it prints the version, name and SQL of each pending migration instead,
+// leaving the database untouched, so the changes can be reviewed before a deployment.
+//
+// Usage:
+//
+//	go run ./cmd/migrate [-dry-run] [-dir migrations]
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "print pending migrations without applying them")
+	dir := flag.String("dir", "migrations", "directory containing the migration files")
+	flag.Parse()
+
+	cfg := config.Load()
+	logger.Init(cfg.LogLevel, cfg.IsProduction(), cfg.Env, cfg.LogTimeFormat)
+	log := logger.L()
+
+	if err := cfg.EnsureDirectories(); err != nil {
+		log.Fatalf("Failed to create directories: %v", err)
+	}
+
+	dbManager, err := db.NewManager(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer dbManager.Close()
+
+	runner := migrations.NewRunner(dbManager.GetDB(), *dir)
+
+	// Warn about missing migration versions
+	if gaps, err := runner.DetectGaps(); err == nil && len(gaps) > 0 {
+		log.WithField("missing_versions", gaps).Warn("Migration versions are not contiguous")
+	}
+
+	if *dryRun {
+		pending, err := runner.DryRun()
+		if err != nil {
+			log.Fatalf("Migration dry run failed: %v", err)
+		}
+		for _, m := range pending {
+			fmt.Printf("-- %03d_%s\n%s\n\n", m.Version, m.Name, m.SQL)
+		}
+		log.WithField("pending", len(pending)).Info("Migration dry run finished, nothing was applied")
+		return
+	}
+
+	if err := runner.Run(); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	log.Info("Database migrations completed successfully")
+}
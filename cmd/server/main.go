@@ -2,44 +2,76 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
-	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/app/server"
+	"github.com/nekoteoj/lab-cms/internal/pkg/auth"
 	"github.com/nekoteoj/lab-cms/internal/pkg/config"
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
 	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
 )
 
+// Build metadata, overridden at link time via -ldflags "-X main.version=..."
+// (see the Makefile build target). The defaults identify a local go run.
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
 func main() {
 	// Load configuration
 	cfg := config.Load()
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
-		logger.Init("error", cfg.IsProduction())
+		logger.Init("error", cfg.IsProduction(), cfg.Env, cfg.LogTimeFormat)
 		logger.L().Fatal("Configuration error: " + err.Error())
 	}
 
+	// Apply the password policy; Validate has already checked the initial admin password
+	auth.SetPasswordPolicy(auth.PasswordPolicy{
+		MinLength:     cfg.PasswordMinLength,
+		RequireDigit:  cfg.PasswordRequireDigit,
+		RequireLetter: cfg.PasswordRequireLetter,
+	})
+
+	// Users created without an explicit role get the configured default
+	auth.SetDefaultUserRole(models.UserRole(cfg.DefaultUserRole))
+
 	// Initialize logger with configuration
-	logger.Init(cfg.LogLevel, cfg.IsProduction())
+	logger.Init(cfg.LogLevel, cfg.IsProduction(), cfg.Env, cfg.LogTimeFormat)
+	if cfg.LogBuffered {
+		logger.EnableBuffering(logger.DefaultFlushInterval)
+		defer logger.Flush()
+	}
 	log := logger.L()
 
-	log.Info("Starting Lab CMS")
+	// Apply the configured content bounds to model validation
+	models.SetPublicationYearRange(cfg.PublicationMinYear, cfg.PublicationMaxYear)
+	models.SetAuthorsTextMaxLength(cfg.PublicationAuthorsMaxLength)
+	models.SetNewsMinContentLength(cfg.NewsMinContentLength)
+	models.SetDisplayLocation(cfg.DisplayLocation())
+
+	buildInfo := server.BuildInfo{Version: version, Commit: commit, BuildDate: buildDate}
+	log.WithField("version", buildInfo.Version).
+		WithField("commit", buildInfo.Commit).
+		WithField("build_date", buildInfo.BuildDate).
+		Info("Starting Lab CMS")
 	log.WithField("port", cfg.Port).
 		WithField("env", cfg.Env).
 		Info("Configuration loaded")
 
-	// Ensure data directory exists
-	if err := ensureDataDir(cfg.DatabaseURL); err != nil {
-		log.Fatalf("Failed to create data directory: %v", err)
+	// Ensure data and upload directories exist
+	if err := cfg.EnsureDirectories(); err != nil {
+		log.Fatalf("Failed to create directories: %v", err)
 	}
 
 	// Initialize database manager with connection pool
@@ -54,6 +86,12 @@ func main() {
 
 	// Run migrations
 	runner := migrations.NewRunner(dbManager.GetDB(), "migrations")
+
+	// Warn about missing migration versions
+	if gaps, err := runner.DetectGaps(); err == nil && len(gaps) > 0 {
+		log.WithField("missing_versions", gaps).Warn("Migration versions are not contiguous")
+	}
+
 	if err := runner.Run(); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
@@ -61,20 +99,26 @@ func main() {
 
 	// Initialize repository factory
 	repoFactory := repository.NewFactory(dbManager)
-	// Note: repoFactory will be used by HTTP handlers in future tasks
-	_ = repoFactory // Prevent unused variable warning
+
+	// Load page templates; RespondNotFound uses them for browser requests
+	templates, err := server.LoadTemplates(cfg.TemplatesPath)
+	if err != nil {
+		log.Fatalf("Failed to load templates: %v", err)
+	}
+	server.UseTemplates(templates)
 
 	// Set up HTTP handlers with middleware chain
-	handler := setupHandler(cfg)
+	handler := setupHandler(cfg, buildInfo,
+		server.HomeHandler(templates, repoFactory.LabSettings),
+		server.PublicationSearchHandler(repoFactory.Publications, cfg.SearchResultLimit),
+		server.DatabaseHealthCheck(dbManager),
+		server.MigrationsHealthCheck(runner),
+		server.UploadDirHealthCheck(cfg.UploadPath),
+		server.DiskSpaceHealthCheck(cfg.UploadPath, server.DefaultMinFreeDiskBytes),
+	)
 
 	// Create HTTP server with timeouts
-	srv := &http.Server{
-		Addr:         ":" + cfg.Port,
-		Handler:      handler,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	srv := server.NewHTTPServer(cfg, handler)
 
 	// Start server in a goroutine
 	go func() {
@@ -104,45 +148,49 @@ func main() {
 }
 
 // setupHandler creates the HTTP handler with middleware chain
-func setupHandler(cfg *config.Config) http.Handler {
+func setupHandler(cfg *config.Config, buildInfo server.BuildInfo, home, search http.Handler, healthChecks ...server.HealthCheck) http.Handler {
 	// Create base mux
 	mux := http.NewServeMux()
 
 	// Health check endpoint
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"healthy"}`))
-	})
+	mux.Handle(server.HealthPath, server.HealthHandler(buildInfo))
 
-	// Static files
-	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./web/static"))))
+	// Aggregated subsystem health report
+	mux.Handle(server.HealthSummaryPath, server.HealthSummaryHandler(healthChecks...))
 
-	// Home route (placeholder)
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path != "/" {
-			server.RespondNotFound(w, r, "page")
-			return
-		}
-		fmt.Fprintf(w, "Welcome to Lab CMS")
-	})
+	// OpenAPI document for the public read API
+	mux.Handle(server.OpenAPIPath, server.OpenAPIHandler())
+
+	// Publication search, returning at most SEARCH_RESULT_LIMIT results
+	mux.Handle(server.PublicationSearchPath, search)
+
+	// Static files, unless a CDN serves them
+	server.RegisterStatic(mux, cfg, "./web/static")
+
+	// Home page, also serving 404s for unmatched paths
+	mux.Handle("/", home)
+
+	// Note: the maintenance toggle (server.MaintenanceHandler), the BibTeX import
+	// (server.PublicationImportHandler), the database integrity check
+	// (server.IntegrityCheckHandler) and the database backup (server.BackupHandler)
+	// will be mounted at server.MaintenancePath, server.PublicationImportPath,
+	// server.IntegrityCheckPath and server.DatabaseBackupPath once admin
+	// authentication is in place. When mounted, create endpoints such as the import
+	// should be wrapped in server.IdempotencyMiddleware, after authentication so that
+	// keys are scoped to the signed-in user and with the endpoint's own body limit;
+	// no endpoint uses it yet
 
 	// Apply middleware chain
 	middlewares := []server.Middleware{
 		server.RequestIDMiddleware(),
 		server.RecoveryMiddleware(),
-		server.SecurityHeadersMiddleware(),
-		server.LoggingMiddleware(),
+		server.SecurityHeadersMiddleware(cfg),
+		server.ConcurrencyLimitMiddleware(cfg.MaxRequestsPerIP, cfg.TrustedProxyNets()),
+		server.LoggingMiddleware(cfg.QuietLogPaths()...),
+		server.MaintenanceMiddleware(cfg),
+		server.GzipMiddleware(cfg.GzipMinBytes),
+		server.PrettyJSONMiddleware(cfg),
 	}
 
 	return server.Chain(middlewares...)(mux)
 }
-
-// ensureDataDir creates the parent directory for the database file if it doesn't exist.
-func ensureDataDir(dbPath string) error {
-	dir := filepath.Dir(dbPath)
-	if dir == "." || dir == "/" {
-		return nil
-	}
-	return os.MkdirAll(dir, 0755)
-}
@@ -0,0 +1,53 @@
+// Command upload-gc removes uploaded images that are no longer referenced anywhere in the
+// database, such as photos left behind when a member's photo is replaced or the member is
+// deleted. Images linked from news, projects, homepage sections or other content are kept,
+// and non-image uploads such as PDFs are never removed.
+//
+// Usage:
+//
+//	go run ./cmd/upload-gc [-dry-run]
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+	"github.com/nekoteoj/lab-cms/internal/pkg/services"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report orphaned files without deleting them")
+	flag.Parse()
+
+	cfg := config.Load()
+	logger.Init(cfg.LogLevel, cfg.IsProduction(), cfg.Env, cfg.LogTimeFormat)
+	log := logger.L()
+
+	dbManager, err := db.NewManager(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer dbManager.Close()
+
+	ctx := context.Background()
+
+	referenced, err := repository.NewFactory(dbManager).UploadReferences(ctx)
+	if err != nil {
+		log.Fatalf("Failed to load upload references: %v", err)
+	}
+
+	removed, err := services.NewUploadService(cfg).GC(ctx, referenced, *dryRun)
+	if err != nil {
+		log.Fatalf("Upload cleanup failed: %v", err)
+	}
+
+	log.WithFields(map[string]interface{}{
+		"removed": removed,
+		"dry_run": *dryRun,
+		"path":    cfg.UploadPath,
+	}).Info("Orphaned upload cleanup finished")
+}
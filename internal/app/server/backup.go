@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// DatabaseBackupPath is the admin endpoint that writes a database backup
+const DatabaseBackupPath = "/admin/db/backup"
+
+// backupTimeFormat names backup files by their UTC creation time; milliseconds keep two
+// backups taken in the same second apart
+const backupTimeFormat = "20060102-150405.000"
+
+// DatabaseBackuper copies the database to a file; it is implemented by db.DBManager
+type DatabaseBackuper interface {
+	Backup(ctx context.Context, path string) error
+}
+
+// backupResult is the response body of the backup endpoint
+type backupResult struct {
+	Path string `json:"path"`
+}
+
+// BackupHandler writes a copy of the database to a timestamped file in cfg.BackupPath
+// (lab-cms-<time>.db) on POST and responds with the file's path. Only root users may
+// trigger a backup; it relies on the authenticated role being in the request context
+// (see ContextWithUser).
+func BackupHandler(backuper DatabaseBackuper, cfg *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeJSONError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+			return
+		}
+		if !requireRole(w, r, models.UserRoleRoot, "create database backup") {
+			return
+		}
+
+		path := filepath.Join(cfg.BackupPath, "lab-cms-"+time.Now().UTC().Format(backupTimeFormat)+".db")
+		if err := backuper.Backup(r.Context(), path); err != nil {
+			logger.L().WithError(err).WithField("path", path).Error("Database backup failed")
+			writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to back up the database")
+			return
+		}
+
+		logger.L().WithField("path", path).Info("Database backup created")
+		writeJSON(w, http.StatusOK, backupResult{Path: path})
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBackuper records the requested backup path and returns a fixed error
+type stubBackuper struct {
+	path string
+	err  error
+}
+
+func (s *stubBackuper) Backup(ctx context.Context, path string) error {
+	s.path = path
+	return s.err
+}
+
+func TestBackupHandler(t *testing.T) {
+	post := func(backuper DatabaseBackuper, cfg *config.Config, user *models.User) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, DatabaseBackupPath, nil)
+		req = req.WithContext(ContextWithUser(req.Context(), user))
+		rec := httptest.NewRecorder()
+		BackupHandler(backuper, cfg).ServeHTTP(rec, req)
+		return rec
+	}
+	root := &models.User{ID: 1, Email: "root@example.com", Role: models.UserRoleRoot}
+
+	t.Run("root creates a backup", func(t *testing.T) {
+		dir := t.TempDir()
+		dbManager, err := db.NewManager(filepath.Join(dir, "lab-cms.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { dbManager.Close() })
+
+		cfg := &config.Config{BackupPath: filepath.Join(dir, "backups")}
+		require.NoError(t, os.MkdirAll(cfg.BackupPath, 0o750))
+
+		rec := post(dbManager, cfg, root)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var body backupResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, cfg.BackupPath, filepath.Dir(body.Path))
+		assert.Regexp(t, `^lab-cms-\d{8}-\d{6}\.\d{3}\.db$`, filepath.Base(body.Path))
+
+		info, err := os.Stat(body.Path)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size())
+	})
+
+	t.Run("normal user is forbidden", func(t *testing.T) {
+		backuper := &stubBackuper{}
+		rec := post(backuper, &config.Config{BackupPath: t.TempDir()},
+			&models.User{ID: 2, Email: "editor@example.com", Role: models.UserRoleNormal})
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, backuper.path, "no backup is taken")
+	})
+
+	t.Run("unauthenticated request is rejected", func(t *testing.T) {
+		backuper := &stubBackuper{}
+		rec := post(backuper, &config.Config{BackupPath: t.TempDir()}, nil)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, backuper.path)
+	})
+
+	t.Run("backup failure", func(t *testing.T) {
+		rec := post(&stubBackuper{err: errors.New("disk full")}, &config.Config{BackupPath: t.TempDir()}, root)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "disk full")
+	})
+
+	t.Run("only POST allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		BackupHandler(&stubBackuper{}, &config.Config{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DatabaseBackupPath, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "POST", rec.Header().Get("Allow"))
+	})
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GzipMiddleware compresses responses with gzip for clients that accept it. Bodies are
+// buffered until they reach minBytes; shorter responses are sent uncompressed because
+// compressing them costs more CPU than it saves bandwidth. A minBytes of 0 compresses every
+// response with a body. Responses that already carry a Content-Encoding, partial (206)
+// responses and already-compressed content types such as JPEG images are left alone.
+func GzipMiddleware(minBytes int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if !acceptsGzip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gw := &gzipResponseWriter{ResponseWriter: w, minBytes: minBytes, status: http.StatusOK}
+			defer gw.close()
+
+			next.ServeHTTP(gw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether the request's Accept-Encoding allows gzip; "gzip;q=0" refuses it
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			weight, err := strconv.ParseFloat(q, 64)
+			return err == nil && weight > 0
+		}
+		return true
+	}
+	return false
+}
+
+// precompressedTypes are content types whose bodies gzip cannot shrink
+var precompressedTypes = []string{
+	"image/jpeg", "image/png", "image/gif", "image/webp", "image/avif",
+	"video/", "audio/",
+	"application/zip", "application/gzip", "application/x-gzip", "application/pdf",
+	"font/woff", "font/woff2",
+}
+
+// compressible reports whether a response with header h and status may be gzipped. Byte
+// ranges refer to the uncompressed body, so partial responses are sent as they are.
+func compressible(h http.Header, status int) bool {
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" || status == http.StatusPartialContent {
+		return false
+	}
+	contentType := strings.ToLower(h.Get("Content-Type"))
+	for _, t := range precompressedTypes {
+		if strings.HasPrefix(contentType, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// gzipResponseWriter holds back the status and the start of the body until it knows
+// whether the response reaches the compression threshold
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	minBytes int
+	status   int
+	buf      []byte
+	decided  bool
+	gz       *gzip.Writer
+}
+
+func (g *gzipResponseWriter) WriteHeader(code int) {
+	if !g.decided {
+		g.status = code
+	}
+}
+
+func (g *gzipResponseWriter) Write(p []byte) (int, error) {
+	if !g.decided {
+		g.buf = append(g.buf, p...)
+		if len(g.buf) < g.minBytes || len(g.buf) == 0 {
+			return len(p), nil
+		}
+		if err := g.decide(true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if g.gz != nil {
+		return g.gz.Write(p)
+	}
+	return g.ResponseWriter.Write(p)
+}
+
+// decide sends the status and the buffered body, compressed if compress is set and the
+// response allows it
+func (g *gzipResponseWriter) decide(compress bool) error {
+	g.decided = true
+
+	h := g.ResponseWriter.Header()
+	if compress && compressible(h, g.status) {
+		h.Del("Content-Length")
+		h.Set("Content-Encoding", "gzip")
+		g.gz = gzip.NewWriter(g.ResponseWriter)
+	}
+	g.ResponseWriter.WriteHeader(g.status)
+
+	buf := g.buf
+	g.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if g.gz != nil {
+		_, err := g.gz.Write(buf)
+		return err
+	}
+	_, err := g.ResponseWriter.Write(buf)
+	return err
+}
+
+// Flush sends the held-back status and body, compressed when the response allows it, and
+// flushes the gzip stream and the underlying writer. Streaming handlers use it through
+// http.ResponseController; the compression threshold no longer applies once they flush.
+func (g *gzipResponseWriter) Flush() {
+	if !g.decided {
+		if err := g.decide(true); err != nil {
+			return
+		}
+	}
+	if g.gz != nil {
+		if err := g.gz.Flush(); err != nil {
+			return
+		}
+	}
+	if f, ok := g.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close sends a response that stayed below the threshold and finishes the gzip stream
+func (g *gzipResponseWriter) close() {
+	if !g.decided {
+		_ = g.decide(false)
+	}
+	if g.gz != nil {
+		_ = g.gz.Close()
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveGzip sends a GET with the given Accept-Encoding through GzipMiddleware(minBytes)
+func serveGzip(minBytes int, acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	GzipMiddleware(minBytes)(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+// bodyOf writes body with status 200
+func bodyOf(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, body)
+	}
+}
+
+func gunzip(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestGzipMiddleware_Threshold(t *testing.T) {
+	const threshold = 100
+
+	t.Run("below threshold is uncompressed", func(t *testing.T) {
+		body := strings.Repeat("a", threshold-1)
+		rec := serveGzip(threshold, "gzip", bodyOf(body))
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, rec.Body.String())
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+	})
+
+	t.Run("at threshold is compressed", func(t *testing.T) {
+		body := strings.Repeat("a", threshold)
+		rec := serveGzip(threshold, "gzip", bodyOf(body))
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, gunzip(t, rec))
+	})
+
+	t.Run("small writes adding up to the threshold are compressed", func(t *testing.T) {
+		rec := serveGzip(threshold, "gzip, deflate", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			for i := 0; i < 20; i++ {
+				io.WriteString(w, "0123456789")
+			}
+		})
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, strings.Repeat("0123456789", 20), gunzip(t, rec))
+	})
+
+	t.Run("status kept for uncompressed responses", func(t *testing.T) {
+		rec := serveGzip(threshold, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "missing")
+		})
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "missing", rec.Body.String())
+	})
+
+	t.Run("zero threshold compresses any body", func(t *testing.T) {
+		rec := serveGzip(0, "gzip", bodyOf("x"))
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "x", gunzip(t, rec))
+	})
+
+	t.Run("empty body is not compressed", func(t *testing.T) {
+		rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Empty(t, rec.Body.String())
+	})
+}
+
+func TestGzipMiddleware_AcceptEncoding(t *testing.T) {
+	body := strings.Repeat("a", 200)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		compressed     bool
+	}{
+		{"missing header", "", false},
+		{"gzip accepted", "br, gzip", true},
+		{"gzip with weight", "gzip;q=0.5", true},
+		{"gzip refused", "gzip;q=0", false},
+		{"other codings only", "br, deflate", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveGzip(10, tt.acceptEncoding, bodyOf(body))
+
+			if tt.compressed {
+				assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+				assert.Equal(t, body, gunzip(t, rec))
+				return
+			}
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, body, rec.Body.String())
+		})
+	}
+}
+
+func TestGzipMiddleware_AlreadyEncoded(t *testing.T) {
+	rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "br")
+		io.WriteString(w, "pre-encoded bytes")
+	})
+
+	assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "pre-encoded bytes", rec.Body.String())
+}
+
+func TestGzipMiddleware_PartialContent(t *testing.T) {
+	body := strings.Repeat("range ", 50)
+
+	t.Run("206 is not compressed", func(t *testing.T) {
+		rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Content-Range", "bytes 0-299/1000")
+			w.WriteHeader(http.StatusPartialContent)
+			io.WriteString(w, body)
+		})
+
+		assert.Equal(t, http.StatusPartialContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, body, rec.Body.String())
+	})
+
+	t.Run("Content-Range is not compressed", func(t *testing.T) {
+		rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Range", "bytes */1000")
+			io.WriteString(w, body)
+		})
+
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+	})
+}
+
+func TestGzipMiddleware_PrecompressedTypes(t *testing.T) {
+	for _, contentType := range []string{"image/jpeg", "image/png", "image/gif", "application/zip", "video/mp4"} {
+		t.Run(contentType, func(t *testing.T) {
+			rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", contentType)
+				io.WriteString(w, "compressed bytes")
+			})
+
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			assert.Equal(t, "compressed bytes", rec.Body.String())
+		})
+	}
+
+	t.Run("svg is compressed", func(t *testing.T) {
+		rec := serveGzip(0, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/svg+xml")
+			io.WriteString(w, "<svg></svg>")
+		})
+
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "<svg></svg>", gunzip(t, rec))
+	})
+}
+
+func TestGzipMiddleware_Flush(t *testing.T) {
+	t.Run("flushed response is compressed with its status", func(t *testing.T) {
+		rec := serveGzip(1024, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusAccepted)
+			io.WriteString(w, "first ")
+			require.NoError(t, http.NewResponseController(w).Flush())
+			io.WriteString(w, "second")
+		})
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "first second", gunzip(t, rec))
+	})
+
+	t.Run("client without gzip", func(t *testing.T) {
+		rec := serveGzip(1024, "", func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "plain")
+			require.NoError(t, http.NewResponseController(w).Flush())
+		})
+
+		assert.True(t, rec.Flushed)
+		assert.Empty(t, rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "plain", rec.Body.String())
+	})
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// ConcurrencyLimitMiddleware caps how many requests a single client IP can have in flight
+// at once. A request beyond the cap is logged at warn level and answered with 429 and a
+// Retry-After header instead of queueing, and the slot is released as soon as a request
+// completes. Clients are identified by the connection's remote address, or by the
+// X-Forwarded-For client address when the connection comes from one of trustedProxies.
+// A non-positive maxPerIP disables the limit.
+func ConcurrencyLimitMiddleware(maxPerIP int, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	if maxPerIP <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	limiter := &ipConcurrencyLimiter{max: maxPerIP, inFlight: make(map[string]int)}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(trustedProxies, r)
+			if !limiter.acquire(ip) {
+				logger.L().WithRequestID(RequestIDFromContext(r.Context())).WithFields(map[string]interface{}{
+					"client_ip": ip,
+					"path":      r.URL.Path,
+				}).Warn("Too many simultaneous requests from client")
+				w.Header().Set("Retry-After", "1")
+				writeJSONError(w, r, http.StatusTooManyRequests, "TOO_MANY_REQUESTS",
+					"Too many simultaneous requests. Please try again shortly.")
+				return
+			}
+			defer limiter.release(ip)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ipConcurrencyLimiter counts in-flight requests per IP. Entries are removed when their
+// count drops to zero, so the map only holds clients with requests in progress.
+type ipConcurrencyLimiter struct {
+	mu       sync.Mutex
+	max      int
+	inFlight map[string]int
+}
+
+// acquire takes a slot for ip, reporting false when ip is already at the cap
+func (l *ipConcurrencyLimiter) acquire(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[ip] >= l.max {
+		return false
+	}
+	l.inFlight[ip]++
+	return true
+}
+
+// release frees a slot taken by acquire
+func (l *ipConcurrencyLimiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[ip] <= 1 {
+		delete(l.inFlight, ip)
+		return
+	}
+	l.inFlight[ip]--
+}
+
+// remoteIP returns the IP address of the connection's peer, without the port
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingHandler signals entered when a request starts and holds it until release is closed
+func blockingHandler(entered chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func serveFrom(handler http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/expensive", nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func waitEntered(t *testing.T, entered <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-entered:
+	case <-time.After(2 * time.Second):
+		t.Fatal("request never reached the handler")
+	}
+}
+
+func TestConcurrencyLimitMiddleware(t *testing.T) {
+	const maxPerIP = 2
+
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+	handler := ConcurrencyLimitMiddleware(maxPerIP, nil)(blockingHandler(entered, release))
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 10)
+	start := func(addr string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serveFrom(handler, addr).Code
+		}()
+	}
+
+	// Fill the cap for one client
+	for i := 0; i < maxPerIP; i++ {
+		start("192.0.2.1:4000")
+		waitEntered(t, entered)
+	}
+
+	t.Run("over the cap is rejected", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger.Init("info", true, "", "")
+		logger.SetOutput(&buf)
+		t.Cleanup(func() {
+			logger.Init("info", false, "", "")
+			logger.SetOutput(os.Stdout)
+		})
+
+		rec := serveFrom(handler, "192.0.2.1:4001")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), "TOO_MANY_REQUESTS")
+
+		var line accessLogLine
+		require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &line), buf.String())
+		assert.Equal(t, "warn", line.Level)
+		assert.Equal(t, "192.0.2.1", line.Fields["client_ip"])
+		assert.Equal(t, "/expensive", line.Fields["path"])
+	})
+
+	t.Run("another client proceeds", func(t *testing.T) {
+		start("198.51.100.7:4000")
+		waitEntered(t, entered)
+	})
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	t.Run("slots are released on completion", func(t *testing.T) {
+		// release is closed, so these complete one after another without hitting the cap
+		for i := 0; i < maxPerIP+1; i++ {
+			require.Equal(t, http.StatusOK, serveFrom(handler, "192.0.2.1:4000").Code)
+		}
+	})
+}
+
+func TestConcurrencyLimitMiddleware_BehindProxy(t *testing.T) {
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+	proxies := (&config.Config{TrustedProxies: "10.0.0.1"}).TrustedProxyNets()
+	handler := ConcurrencyLimitMiddleware(1, proxies)(blockingHandler(entered, release))
+
+	serveVia := func(forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/expensive", nil)
+		req.RemoteAddr = "10.0.0.1:4000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		serveVia("192.0.2.1")
+	}()
+	waitEntered(t, entered)
+
+	t.Run("same client through the proxy is limited", func(t *testing.T) {
+		assert.Equal(t, http.StatusTooManyRequests, serveVia("192.0.2.1").Code)
+	})
+
+	t.Run("other clients through the proxy proceed", func(t *testing.T) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveVia("198.51.100.7")
+		}()
+		waitEntered(t, entered)
+	})
+
+	close(release)
+	wg.Wait()
+}
+
+func TestConcurrencyLimitMiddleware_Disabled(t *testing.T) {
+	entered := make(chan struct{}, 10)
+	release := make(chan struct{})
+	handler := ConcurrencyLimitMiddleware(0, nil)(blockingHandler(entered, release))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			serveFrom(handler, "192.0.2.1:4000")
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		waitEntered(t, entered)
+	}
+
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// NewCookie builds a cookie carrying the security and scope attributes from cfg.
+// Session and CSRF cookies must be created through it so that COOKIE_DOMAIN, COOKIE_PATH,
+// COOKIE_SECURE, COOKIE_HTTPONLY and COOKIE_SAMESITE apply to all of them alike.
+// maxAge is in seconds; 0 makes a session cookie and a negative value deletes the cookie.
+// The cookie is also marked Secure when r arrived over HTTPS, directly or through a trusted
+// proxy (see IsSecureRequest); r may be nil when no request is at hand.
+func NewCookie(cfg *config.Config, r *http.Request, name, value string, maxAge int) *http.Cookie {
+	path := cfg.CookiePath
+	if path == "" {
+		path = "/"
+	}
+
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Domain:   cfg.CookieDomain,
+		Path:     path,
+		MaxAge:   maxAge,
+		Secure:   cfg.CookieSecure || IsSecureRequest(cfg, r),
+		HttpOnly: cfg.CookieHttpOnly,
+		SameSite: cookieSameSite(cfg.CookieSameSite),
+	}
+}
+
+// cookieSameSite maps a COOKIE_SAMESITE value to http.SameSite, defaulting to strict
+func cookieSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
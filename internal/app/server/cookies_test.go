@@ -0,0 +1,70 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setCookie writes c to a response and parses it back, as a browser would see it
+func setCookie(t *testing.T, c *http.Cookie) *http.Cookie {
+	rec := httptest.NewRecorder()
+	http.SetCookie(rec, c)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return cookies[0]
+}
+
+func TestNewCookie_Defaults(t *testing.T) {
+	c := setCookie(t, NewCookie(&config.Config{CookieHttpOnly: true}, nil, "session", "abc", 3600))
+
+	assert.Equal(t, "session", c.Name)
+	assert.Equal(t, "abc", c.Value)
+	assert.Empty(t, c.Domain, "cookie should be host-only when COOKIE_DOMAIN is unset")
+	assert.Equal(t, "/", c.Path)
+	assert.Equal(t, 3600, c.MaxAge)
+	assert.True(t, c.HttpOnly)
+	assert.False(t, c.Secure)
+	assert.Equal(t, http.SameSiteStrictMode, c.SameSite)
+}
+
+func TestNewCookie_FromConfig(t *testing.T) {
+	cfg := &config.Config{
+		CookieDomain:   "example.org",
+		CookiePath:     "/admin",
+		CookieSecure:   true,
+		CookieHttpOnly: true,
+		CookieSameSite: "lax",
+	}
+
+	c := setCookie(t, NewCookie(cfg, nil, "csrf", "token", 0))
+
+	assert.Equal(t, "example.org", c.Domain)
+	assert.Equal(t, "/admin", c.Path)
+	assert.True(t, c.Secure)
+	assert.True(t, c.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, c.SameSite)
+}
+
+func TestNewCookie_SecureBehindTrustedProxy(t *testing.T) {
+	cfg := &config.Config{CookieHttpOnly: true, TrustedProxies: "10.0.0.1"}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:51234"
+	req.Header.Set("X-Forwarded-Proto", "https")
+	assert.True(t, setCookie(t, NewCookie(cfg, req, "session", "abc", 0)).Secure)
+
+	req.RemoteAddr = "192.0.2.7:51234"
+	assert.False(t, setCookie(t, NewCookie(cfg, req, "session", "abc", 0)).Secure)
+}
+
+func TestCookieSameSite(t *testing.T) {
+	assert.Equal(t, http.SameSiteStrictMode, cookieSameSite("strict"))
+	assert.Equal(t, http.SameSiteLaxMode, cookieSameSite("Lax"))
+	assert.Equal(t, http.SameSiteNoneMode, cookieSameSite("none"))
+	assert.Equal(t, http.SameSiteStrictMode, cookieSameSite(""))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+)
+
+// HealthSummaryPath is where the aggregated health report is served
+const HealthSummaryPath = "/health/summary"
+
+// healthCheckTimeout bounds how long a single check may run
+const healthCheckTimeout = 5 * time.Second
+
+// DefaultMinFreeDiskBytes is the free space below which the disk check fails
+const DefaultMinFreeDiskBytes = 100 << 20
+
+// HealthCheck is a subsystem check reported by HealthSummaryHandler.
+// Check returns nil when the subsystem is healthy.
+type HealthCheck interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// funcHealthCheck adapts a function to the HealthCheck interface
+type funcHealthCheck struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c funcHealthCheck) Name() string                    { return c.name }
+func (c funcHealthCheck) Check(ctx context.Context) error { return c.fn(ctx) }
+
+// NewHealthCheck creates a HealthCheck named name that runs fn
+func NewHealthCheck(name string, fn func(ctx context.Context) error) HealthCheck {
+	return funcHealthCheck{name: name, fn: fn}
+}
+
+// DatabaseHealthCheck pings the database
+func DatabaseHealthCheck(dbManager *db.DBManager) HealthCheck {
+	return NewHealthCheck("database", dbManager.Ping)
+}
+
+// MigrationsHealthCheck fails when migrations are pending
+func MigrationsHealthCheck(runner *migrations.Runner) HealthCheck {
+	return NewHealthCheck("migrations", func(ctx context.Context) error {
+		pending, err := runner.GetPendingMigrations()
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending migration(s)", len(pending))
+		}
+		return nil
+	})
+}
+
+// UploadDirHealthCheck verifies that files can be created in the upload directory
+func UploadDirHealthCheck(dir string) HealthCheck {
+	return NewHealthCheck("uploads", func(ctx context.Context) error {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("upload directory is not accessible: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("upload path %s is not a directory", dir)
+		}
+		if err := checkWritable(dir); err != nil {
+			return fmt.Errorf("upload directory is not writable: %w", err)
+		}
+		return nil
+	})
+}
+
+// DiskSpaceHealthCheck fails when the filesystem holding path has less than minFree bytes available
+func DiskSpaceHealthCheck(path string, minFree uint64) HealthCheck {
+	return NewHealthCheck("disk_space", func(ctx context.Context) error {
+		free, err := freeDiskSpace(path)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("only %d MB free, need %d MB", free>>20, minFree>>20)
+		}
+		return nil
+	})
+}
+
+// healthSummary is the response body of the health summary endpoint
+type healthSummary struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks"`
+}
+
+// checkResult is the outcome of a single health check
+type checkResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// HealthSummaryHandler runs all checks concurrently and reports their status and latency.
+// It responds 200 with status "ok" when every check passes, and 503 with status "degraded"
+// otherwise, so it can be used directly by load balancers and uptime monitors. The endpoint
+// is public, so failure details are only logged, never included in the response.
+func HealthSummaryHandler(checks ...HealthCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		summary := healthSummary{Status: "ok", Checks: make(map[string]checkResult, len(checks))}
+
+		var (
+			wg sync.WaitGroup
+			mu sync.Mutex
+		)
+		for _, check := range checks {
+			wg.Add(1)
+			go func(check HealthCheck) {
+				defer wg.Done()
+				result := runHealthCheck(r.Context(), check)
+
+				mu.Lock()
+				defer mu.Unlock()
+				summary.Checks[check.Name()] = result
+				if result.Status != "ok" {
+					summary.Status = "degraded"
+				}
+			}(check)
+		}
+		wg.Wait()
+
+		status := http.StatusOK
+		if summary.Status != "ok" {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, summary)
+	}
+}
+
+// runHealthCheck runs a check with a timeout and measures its latency
+func runHealthCheck(ctx context.Context, check HealthCheck) checkResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := checkResult{
+		Status:    "ok",
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = "fail"
+		logger.L().WithError(err).WithField("check", check.Name()).Warn("Health check failed")
+	}
+	return result
+}
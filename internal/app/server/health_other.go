@@ -0,0 +1,25 @@
+//go:build !unix
+
+package server
+
+import (
+	"errors"
+	"os"
+)
+
+// freeDiskSpace is not implemented outside Unix-like systems
+func freeDiskSpace(path string) (uint64, error) {
+	return 0, errors.New("disk space check is not supported on this platform")
+}
+
+// checkWritable creates and removes a temporary file in dir, as there is no portable
+// access check outside Unix-like systems
+func checkWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".healthcheck-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeHealthSummary runs the handler and decodes its response
+func decodeHealthSummary(t *testing.T, handler http.Handler) (int, healthSummary) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HealthSummaryPath, nil))
+
+	var summary healthSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	return rec.Code, summary
+}
+
+func TestHealthSummaryHandler_AllPassing(t *testing.T) {
+	dbManager, err := db.NewManager(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { dbManager.Close() })
+	runner := migrations.NewRunner(dbManager.GetDB(), "../../../migrations")
+	require.NoError(t, runner.Run())
+
+	dir := t.TempDir()
+	code, summary := decodeHealthSummary(t, HealthSummaryHandler(
+		DatabaseHealthCheck(dbManager),
+		MigrationsHealthCheck(runner),
+		UploadDirHealthCheck(dir),
+		DiskSpaceHealthCheck(dir, 1),
+	))
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ok", summary.Status)
+	require.Len(t, summary.Checks, 4)
+	for name, result := range summary.Checks {
+		assert.Equal(t, "ok", result.Status, name)
+		assert.GreaterOrEqual(t, result.LatencyMS, 0.0, name)
+	}
+
+	// The writability check must not create files
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestHealthSummaryHandler_Degraded(t *testing.T) {
+	code, summary := decodeHealthSummary(t, HealthSummaryHandler(
+		NewHealthCheck("cache", func(ctx context.Context) error { return nil }),
+		NewHealthCheck("mailer", func(ctx context.Context) error { return errors.New("connection refused") }),
+		UploadDirHealthCheck(filepath.Join(t.TempDir(), "missing")),
+	))
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "degraded", summary.Status)
+	assert.Equal(t, "ok", summary.Checks["cache"].Status)
+	assert.Equal(t, "fail", summary.Checks["mailer"].Status)
+	assert.Equal(t, "fail", summary.Checks["uploads"].Status)
+}
+
+func TestHealthSummaryHandler_HidesErrors(t *testing.T) {
+	var buf bytes.Buffer
+	logger.Init("info", true, "", "")
+	logger.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.Init("info", false, "", "")
+		logger.SetOutput(os.Stdout)
+	})
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	rec := httptest.NewRecorder()
+	HealthSummaryHandler(UploadDirHealthCheck(missing)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HealthSummaryPath, nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotContains(t, rec.Body.String(), missing, "filesystem paths must not reach public clients")
+	assert.NotContains(t, rec.Body.String(), "error")
+	assert.Contains(t, buf.String(), missing, "the failure is logged server-side")
+	assert.Contains(t, buf.String(), `"check":"uploads"`)
+}
+
+func TestUploadDirHealthCheck_ReadOnly(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root bypasses directory permissions")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0555))
+	t.Cleanup(func() { os.Chmod(dir, 0755) })
+
+	assert.Error(t, UploadDirHealthCheck(dir).Check(context.Background()))
+}
+
+func TestMigrationsHealthCheck_Pending(t *testing.T) {
+	dbManager, err := db.NewManager(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { dbManager.Close() })
+
+	// A database that was never migrated must not be reported healthy
+	err = MigrationsHealthCheck(migrations.NewRunner(dbManager.GetDB(), "../../../migrations")).Check(context.Background())
+	assert.Error(t, err)
+}
+
+func TestDiskSpaceHealthCheck_BelowMinimum(t *testing.T) {
+	err := DiskSpaceHealthCheck(t.TempDir(), 1<<62).Check(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package server
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// freeDiskSpace returns the bytes available to unprivileged users on the filesystem holding path
+func freeDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
+
+// checkWritable asks the kernel whether the process may create files in dir, without
+// writing anything
+func checkWritable(dir string) error {
+	return unix.Access(dir, unix.W_OK|unix.X_OK)
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// Server timeouts. ReadHeaderTimeout is configurable via config.Config.
+const (
+	readTimeout  = 15 * time.Second
+	writeTimeout = 15 * time.Second
+	idleTimeout  = 60 * time.Second
+)
+
+// NewHTTPServer creates the HTTP server listening on the configured port.
+// ReadHeaderTimeout bounds how long a client may take to send request headers,
+// so slow-header (slowloris) clients cannot hold connections open indefinitely.
+func NewHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.Port,
+		Handler:           handler,
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout) * time.Second,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+}
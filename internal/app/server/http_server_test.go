@@ -0,0 +1,20 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHTTPServer(t *testing.T) {
+	srv := NewHTTPServer(&config.Config{Port: "9090", ReadHeaderTimeout: 5}, okHandler)
+
+	assert.Equal(t, ":9090", srv.Addr)
+	assert.NotNil(t, srv.Handler)
+	assert.Equal(t, 5*time.Second, srv.ReadHeaderTimeout)
+	assert.Equal(t, 15*time.Second, srv.ReadTimeout)
+	assert.Equal(t, 15*time.Second, srv.WriteTimeout)
+	assert.Equal(t, 60*time.Second, srv.IdleTimeout)
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IdempotencyKeyHeader lets clients mark a create request so that a retry or a
+// double-submitted form does not create the resource twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses replayed for a repeated key
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long a completed response is kept for replay
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// maxIdempotencyKeyLength bounds keys so clients cannot inflate the in-memory store
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotencyEntries bounds the number of remembered responses; when the store is full
+// the response closest to expiry is dropped to make room
+const maxIdempotencyEntries = 10000
+
+// DefaultIdempotencyMaxBodyBytes bounds the request body read to fingerprint a keyed
+// request when no limit is given; it matches the default MAX_UPLOAD_SIZE
+const DefaultIdempotencyMaxBodyBytes = 10 << 20
+
+// idempotencySweepInterval is how often expired responses are swept from the store;
+// in between, an expired entry is only dropped when its own key comes back
+const idempotencySweepInterval = time.Minute
+
+// IdempotencyMiddleware makes POST handlers safe to retry. The first request carrying an
+// Idempotency-Key header runs normally; a successful (2xx) response is remembered for ttl
+// and replayed, with Idempotent-Replayed: true, for later requests with the same key,
+// method, path and authenticated user instead of running the handler again. Reusing a key
+// with a different body gets 422. Failed responses are not kept so the client can retry
+// with the same key. A repeat that arrives while the first request is still running gets
+// 409. Requests without the header are passed through unchanged. Responses are held in
+// memory, at most maxIdempotencyEntries of them, so keys do not survive a restart; a
+// non-positive ttl uses DefaultIdempotencyTTL.
+//
+// The body of a keyed request is read up front to fingerprint it, so maxBodyBytes should
+// be the wrapped endpoint's own limit, such as the upload or import size; larger bodies
+// get 413. A non-positive maxBodyBytes uses DefaultIdempotencyMaxBodyBytes.
+func IdempotencyMiddleware(ttl time.Duration, maxBodyBytes int64) func(http.Handler) http.Handler {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	store := newIdempotencyStore(ttl, time.Now)
+	if maxBodyBytes > 0 {
+		store.maxBodyBytes = maxBodyBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return store.wrap(next)
+	}
+}
+
+// idempotencyStore holds the responses of keyed requests until they expire
+type idempotencyStore struct {
+	mu           sync.Mutex
+	ttl          time.Duration
+	now          func() time.Time
+	maxEntries   int
+	maxBodyBytes int64
+	nextSweep    time.Time
+	entries      map[string]*idempotentResponse
+}
+
+// idempotentResponse is a remembered response; done is false while the first request runs
+type idempotentResponse struct {
+	bodyHash    [sha256.Size]byte
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expires     time.Time
+}
+
+// expired reports whether a completed response is past its replay window
+func (e *idempotentResponse) expired(now time.Time) bool {
+	return e.done && now.After(e.expires)
+}
+
+func newIdempotencyStore(ttl time.Duration, now func() time.Time) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:          ttl,
+		now:          now,
+		maxEntries:   maxIdempotencyEntries,
+		maxBodyBytes: DefaultIdempotencyMaxBodyBytes,
+		entries:      make(map[string]*idempotentResponse),
+	}
+}
+
+func (s *idempotencyStore) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			writeJSONError(w, r, http.StatusBadRequest, "VALIDATION_ERROR",
+				"Idempotency-Key must be at most "+strconv.Itoa(maxIdempotencyKeyLength)+" characters")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeJSONError(w, r, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "The request body is too large")
+				return
+			}
+			writeJSONError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		bodyHash := sha256.Sum256(body)
+
+		scoped := r.Method + " " + r.URL.Path + " " + idempotencyUser(r) + " " + key
+		prior, started, full := s.begin(scoped, bodyHash)
+		if full {
+			writeJSONError(w, r, http.StatusTooManyRequests, "TOO_MANY_REQUESTS",
+				"Too many requests with an Idempotency-Key are being processed")
+			return
+		}
+		if !started {
+			if prior.bodyHash != bodyHash {
+				writeJSONError(w, r, http.StatusUnprocessableEntity, "VALIDATION_ERROR",
+					"Idempotency-Key was already used with a different request body")
+				return
+			}
+			if !prior.done {
+				writeJSONError(w, r, http.StatusConflict, "CONFLICT",
+					"A request with this Idempotency-Key is still being processed")
+				return
+			}
+			w.Header().Set("Content-Type", prior.contentType)
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			w.WriteHeader(prior.status)
+			w.Write(prior.body)
+			return
+		}
+
+		rec := &bodyRecorder{statusRecorder: statusRecorder{ResponseWriter: w, status: http.StatusOK}}
+		completed := false
+		defer func() {
+			if !completed || rec.status < 200 || rec.status > 299 {
+				s.abandon(scoped)
+				return
+			}
+			s.complete(scoped, bodyHash, rec.status, w.Header().Get("Content-Type"), rec.body.Bytes())
+		}()
+
+		next.ServeHTTP(rec, r)
+		completed = true
+	})
+}
+
+// idempotencyUser identifies the authenticated user so that keys of different users never
+// collide; anonymous requests share the empty scope
+func idempotencyUser(r *http.Request) string {
+	if user := UserFromContext(r.Context()); user != nil {
+		return strconv.Itoa(user.ID)
+	}
+	return ""
+}
+
+// begin reserves key for a new request body and reports started, or returns the live entry
+// for key. Expired entries are swept at most once per idempotencySweepInterval; when the
+// store is full the completed entry closest to expiry is evicted, and full is reported if
+// every entry is still running.
+func (s *idempotencyStore) begin(key string, bodyHash [sha256.Size]byte) (prior idempotentResponse, started, full bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !now.Before(s.nextSweep) {
+		s.sweep(now)
+		s.nextSweep = now.Add(idempotencySweepInterval)
+	}
+
+	if entry, ok := s.entries[key]; ok {
+		if !entry.expired(now) {
+			return *entry, false, false
+		}
+		delete(s.entries, key)
+	}
+	if len(s.entries) >= s.maxEntries && !s.evictOldest() {
+		return idempotentResponse{}, false, true
+	}
+	s.entries[key] = &idempotentResponse{bodyHash: bodyHash}
+	return idempotentResponse{}, true, false
+}
+
+// sweep drops every expired entry. The caller must hold s.mu.
+func (s *idempotencyStore) sweep(now time.Time) {
+	for k, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, k)
+		}
+	}
+}
+
+// evictOldest drops the completed entry that expires first, reporting false if there is none.
+// The caller must hold s.mu.
+func (s *idempotencyStore) evictOldest() bool {
+	oldest := ""
+	var expires time.Time
+	for k, entry := range s.entries {
+		if entry.done && (oldest == "" || entry.expires.Before(expires)) {
+			oldest, expires = k, entry.expires
+		}
+	}
+	if oldest == "" {
+		return false
+	}
+	delete(s.entries, oldest)
+	return true
+}
+
+// complete stores the response for key so that repeats replay it until it expires
+func (s *idempotencyStore) complete(key string, bodyHash [sha256.Size]byte, status int, contentType string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = &idempotentResponse{
+		bodyHash:    bodyHash,
+		done:        true,
+		status:      status,
+		contentType: contentType,
+		body:        body,
+		expires:     s.now().Add(s.ttl),
+	}
+}
+
+// abandon forgets key after a failed request so that the client can retry it
+func (s *idempotencyStore) abandon(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// bodyRecorder captures the status and a copy of the body written by the wrapped handler
+type bodyRecorder struct {
+	statusRecorder
+	body bytes.Buffer
+}
+
+func (b *bodyRecorder) Write(p []byte) (int, error) {
+	b.body.Write(p)
+	return b.statusRecorder.Write(p)
+}
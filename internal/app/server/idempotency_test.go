@@ -0,0 +1,263 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createdResource is the body written by the counting create handler
+type createdResource struct {
+	ID int64 `json:"id"`
+}
+
+// countingCreateHandler creates a new resource with the next ID on every call
+func countingCreateHandler(calls *atomic.Int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, createdResource{ID: calls.Add(1)})
+	})
+}
+
+func postWithKey(t *testing.T, h http.Handler, path, key string) (*httptest.ResponseRecorder, createdResource) {
+	t.Helper()
+	return postBodyWithKey(t, h, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{}")), key)
+}
+
+func postBodyWithKey(t *testing.T, h http.Handler, req *http.Request, key string) (*httptest.ResponseRecorder, createdResource) {
+	t.Helper()
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body createdResource
+	if rec.Code == http.StatusCreated {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	}
+	return rec, body
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("repeated key returns the original resource", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		first, created := postWithKey(t, h, "/items", "form-1")
+		again, replayed := postWithKey(t, h, "/items", "form-1")
+
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, http.StatusCreated, again.Code)
+		assert.Equal(t, created.ID, replayed.ID)
+		assert.Equal(t, int64(1), calls.Load())
+		assert.Equal(t, "application/json", again.Header().Get("Content-Type"))
+		assert.Equal(t, "true", again.Header().Get(IdempotentReplayedHeader))
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("distinct keys create distinct resources", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		_, a := postWithKey(t, h, "/items", "form-1")
+		_, b := postWithKey(t, h, "/items", "form-2")
+
+		assert.NotEqual(t, a.ID, b.ID)
+		assert.Equal(t, int64(2), calls.Load())
+	})
+
+	t.Run("same key on another path is independent", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		_, a := postWithKey(t, h, "/items", "form-1")
+		_, b := postWithKey(t, h, "/other", "form-1")
+
+		assert.NotEqual(t, a.ID, b.ID)
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		postWithKey(t, h, "/items", "")
+		postWithKey(t, h, "/items", "")
+
+		assert.Equal(t, int64(2), calls.Load())
+	})
+
+	t.Run("failed responses are not replayed", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed")
+				return
+			}
+			writeJSON(w, http.StatusCreated, createdResource{ID: 42})
+		}))
+
+		first, _ := postWithKey(t, h, "/items", "form-1")
+		retry, created := postWithKey(t, h, "/items", "form-1")
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusCreated, retry.Code)
+		assert.Equal(t, int64(42), created.ID)
+	})
+
+	t.Run("same key of another user is independent", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		post := func(userID int) createdResource {
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader("{}"))
+			req = req.WithContext(ContextWithUser(req.Context(), &models.User{ID: userID, Role: models.UserRoleNormal}))
+			_, created := postBodyWithKey(t, h, req, "form-1")
+			return created
+		}
+
+		a := post(1)
+		b := post(2)
+
+		assert.NotEqual(t, a.ID, b.ID)
+		assert.Equal(t, a.ID, post(1).ID)
+		assert.Equal(t, int64(2), calls.Load())
+	})
+
+	t.Run("key reused with another body rejected", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		postWithKey(t, h, "/items", "form-1")
+		rec, _ := postBodyWithKey(t, h, httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"title":"other"}`)), "form-1")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, int64(1), calls.Load())
+	})
+
+	t.Run("handler still reads the body", func(t *testing.T) {
+		var got string
+		h := IdempotencyMiddleware(time.Hour, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			got = string(body)
+			writeJSON(w, http.StatusCreated, createdResource{ID: 1})
+		}))
+
+		postWithKey(t, h, "/items", "form-1")
+
+		assert.Equal(t, "{}", got)
+	})
+
+	t.Run("overlong key rejected", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 0)(countingCreateHandler(&calls))
+
+		rec, _ := postWithKey(t, h, "/items", strings.Repeat("k", maxIdempotencyKeyLength+1))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Zero(t, calls.Load())
+	})
+
+	t.Run("body over the limit rejected", func(t *testing.T) {
+		var calls atomic.Int64
+		h := IdempotencyMiddleware(time.Hour, 16)(countingCreateHandler(&calls))
+
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(strings.Repeat("x", 17)))
+		rec, _ := postBodyWithKey(t, h, req, "form-1")
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Zero(t, calls.Load())
+	})
+}
+
+func TestIdempotencyStore_Expiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newIdempotencyStore(time.Minute, func() time.Time { return now })
+
+	var calls atomic.Int64
+	h := store.wrap(countingCreateHandler(&calls))
+
+	_, first := postWithKey(t, h, "/items", "form-1")
+
+	now = now.Add(30 * time.Second)
+	_, replayed := postWithKey(t, h, "/items", "form-1")
+	assert.Equal(t, first.ID, replayed.ID)
+
+	now = now.Add(time.Minute)
+	_, fresh := postWithKey(t, h, "/items", "form-1")
+	assert.NotEqual(t, first.ID, fresh.ID)
+}
+
+func TestIdempotencyStore_Sweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newIdempotencyStore(time.Second, func() time.Time { return now })
+
+	var calls atomic.Int64
+	h := store.wrap(countingCreateHandler(&calls))
+
+	postWithKey(t, h, "/items", "form-1")
+	now = now.Add(2 * time.Second)
+	postWithKey(t, h, "/items", "form-2")
+	assert.Len(t, store.entries, 2, "expired entries are not swept on every request")
+
+	now = now.Add(idempotencySweepInterval)
+	postWithKey(t, h, "/items", "form-3")
+	assert.Len(t, store.entries, 1, "expired entries are swept once the interval has passed")
+}
+
+func TestIdempotencyStore_InProgress(t *testing.T) {
+	store := newIdempotencyStore(time.Minute, time.Now)
+
+	_, started, _ := store.begin("POST /items  form-1", sha256.Sum256([]byte("{}")))
+	require.True(t, started)
+
+	var calls atomic.Int64
+	rec, _ := postWithKey(t, store.wrap(countingCreateHandler(&calls)), "/items", "form-1")
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Zero(t, calls.Load())
+}
+
+func TestIdempotencyStore_Capacity(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newIdempotencyStore(time.Minute, func() time.Time { return now })
+	store.maxEntries = 2
+
+	var calls atomic.Int64
+	h := store.wrap(countingCreateHandler(&calls))
+
+	t.Run("oldest completed response is evicted", func(t *testing.T) {
+		_, first := postWithKey(t, h, "/items", "form-1")
+		now = now.Add(time.Second)
+		_, second := postWithKey(t, h, "/items", "form-2")
+		now = now.Add(time.Second)
+		postWithKey(t, h, "/items", "form-3")
+
+		assert.Len(t, store.entries, 2)
+		_, again := postWithKey(t, h, "/items", "form-2")
+		assert.Equal(t, second.ID, again.ID)
+		_, fresh := postWithKey(t, h, "/items", "form-1")
+		assert.NotEqual(t, first.ID, fresh.ID, "evicted key runs again")
+	})
+
+	t.Run("full store of running requests rejects new keys", func(t *testing.T) {
+		store.entries = make(map[string]*idempotentResponse)
+		for _, key := range []string{"a", "b"} {
+			_, started, _ := store.begin(key, sha256.Sum256(nil))
+			require.True(t, started)
+		}
+
+		rec, _ := postWithKey(t, h, "/items", "form-4")
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	})
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// IntegrityCheckPath is the admin endpoint that runs the database integrity check
+const IntegrityCheckPath = "/admin/db/integrity"
+
+// IntegrityChecker reports database corruption; it is implemented by db.DBManager
+type IntegrityChecker interface {
+	IntegrityCheck(ctx context.Context) ([]string, error)
+}
+
+// integrityReport is the response body of the integrity check endpoint
+type integrityReport struct {
+	OK       bool     `json:"ok"`
+	Problems []string `json:"problems"`
+}
+
+// IntegrityCheckHandler runs the database integrity check and reports the problems found.
+// The check reads the whole database file, so it must only be mounted behind admin
+// authentication. A corrupt database is reported with 200 and ok=false; 500 means the
+// check itself could not run.
+func IntegrityCheckHandler(checker IntegrityChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSONError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+			return
+		}
+
+		problems, err := checker.IntegrityCheck(r.Context())
+		if err != nil {
+			logger.L().WithError(err).Error("Database integrity check failed")
+			writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to run the integrity check")
+			return
+		}
+
+		if len(problems) > 0 {
+			logger.L().WithField("problems", len(problems)).Warn("Database integrity check reported problems")
+		}
+		writeJSON(w, http.StatusOK, integrityReport{OK: len(problems) == 0, Problems: problems})
+	}
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIntegrityChecker returns fixed integrity check results
+type stubIntegrityChecker struct {
+	problems []string
+	err      error
+}
+
+func (s stubIntegrityChecker) IntegrityCheck(ctx context.Context) ([]string, error) {
+	return s.problems, s.err
+}
+
+func TestIntegrityCheckHandler(t *testing.T) {
+	get := func(checker IntegrityChecker) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		IntegrityCheckHandler(checker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, IntegrityCheckPath, nil))
+		return rec
+	}
+
+	t.Run("healthy database", func(t *testing.T) {
+		dbManager, err := db.NewManager(":memory:")
+		require.NoError(t, err)
+		t.Cleanup(func() { dbManager.Close() })
+
+		rec := get(dbManager)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"ok":true,"problems":[]}`, rec.Body.String())
+	})
+
+	t.Run("problems reported", func(t *testing.T) {
+		rec := get(stubIntegrityChecker{problems: []string{"row 3 missing from index idx_news"}})
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"ok":false,"problems":["row 3 missing from index idx_news"]}`, rec.Body.String())
+	})
+
+	t.Run("check failure", func(t *testing.T) {
+		rec := get(stubIntegrityChecker{err: errors.New("database is locked")})
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("only GET is allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		IntegrityCheckHandler(stubIntegrityChecker{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, IntegrityCheckPath, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"time"
+)
+
+// CheckNotModified sets the Last-Modified header from lastModified (for example
+// HomepageRepository.LastUpdated) and reports whether the client's copy is still fresh.
+// When it returns true a 304 Not Modified has been written and the handler must stop.
+// A zero lastModified disables the check.
+func CheckNotModified(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have second precision
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
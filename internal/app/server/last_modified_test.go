@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckNotModified(t *testing.T) {
+	lastModified := time.Date(2025, 3, 1, 12, 0, 0, 500, time.UTC)
+
+	check := func(method, ifModifiedSince string, last time.Time) (*httptest.ResponseRecorder, bool) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/", nil)
+		if ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", ifModifiedSince)
+		}
+		return rec, CheckNotModified(rec, req, last)
+	}
+
+	t.Run("no conditional header", func(t *testing.T) {
+		rec, notModified := check(http.MethodGet, "", lastModified)
+		assert.False(t, notModified)
+		assert.Equal(t, "Sat, 01 Mar 2025 12:00:00 GMT", rec.Header().Get("Last-Modified"))
+	})
+
+	t.Run("client copy is current", func(t *testing.T) {
+		rec, notModified := check(http.MethodGet, "Sat, 01 Mar 2025 12:00:00 GMT", lastModified)
+		assert.True(t, notModified)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+	})
+
+	t.Run("content changed since", func(t *testing.T) {
+		_, notModified := check(http.MethodGet, "Sat, 01 Mar 2025 11:59:59 GMT", lastModified)
+		assert.False(t, notModified)
+	})
+
+	t.Run("unsafe methods are never 304", func(t *testing.T) {
+		_, notModified := check(http.MethodPost, "Sat, 01 Mar 2025 12:00:00 GMT", lastModified)
+		assert.False(t, notModified)
+	})
+
+	t.Run("zero time disables the check", func(t *testing.T) {
+		rec, notModified := check(http.MethodGet, "Sat, 01 Mar 2025 12:00:00 GMT", time.Time{})
+		assert.False(t, notModified)
+		assert.Empty(t, rec.Header().Get("Last-Modified"))
+	})
+}
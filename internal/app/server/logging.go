@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// LoggingMiddleware writes an access log line for every request with its method, path,
+// status and duration. Requests to quietPaths (exact matches, typically health probes that
+// would otherwise flood the log) are logged at debug level instead of info, unless they
+// fail with a 5xx status so that a failing probe is still visible.
+func LoggingMiddleware(quietPaths ...string) func(http.Handler) http.Handler {
+	quiet := make(map[string]bool, len(quietPaths))
+	for _, path := range quietPaths {
+		quiet[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			log := accessLog(r, rec.status, start)
+			if quiet[r.URL.Path] && rec.status < http.StatusInternalServerError {
+				log.Debug("Request handled")
+				return
+			}
+			log.Info("Request handled")
+		})
+	}
+}
+
+// accessLog returns a logger carrying the access log fields of a handled request
+func accessLog(r *http.Request, status int, start time.Time) *logger.Logger {
+	return logger.L().WithRequestID(RequestIDFromContext(r.Context())).WithFields(map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"status":      status,
+		"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+	})
+}
+
+// statusRecorder captures the status code written by the wrapped handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if !s.wroteHeader {
+		s.status = code
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
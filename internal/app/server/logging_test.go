@@ -0,0 +1,85 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accessLogLine is the part of a JSON log line the logging tests look at
+type accessLogLine struct {
+	Level  string                 `json:"level"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// captureAccessLogs serves each path through LoggingMiddleware with the global logger at
+// level and returns the JSON log lines written
+func captureAccessLogs(t *testing.T, level string, handler http.Handler, paths ...string) []accessLogLine {
+	var buf bytes.Buffer
+	logger.Init(level, true, "", "")
+	logger.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.Init("info", false, "", "")
+		logger.SetOutput(os.Stdout)
+	})
+
+	h := LoggingMiddleware("/health", "/readyz")(handler)
+	for _, path := range paths {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	var lines []accessLogLine
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if raw == "" {
+			continue
+		}
+		var line accessLogLine
+		require.NoError(t, json.Unmarshal([]byte(raw), &line), raw)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	t.Run("normal path is logged at info", func(t *testing.T) {
+		lines := captureAccessLogs(t, "info", ok, "/publications")
+		require.Len(t, lines, 1)
+		assert.Equal(t, "info", lines[0].Level)
+		assert.Equal(t, "/publications", lines[0].Fields["path"])
+		assert.Equal(t, "GET", lines[0].Fields["method"])
+		assert.EqualValues(t, http.StatusOK, lines[0].Fields["status"])
+	})
+
+	t.Run("probe paths are skipped at info", func(t *testing.T) {
+		lines := captureAccessLogs(t, "info", ok, "/health", "/readyz", "/news")
+		require.Len(t, lines, 1)
+		assert.Equal(t, "/news", lines[0].Fields["path"])
+	})
+
+	t.Run("probe paths are logged at debug", func(t *testing.T) {
+		lines := captureAccessLogs(t, "debug", ok, "/health")
+		require.Len(t, lines, 1)
+		assert.Equal(t, "debug", lines[0].Level)
+	})
+
+	t.Run("failing probe is logged at info", func(t *testing.T) {
+		failing := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		lines := captureAccessLogs(t, "info", failing, "/health")
+		require.Len(t, lines, 1)
+		assert.Equal(t, "info", lines[0].Level)
+		assert.EqualValues(t, http.StatusServiceUnavailable, lines[0].Fields["status"])
+	})
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// MaintenancePath is the admin endpoint used to toggle maintenance mode.
+// It is exempt from the write block so that maintenance can be switched off again.
+const MaintenancePath = "/admin/maintenance"
+
+// maintenanceMode is the runtime read-only flag shared by the middleware and the admin endpoint
+var maintenanceMode atomic.Bool
+
+// IsMaintenanceMode reports whether the application is currently read-only
+func IsMaintenanceMode() bool {
+	return maintenanceMode.Load()
+}
+
+// SetMaintenanceMode enables or disables read-only maintenance mode at runtime
+func SetMaintenanceMode(enabled bool) {
+	if maintenanceMode.Swap(enabled) != enabled {
+		logger.L().WithField("maintenance_mode", enabled).Info("Maintenance mode changed")
+	}
+}
+
+// MaintenanceMiddleware blocks write requests with 503 while maintenance mode is on.
+// The initial state is taken from cfg.MaintenanceMode; afterwards it can be changed
+// at runtime with SetMaintenanceMode or through MaintenanceHandler.
+// Safe methods (GET, HEAD, OPTIONS) are always served so content stays readable.
+func MaintenanceMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	maintenanceMode.Store(cfg.MaintenanceMode)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maintenanceMode.Load() && isWriteMethod(r.Method) && r.URL.Path != MaintenancePath {
+				w.Header().Set("Retry-After", "120")
+				writeJSONError(w, r, http.StatusServiceUnavailable, "MAINTENANCE_MODE",
+					"The site is undergoing maintenance and is read-only. Please try again shortly.")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maintenanceStatus is the request and response body of the maintenance endpoint
+type maintenanceStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+// MaintenanceHandler reports (GET) or changes (POST/PUT) the maintenance mode flag.
+// It must only be mounted behind admin authentication.
+func MaintenanceHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			var body maintenanceStatus
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeJSONError(w, r, http.StatusBadRequest, "VALIDATION_ERROR",
+					`Request body must be JSON like {"enabled": true}`)
+				return
+			}
+			SetMaintenanceMode(body.Enabled)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST, PUT")
+			writeJSONError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, maintenanceStatus{Enabled: IsMaintenanceMode()})
+	}
+}
+
+// isWriteMethod returns true for HTTP methods that modify state
+func isWriteMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// okHandler is a terminal handler that always responds 200
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	handler := MaintenanceMiddleware(&config.Config{MaintenanceMode: true})(okHandler)
+
+	t.Run("writes are blocked", func(t *testing.T) {
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, "/admin/news", nil))
+
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code, method)
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), `"code":"MAINTENANCE_MODE"`)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+		}
+	})
+
+	t.Run("reads are allowed", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(method, "/news", nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code, method)
+		}
+	})
+
+	t.Run("disabled mode allows writes", func(t *testing.T) {
+		handler := MaintenanceMiddleware(&config.Config{MaintenanceMode: false})(okHandler)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/news", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestMaintenanceHandler_Toggle(t *testing.T) {
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	mux := http.NewServeMux()
+	mux.Handle(MaintenancePath, MaintenanceHandler())
+	mux.Handle("/", okHandler)
+	handler := MaintenanceMiddleware(&config.Config{})(mux)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, post("/admin/news", "").Code)
+
+	rec := post(MaintenancePath, `{"enabled":true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled":true}`, rec.Body.String())
+	assert.True(t, IsMaintenanceMode())
+
+	assert.Equal(t, http.StatusServiceUnavailable, post("/admin/news", "").Code)
+
+	// The toggle endpoint itself stays writable so maintenance can be lifted
+	rec = post(MaintenancePath, `{"enabled":false}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, IsMaintenanceMode())
+
+	assert.Equal(t, http.StatusOK, post("/admin/news", "").Code)
+}
+
+func TestMaintenanceHandler_Status(t *testing.T) {
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+	SetMaintenanceMode(true)
+
+	rec := httptest.NewRecorder()
+	MaintenanceHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, MaintenancePath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"enabled":true}`, rec.Body.String())
+}
+
+func TestMaintenanceHandler_InvalidBody(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, MaintenancePath, strings.NewReader("not json"))
+	MaintenanceHandler().ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.False(t, IsMaintenanceMode())
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// Middleware wraps an http.Handler with extra behaviour
+type Middleware func(http.Handler) http.Handler
+
+// Chain combines middlewares into one. The first middleware is the outermost: it sees the
+// request first and the response last.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// RecoveryMiddleware turns a panic in a handler into a 500 INTERNAL_ERROR response and an
+// error log line carrying the request ID and stack trace, so one bad request does not take
+// down the connection. http.ErrAbortHandler is re-raised, since it is the standard way for a
+// handler to abort a response on purpose.
+func RecoveryMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(rec)
+				}
+
+				logger.L().WithRequestID(RequestIDFromContext(r.Context())).
+					WithField("panic", fmt.Sprint(rec)).
+					WithField("stack", string(debug.Stack())).
+					Error("Handler panicked")
+				writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal server error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	final := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	})
+
+	Chain(tag("first"), tag("second"))(final).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, []string{"first", "second", "handler"}, order)
+
+	order = nil
+	Chain()(final).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, []string{"handler"}, order)
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	t.Run("panic becomes a logged 500", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger.Init("info", true, "", "")
+		logger.SetOutput(&buf)
+		t.Cleanup(func() {
+			logger.Init("info", false, "", "")
+			logger.SetOutput(os.Stdout)
+		})
+
+		h := Chain(RequestIDMiddleware(), RecoveryMiddleware())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/news", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "INTERNAL_ERROR")
+		assert.NotContains(t, rec.Body.String(), "boom", "the panic value is not sent to the client")
+
+		var line struct {
+			accessLogLine
+			RequestID string `json:"request_id"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &line), buf.String())
+		assert.Equal(t, "error", line.Level)
+		assert.Equal(t, "boom", line.Fields["panic"])
+		assert.Equal(t, rec.Header().Get(RequestIDHeader), line.RequestID)
+		assert.NotEmpty(t, line.Fields["stack"])
+	})
+
+	t.Run("passes through without a panic", func(t *testing.T) {
+		h := RecoveryMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("abort handler is re-raised", func(t *testing.T) {
+		h := RecoveryMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
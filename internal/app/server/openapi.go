@@ -0,0 +1,316 @@
+package server
+
+import (
+	"database/sql"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// OpenAPIPath is where the OpenAPI document is served
+const OpenAPIPath = "/api/openapi.json"
+
+// openAPIModels are the models exposed as schema components, keyed by component name
+var openAPIModels = map[string]reflect.Type{
+	"LabMember":              reflect.TypeOf(models.LabMember{}),
+	"Publication":            reflect.TypeOf(models.Publication{}),
+	"PublicationWithAuthors": reflect.TypeOf(models.PublicationWithAuthors{}),
+	"Project":                reflect.TypeOf(models.Project{}),
+	"ProjectWithRelations":   reflect.TypeOf(models.ProjectWithRelations{}),
+	"News":                   reflect.TypeOf(models.News{}),
+}
+
+var (
+	openAPIOnce     sync.Once
+	openAPIDocument map[string]interface{}
+)
+
+// OpenAPIHandler serves an OpenAPI 3 document describing the public read endpoints.
+// Model schemas are generated from the struct json and validate tags so they cannot
+// drift from the models; sql.Null* fields are described as the objects encoding/json
+// writes for them, such as {"String": "...", "Valid": true}.
+func OpenAPIHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		openAPIOnce.Do(func() {
+			openAPIDocument = buildOpenAPIDocument()
+		})
+		writeJSON(w, http.StatusOK, openAPIDocument)
+	}
+}
+
+// buildOpenAPIDocument assembles the full OpenAPI document
+func buildOpenAPIDocument() map[string]interface{} {
+	schemas := map[string]interface{}{
+		"Error": map[string]interface{}{
+			"type":     "object",
+			"required": []string{"error"},
+			"properties": map[string]interface{}{
+				"error": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"code", "message"},
+					"properties": map[string]interface{}{
+						"code":       map[string]interface{}{"type": "string"},
+						"message":    map[string]interface{}{"type": "string"},
+						"request_id": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+	for name, t := range openAPIModels {
+		schemas[name] = structSchema(t)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Lab CMS Public API",
+			"version":     "1.0.0",
+			"description": "Read-only access to the lab's public content.",
+		},
+		"paths": map[string]interface{}{
+			"/api/members":           listPath("List current lab members", "LabMember"),
+			"/api/members/{id}":      itemPath("Get a lab member", "LabMember"),
+			"/api/publications":      listPath("List publications, newest first", "Publication"),
+			"/api/publications/{id}": itemPath("Get a publication with its lab authors", "PublicationWithAuthors"),
+			PublicationSearchPath:    searchPath("Search publications by title, authors or venue, best matches first", "Publication"),
+			"/api/projects":          listPath("List research projects", "Project"),
+			"/api/projects/{id}":     itemPath("Get a project with its members and publications", "ProjectWithRelations"),
+			"/api/news":              listPath("List published news", "News"),
+			"/api/news/{id}":         itemPath("Get a published news item", "News"),
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+// searchPath describes a list endpoint that takes the search term in the q query parameter
+func searchPath(summary, component string) map[string]interface{} {
+	path := listPath(summary, component)
+	path["get"].(map[string]interface{})["parameters"] = []interface{}{
+		map[string]interface{}{
+			"name":     "q",
+			"in":       "query",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		},
+	}
+	return path
+}
+
+// listPath describes a GET endpoint returning an array of the given component
+func listPath(summary, component string) map[string]interface{} {
+	return map[string]interface{}{
+		"get": map[string]interface{}{
+			"summary": summary,
+			"responses": map[string]interface{}{
+				"200": jsonResponse("OK", map[string]interface{}{
+					"type":  "array",
+					"items": schemaRef(component),
+				}),
+			},
+		},
+	}
+}
+
+// itemPath describes a GET endpoint returning a single component looked up by id
+func itemPath(summary, component string) map[string]interface{} {
+	return map[string]interface{}{
+		"get": map[string]interface{}{
+			"summary": summary,
+			"parameters": []interface{}{
+				map[string]interface{}{
+					"name":     "id",
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]interface{}{"type": "integer"},
+				},
+			},
+			"responses": map[string]interface{}{
+				"200": jsonResponse("OK", schemaRef(component)),
+				"404": jsonResponse("Not found", schemaRef("Error")),
+			},
+		},
+	}
+}
+
+// jsonResponse describes a JSON response body with the given schema
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// schemaRef references a schema component by name
+func schemaRef(component string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + component}
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	nullStringType = reflect.TypeOf(sql.NullString{})
+	nullTimeType   = reflect.TypeOf(sql.NullTime{})
+	nullInt64Type  = reflect.TypeOf(sql.NullInt64{})
+)
+
+// structSchema builds an object schema from a struct's exported json fields.
+// Embedded structs are flattened, matching encoding/json.
+func structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+	collectFields(t, properties, &required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// collectFields adds the json properties of t to properties, recursing into embedded structs.
+// Fields without omitempty are always present in responses and are listed as required.
+func collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+			collectFields(field.Type, properties, required)
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = fieldSchema(field)
+		if !strings.Contains(opts, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullValueFields names the field that holds the value of each sql.Null* type
+var nullValueFields = map[reflect.Type]string{
+	nullStringType: "String",
+	nullTimeType:   "Time",
+	nullInt64Type:  "Int64",
+}
+
+// nullSchema describes a sql.Null* type as encoded by encoding/json: an object with the
+// value and a Valid flag that is false when the column is NULL
+func nullSchema(t reflect.Type, value map[string]interface{}) map[string]interface{} {
+	name := nullValueFields[t]
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			name:    value,
+			"Valid": map[string]interface{}{"type": "boolean"},
+		},
+		"required": []string{name, "Valid"},
+	}
+}
+
+// fieldSchema describes a single struct field, including constraints from its validate tag.
+// Constraints on sql.Null* fields apply to the wrapped value.
+func fieldSchema(field reflect.StructField) map[string]interface{} {
+	schema := typeSchema(field.Type)
+
+	target := schema
+	if name, ok := nullValueFields[field.Type]; ok {
+		target = schema["properties"].(map[string]interface{})[name].(map[string]interface{})
+	}
+
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "oneof":
+			target["enum"] = strings.Fields(value)
+		case "pubyear":
+			min, max := models.PublicationYearRange()
+			target["minimum"], target["maximum"] = min, max
+		case "authorslen":
+			target["maxLength"] = models.AuthorsTextMaxLength()
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			target[constraintName(target["type"], key)] = n
+		}
+	}
+
+	return schema
+}
+
+// constraintName maps a validator min/max rule to the matching OpenAPI keyword
+func constraintName(schemaType interface{}, rule string) string {
+	if schemaType == "string" {
+		if rule == "min" {
+			return "minLength"
+		}
+		return "maxLength"
+	}
+	if rule == "min" {
+		return "minimum"
+	}
+	return "maximum"
+}
+
+// typeSchema maps a Go type to its JSON schema
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch t {
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case nullStringType:
+		return nullSchema(t, map[string]interface{}{"type": "string"})
+	case nullTimeType:
+		return nullSchema(t, map[string]interface{}{"type": "string", "format": "date-time"})
+	case nullInt64Type:
+		return nullSchema(t, map[string]interface{}{"type": "integer"})
+	}
+
+	for name, model := range openAPIModels {
+		if t == model {
+			return schemaRef(name)
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Ptr:
+		schema := typeSchema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return map[string]interface{}{}
+	}
+}
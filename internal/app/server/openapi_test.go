@@ -0,0 +1,62 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	OpenAPIHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc struct {
+		OpenAPI    string                     `json:"openapi"`
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Type       string                    `json:"type"`
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	for _, name := range []string{"LabMember", "Publication", "PublicationWithAuthors", "Project", "ProjectWithRelations", "News", "Error"} {
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+	for _, path := range []string{"/api/members", "/api/publications", "/api/projects", "/api/news"} {
+		assert.Contains(t, doc.Paths, path)
+	}
+
+	t.Run("embedded fields are flattened", func(t *testing.T) {
+		withAuthors := doc.Components.Schemas["PublicationWithAuthors"]
+		assert.Contains(t, withAuthors.Properties, "title")
+		assert.Contains(t, withAuthors.Properties, "authors")
+	})
+
+	t.Run("null types are value and Valid objects", func(t *testing.T) {
+		venue := doc.Components.Schemas["Publication"].Properties["venue"]
+		assert.Equal(t, "object", venue["type"])
+		assert.ElementsMatch(t, []any{"String", "Valid"}, venue["required"])
+
+		properties := venue["properties"].(map[string]any)
+		assert.Equal(t, "string", properties["String"].(map[string]any)["type"])
+		assert.Equal(t, "boolean", properties["Valid"].(map[string]any)["type"])
+	})
+
+	t.Run("validate tags become constraints", func(t *testing.T) {
+		member := doc.Components.Schemas["LabMember"]
+		assert.ElementsMatch(t, []any{"PI", "Postdoc", "PhD", "Master", "Bachelor", "Researcher"}, member.Properties["role"]["enum"])
+		assert.Contains(t, member.Required, "name")
+	})
+}
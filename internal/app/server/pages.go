@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// ErrorPageData is the data passed to the HTML error templates
+type ErrorPageData struct {
+	StatusCode  int
+	Title       string
+	Message     string
+	Description string
+	RequestID   string
+}
+
+// HomePageData is the data passed to the home page template
+type HomePageData struct {
+	LabName string
+}
+
+// LabNamer provides the lab name shown on pages; it is implemented by
+// repository.LabSettingRepository
+type LabNamer interface {
+	LabName(ctx context.Context) (string, error)
+}
+
+// RespondNotFound reports that the requested resource (e.g. "page", "publication") does
+// not exist. API clients get the JSON error envelope; browsers get the HTML 404 page.
+// Requests under /api/ are always API requests; elsewhere the Accept header decides.
+func RespondNotFound(w http.ResponseWriter, r *http.Request, resource string) {
+	message := "The requested " + resource + " was not found"
+
+	if wantsJSON(r) {
+		writeJSONError(w, r, http.StatusNotFound, "NOT_FOUND", message)
+		return
+	}
+
+	writeHTML(w, http.StatusNotFound, notFoundTemplate(), ErrorPageData{
+		StatusCode: http.StatusNotFound,
+		Title:      "Page Not Found",
+		Message:    message,
+		RequestID:  RequestIDFromContext(r.Context()),
+	})
+}
+
+// wantsJSON reports whether the response to r should be JSON rather than HTML
+func wantsJSON(r *http.Request) bool {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		return true
+	}
+
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "application/json") && !strings.Contains(accept, "text/html")
+}
+
+// HomeHandler renders the home page template at "/" and the 404 page for any other
+// path that reaches it, since "/" is the catch-all pattern of the mux.
+func HomeHandler(t *Templates, labName LabNamer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			RespondNotFound(w, r, "page")
+			return
+		}
+
+		name, err := labName.LabName(r.Context())
+		if err != nil {
+			logger.L().WithError(err).Warn("Failed to load lab name, using the default")
+			name = models.DefaultLabName
+		}
+
+		writeHTML(w, http.StatusOK, t.Home, HomePageData{LabName: name})
+	}
+}
+
+// writeHTML renders tmpl with data as the response body with the given status code.
+// The template is rendered to a buffer first so a failure can still become a 500.
+func writeHTML(w http.ResponseWriter, status int, tmpl *template.Template, data interface{}) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		logger.L().WithError(err).WithField("template", tmpl.Name()).Error("Failed to render template")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// templatesDir is the repository's template directory, relative to this package
+const templatesDir = "../../../web/templates"
+
+// fakeLabNamer returns a fixed lab name or error
+type fakeLabNamer struct {
+	name string
+	err  error
+}
+
+func (f fakeLabNamer) LabName(ctx context.Context) (string, error) {
+	return f.name, f.err
+}
+
+func TestRespondNotFound_APIPathGetsJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/publications/999", nil)
+	req.Header.Set("Accept", "text/html")
+
+	RespondNotFound(rec, req, "publication")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body errorBody
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "NOT_FOUND", body.Error.Code)
+	assert.Contains(t, body.Error.Message, "publication")
+}
+
+func TestRespondNotFound_AcceptJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req.Header.Set("Accept", "application/json")
+
+	RespondNotFound(rec, req, "page")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func TestRespondNotFound_BrowserPathGetsHTML(t *testing.T) {
+	templates, err := LoadTemplates(templatesDir)
+	require.NoError(t, err)
+	UseTemplates(templates)
+	t.Cleanup(func() { UseTemplates(nil) })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/no-such-page", nil)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		RespondNotFound(w, r, "page")
+	})).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "Page Not Found")
+	assert.Contains(t, rec.Body.String(), "Request ID: "+rec.Header().Get(RequestIDHeader))
+}
+
+func TestRespondNotFound_FallbackHTML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	RespondNotFound(rec, httptest.NewRequest(http.MethodGet, "/missing", nil), "page")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<h1>Page Not Found</h1>")
+}
+
+func TestHomeHandler(t *testing.T) {
+	templates, err := LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	t.Run("renders lab name", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		HomeHandler(templates, fakeLabNamer{name: "Vision Lab"}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Welcome to Vision Lab")
+	})
+
+	t.Run("falls back to the default lab name", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		HomeHandler(templates, fakeLabNamer{err: errors.New("db down")}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Welcome to Research Lab")
+	})
+
+	t.Run("other paths are not found", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		HomeHandler(templates, fakeLabNamer{name: "Vision Lab"}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/unknown", nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+}
+
+func TestLoadTemplates_MissingDirectory(t *testing.T) {
+	_, err := LoadTemplates(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestLoadTemplates_DisplayTime(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "pages"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "errors"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pages", "home.html"), []byte(`Updated {{displayTime .}}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "errors", "404.html"), []byte(`{{.Title}}`), 0o644))
+
+	templates, err := LoadTemplates(dir)
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	models.SetDisplayLocation(loc)
+	t.Cleanup(func() { models.SetDisplayLocation(nil) })
+
+	var buf bytes.Buffer
+	require.NoError(t, templates.Home.Execute(&buf, time.Date(2024, 3, 1, 13, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "Updated 2024-03-01 14:30 CET", buf.String())
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// PageMeta describes where a page of results sits in the full result set
+type PageMeta struct {
+	Page       int  `json:"page"`        // Current page, starting at 1
+	PerPage    int  `json:"per_page"`    // Page size used for the query
+	Total      int  `json:"total"`       // Number of items across all pages
+	TotalPages int  `json:"total_pages"` // 0 when there are no items
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// Paginate computes page metadata for a LIMIT/OFFSET query returning one page of total items.
+// A non-positive limit is treated as a single page holding everything; a negative offset as 0.
+func Paginate(total, limit, offset int) PageMeta {
+	if offset < 0 {
+		offset = 0
+	}
+	if total < 0 {
+		total = 0
+	}
+	if limit <= 0 {
+		limit = max(total, 1)
+	}
+
+	meta := PageMeta{
+		Page:       offset/limit + 1,
+		PerPage:    limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}
+	meta.HasNext = offset+limit < total
+	meta.HasPrev = offset > 0
+	return meta
+}
+
+// SetHeaders exposes the metadata as X-Total-Count, X-Page and X-Total-Pages response headers.
+// It must be called before the response body is written.
+func (m PageMeta) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("X-Total-Count", strconv.Itoa(m.Total))
+	w.Header().Set("X-Page", strconv.Itoa(m.Page))
+	w.Header().Set("X-Total-Pages", strconv.Itoa(m.TotalPages))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, limit, offset int
+		want                 PageMeta
+	}{
+		{
+			name:  "exact fit first page",
+			total: 20, limit: 10, offset: 0,
+			want: PageMeta{Page: 1, PerPage: 10, Total: 20, TotalPages: 2, HasNext: true},
+		},
+		{
+			name:  "exact fit last page",
+			total: 20, limit: 10, offset: 10,
+			want: PageMeta{Page: 2, PerPage: 10, Total: 20, TotalPages: 2, HasPrev: true},
+		},
+		{
+			name:  "partial last page",
+			total: 25, limit: 10, offset: 20,
+			want: PageMeta{Page: 3, PerPage: 10, Total: 25, TotalPages: 3, HasPrev: true},
+		},
+		{
+			name:  "middle page",
+			total: 25, limit: 10, offset: 10,
+			want: PageMeta{Page: 2, PerPage: 10, Total: 25, TotalPages: 3, HasNext: true, HasPrev: true},
+		},
+		{
+			name:  "empty result",
+			total: 0, limit: 10, offset: 0,
+			want: PageMeta{Page: 1, PerPage: 10, Total: 0, TotalPages: 0},
+		},
+		{
+			name:  "offset past the end",
+			total: 5, limit: 10, offset: 30,
+			want: PageMeta{Page: 4, PerPage: 10, Total: 5, TotalPages: 1, HasPrev: true},
+		},
+		{
+			name:  "no limit is a single page",
+			total: 7, limit: 0, offset: 0,
+			want: PageMeta{Page: 1, PerPage: 7, Total: 7, TotalPages: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Paginate(tt.total, tt.limit, tt.offset))
+		})
+	}
+}
+
+func TestPageMeta_SetHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Paginate(25, 10, 20).SetHeaders(rec)
+
+	assert.Equal(t, "25", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, "3", rec.Header().Get("X-Page"))
+	assert.Equal(t, "3", rec.Header().Get("X-Total-Pages"))
+}
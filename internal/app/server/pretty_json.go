@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// PrettyJSONMiddleware indents JSON responses when the request has ?pretty=1, so API
+// output is readable in a browser while debugging. It is only active in development;
+// in production the parameter is ignored and responses stay compact.
+func PrettyJSONMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	if cfg.IsProduction() {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("pretty") != "1" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			buf := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(buf, r)
+
+			body := buf.body.Bytes()
+			if mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type")); mediaType == "application/json" {
+				var indented bytes.Buffer
+				if err := json.Indent(&indented, body, "", "  "); err == nil {
+					body = indented.Bytes()
+					w.Header().Del("Content-Length")
+				}
+			}
+
+			w.WriteHeader(buf.status)
+			w.Write(body)
+		})
+	}
+}
+
+// bufferedResponse holds back the status and body written by the wrapped handler
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(code int) {
+	b.status = code
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	return b.body.Write(p)
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyJSONMiddleware(t *testing.T) {
+	jsonHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": 1, "tags": []string{"a"}})
+	})
+	serve := func(env, target string, h http.Handler) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		PrettyJSONMiddleware(&config.Config{Env: env})(h).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("indented with param in development", func(t *testing.T) {
+		rec := serve("development", "/api/items?pretty=1", jsonHandler)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "{\n  \"id\": 1,\n  \"tags\": [\n    \"a\"\n  ]\n}\n", rec.Body.String())
+	})
+
+	t.Run("compact without param", func(t *testing.T) {
+		rec := serve("development", "/api/items", jsonHandler)
+
+		assert.Equal(t, "{\"id\":1,\"tags\":[\"a\"]}\n", rec.Body.String())
+	})
+
+	t.Run("param ignored in production", func(t *testing.T) {
+		rec := serve("production", "/api/items?pretty=1", jsonHandler)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "{\"id\":1,\"tags\":[\"a\"]}\n", rec.Body.String())
+	})
+
+	t.Run("non-JSON responses untouched", func(t *testing.T) {
+		rec := serve("development", "/?pretty=1", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, "<p>{\"not\":\"json\"}</p>")
+		}))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "<p>{\"not\":\"json\"}</p>", rec.Body.String())
+	})
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// IsSecureRequest reports whether the client reached the application over HTTPS.
+// A request served over TLS directly is always secure. Behind a TLS-terminating proxy the
+// X-Forwarded-Proto header is honoured only when the immediate peer is listed in
+// TRUSTED_PROXIES; from anyone else the header is client-controlled and ignored.
+func IsSecureRequest(cfg *config.Config, r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	if r.TLS != nil {
+		return true
+	}
+
+	// Chained proxies append their own value, to the same header line or a new one; only
+	// the last one was set by the trusted peer, anything before it may have come from the client
+	protos := strings.Split(strings.Join(r.Header.Values("X-Forwarded-Proto"), ","), ",")
+	if !strings.EqualFold(strings.TrimSpace(protos[len(protos)-1]), "https") {
+		return false
+	}
+
+	return isTrustedProxy(cfg.TrustedProxyNets(), r.RemoteAddr)
+}
+
+// isTrustedProxy reports whether remoteAddr (host:port or a bare IP) falls in one of nets
+func isTrustedProxy(nets []*net.IPNet, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the address of the client that sent r. When the immediate peer is a
+// trusted proxy, X-Forwarded-For is read from the right, skipping trusted proxies, and the
+// first other address is the client; entries further left were written by the client and
+// cannot be trusted. Otherwise the peer's own address is used.
+func clientIP(nets []*net.IPNet, r *http.Request) string {
+	ip := remoteIP(r)
+	if !isTrustedProxy(nets, r.RemoteAddr) {
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+		if !isTrustedProxy(nets, hop) {
+			break
+		}
+	}
+	return ip
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSecureRequest(t *testing.T) {
+	cfg := &config.Config{TrustedProxies: "127.0.0.1, 10.0.0.0/8, ::1"}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		proto      string
+		tls        bool
+		want       bool
+	}{
+		{"direct TLS", "192.0.2.7:443", "", true, true},
+		{"plain HTTP", "192.0.2.7:5000", "", false, false},
+		{"trusted proxy https", "127.0.0.1:5000", "https", false, true},
+		{"trusted proxy in range", "10.1.2.3:5000", "HTTPS", false, true},
+		{"trusted IPv6 proxy", "[::1]:5000", "https", false, true},
+		{"trusted proxy http", "127.0.0.1:5000", "http", false, false},
+		{"trusted proxy chain uses last value", "127.0.0.1:5000", "http, https", false, true},
+		{"client-supplied https is ignored", "127.0.0.1:5000", "https, http", false, false},
+		{"trusted proxy chain of three", "127.0.0.1:5000", "http,http, HTTPS", false, true},
+		{"untrusted peer https", "192.0.2.7:5000", "https", false, false},
+		{"untrusted peer outside range", "11.0.0.1:5000", "https", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			if tt.tls {
+				req.TLS = &tls.ConnectionState{}
+			} else {
+				req.TLS = nil
+			}
+
+			assert.Equal(t, tt.want, IsSecureRequest(cfg, req))
+		})
+	}
+}
+
+func TestIsSecureRequest_MultipleHeaderLines(t *testing.T) {
+	cfg := &config.Config{TrustedProxies: "127.0.0.1"}
+
+	secure := func(protos ...string) bool {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "127.0.0.1:5000"
+		for _, proto := range protos {
+			req.Header.Add("X-Forwarded-Proto", proto)
+		}
+		return IsSecureRequest(cfg, req)
+	}
+
+	assert.True(t, secure("http", "https"), "the proxy's own header line is the last one")
+	assert.False(t, secure("https", "http"), "a client-supplied first line is ignored")
+	assert.False(t, secure("https, https", "http"))
+}
+
+func TestIsSecureRequest_NoTrustedProxies(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "127.0.0.1:5000"
+	req.Header.Set("X-Forwarded-Proto", "https")
+
+	assert.False(t, IsSecureRequest(&config.Config{}, req), "X-Forwarded-Proto must be ignored when no proxy is trusted")
+	assert.False(t, IsSecureRequest(&config.Config{}, nil))
+}
+
+func TestClientIP(t *testing.T) {
+	nets := (&config.Config{TrustedProxies: "127.0.0.1, 10.0.0.0/8"}).TrustedProxyNets()
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		want       string
+	}{
+		{"direct client", "192.0.2.7:5000", "", "192.0.2.7"},
+		{"untrusted peer cannot spoof", "192.0.2.7:5000", "203.0.113.9", "192.0.2.7"},
+		{"trusted proxy", "127.0.0.1:5000", "203.0.113.9", "203.0.113.9"},
+		{"client-supplied entries are skipped", "127.0.0.1:5000", "198.51.100.1, 203.0.113.9", "203.0.113.9"},
+		{"chained trusted proxies", "127.0.0.1:5000", "203.0.113.9, 10.0.0.2", "203.0.113.9"},
+		{"trusted proxy without header", "127.0.0.1:5000", "", "127.0.0.1"},
+		{"malformed entry", "127.0.0.1:5000", "not-an-ip", "127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+
+			assert.Equal(t, tt.want, clientIP(nets, req))
+		})
+	}
+}
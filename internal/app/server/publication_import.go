@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	apperrors "github.com/nekoteoj/lab-cms/internal/pkg/errors"
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+)
+
+// PublicationImportPath is the admin endpoint that imports publications from BibTeX
+const PublicationImportPath = "/admin/publications/import"
+
+// maxImportBytes caps the size of an uploaded BibTeX file
+const maxImportBytes = 2 << 20
+
+// BibTeXImporter creates publications from raw BibTeX; it is implemented by
+// repository.PublicationRepository
+type BibTeXImporter interface {
+	ImportBibTeXBatch(ctx context.Context, raw string) (repository.BatchResult, error)
+}
+
+// PublicationImportHandler imports the BibTeX file sent as the POST body and responds
+// with the IDs of the created publications and the entries that were rejected.
+// Rejected entries do not fail the request; the response is 200 as long as the
+// file could be processed. It must only be mounted behind admin authentication.
+func PublicationImportHandler(importer BibTeXImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeJSONError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+			return
+		}
+
+		raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeJSONError(w, r, http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", "The BibTeX file is too large")
+				return
+			}
+			writeJSONError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "Could not read the BibTeX file")
+			return
+		}
+
+		result, err := importer.ImportBibTeXBatch(r.Context(), string(raw))
+		if err != nil {
+			logger.L().WithError(err).WithField("imported", len(result.ImportedIDs)).Error("BibTeX import failed")
+			if errors.Is(err, repository.ErrStorageFull) {
+				full := apperrors.StorageFull(err)
+				writeJSONError(w, r, full.StatusCode, full.Code, full.Message)
+				return
+			}
+			writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to import publications")
+			return
+		}
+
+		if len(result.ImportedIDs) == 0 && len(result.Errors) == 0 {
+			writeJSONError(w, r, http.StatusBadRequest, "VALIDATION_ERROR", "The file contains no BibTeX entries")
+			return
+		}
+
+		// Rejected entries quote BibTeX fields, which often contain URLs and "&"
+		writeJSON(w, http.StatusOK, result, withoutHTMLEscaping)
+	}
+}
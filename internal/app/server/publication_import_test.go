@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingImporter simulates a database failure during import
+type failingImporter struct{}
+
+func (failingImporter) ImportBibTeXBatch(ctx context.Context, raw string) (repository.BatchResult, error) {
+	return repository.BatchResult{}, errors.New("disk I/O error")
+}
+
+func TestPublicationImportHandler(t *testing.T) {
+	dbManager, err := db.NewManager(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { dbManager.Close() })
+	require.NoError(t, migrations.NewRunner(dbManager.GetDB(), "../../../migrations").Run())
+
+	handler := PublicationImportHandler(repository.NewPublicationRepository(dbManager))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PublicationImportPath, strings.NewReader(body)))
+		return rec
+	}
+
+	t.Run("mixed entries report partial success", func(t *testing.T) {
+		rec := post(`
+@article{ok, author = {Jane Doe}, title = {Imported}, year = 2024}
+@article{bad, title = {Oops}
+@article{noauthor, title = {No Authors}, year = 2022}
+`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var result repository.BatchResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		assert.Len(t, result.ImportedIDs, 1)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, "bad", result.Errors[0].Key)
+		assert.Equal(t, "noauthor", result.Errors[1].Key)
+		assert.Contains(t, result.Errors[1].Message, "invalid authorstext")
+	})
+
+	t.Run("empty file is rejected", func(t *testing.T) {
+		rec := post("% nothing here\n")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"VALIDATION_ERROR"`)
+	})
+
+	t.Run("only POST is allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PublicationImportPath, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "POST", rec.Header().Get("Allow"))
+	})
+}
+
+func TestPublicationImportHandler_DatabaseError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, PublicationImportPath, strings.NewReader("@article{a, title = {T}}"))
+	PublicationImportHandler(failingImporter{}).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "disk I/O")
+}
+
+// fullImporter simulates the disk filling up during import
+type fullImporter struct{}
+
+func (fullImporter) ImportBibTeXBatch(ctx context.Context, raw string) (repository.BatchResult, error) {
+	return repository.BatchResult{}, fmt.Errorf("create publication failed: %w", repository.ErrStorageFull)
+}
+
+func TestPublicationImportHandler_StorageFull(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, PublicationImportPath, strings.NewReader("@article{a, title = {T}}"))
+	PublicationImportHandler(fullImporter{}).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInsufficientStorage, rec.Code)
+	assert.Contains(t, rec.Body.String(), "STORAGE_FULL")
+}
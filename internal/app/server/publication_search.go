@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// PublicationSearchPath is the public endpoint that searches publications
+const PublicationSearchPath = "/api/publications/search"
+
+// PublicationSearcher finds publications matching a term; it is implemented by
+// repository.PublicationRepository
+type PublicationSearcher interface {
+	Search(ctx context.Context, term string, limit int) ([]models.Publication, error)
+}
+
+// PublicationSearchHandler responds to GET ?q=term with the publications whose title,
+// authors or venue contain the term, best matches first. At most limit results are
+// returned, so limit should come from SEARCH_RESULT_LIMIT. A blank term returns an
+// empty list.
+func PublicationSearchHandler(searcher PublicationSearcher, limit int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeJSONError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+			return
+		}
+
+		results, err := searcher.Search(r.Context(), r.URL.Query().Get("q"), limit)
+		if err != nil {
+			logger.L().WithError(err).Error("Publication search failed")
+			writeJSONError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to search publications")
+			return
+		}
+		if results == nil {
+			results = []models.Publication{}
+		}
+
+		writeJSON(w, http.StatusOK, results)
+	}
+}
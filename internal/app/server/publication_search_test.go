@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSearcher records the search it receives and returns fixed results
+type stubSearcher struct {
+	term    string
+	limit   int
+	results []models.Publication
+	err     error
+}
+
+func (s *stubSearcher) Search(ctx context.Context, term string, limit int) ([]models.Publication, error) {
+	s.term, s.limit = term, limit
+	return s.results, s.err
+}
+
+func TestPublicationSearchHandler(t *testing.T) {
+	get := func(h http.Handler, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	t.Run("searches with the configured limit", func(t *testing.T) {
+		searcher := &stubSearcher{results: []models.Publication{{ID: 1, Title: "Robotics"}}}
+
+		rec := get(PublicationSearchHandler(searcher, 20), PublicationSearchPath+"?q=robotics")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "robotics", searcher.term)
+		assert.Equal(t, 20, searcher.limit)
+
+		var results []models.Publication
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+		require.Len(t, results, 1)
+		assert.Equal(t, "Robotics", results[0].Title)
+	})
+
+	t.Run("no results is an empty list", func(t *testing.T) {
+		rec := get(PublicationSearchHandler(&stubSearcher{}, 20), PublicationSearchPath+"?q=")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, "[]", rec.Body.String())
+	})
+
+	t.Run("search failure", func(t *testing.T) {
+		rec := get(PublicationSearchHandler(&stubSearcher{err: errors.New("disk I/O error")}, 20), PublicationSearchPath+"?q=x")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Body.String(), "INTERNAL_ERROR")
+	})
+
+	t.Run("POST not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		PublicationSearchHandler(&stubSearcher{}, 20).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PublicationSearchPath, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// RequestIDHeader carries the request ID on requests from trusted proxies and on responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds incoming request IDs so clients cannot inflate logs
+const maxRequestIDLength = 64
+
+// RequestIDMiddleware assigns every request an ID, stores it in the request context and
+// echoes it in the X-Request-ID response header. An incoming X-Request-ID (e.g. set by a
+// reverse proxy) is reused when it is short and only contains letters, digits, '-' and '_'.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+			next.ServeHTTP(w, r.WithContext(logger.ContextWithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+// RequestIDFromContext returns the request ID set by RequestIDMiddleware, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	return logger.RequestIDFromContext(ctx)
+}
+
+// newRequestID returns a random 128-bit hex ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID reports whether an incoming request ID is safe to reuse
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
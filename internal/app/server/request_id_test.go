@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingHandler always responds with a JSON error
+var failingHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	writeJSONError(w, r, http.StatusNotFound, "NOT_FOUND", "Page not found")
+})
+
+func TestRequestIDMiddleware_ErrorBody(t *testing.T) {
+	t.Run("request id present with middleware", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		RequestIDMiddleware()(failingHandler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		var body errorBody
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Len(t, body.Error.RequestID, 32)
+		assert.Equal(t, rec.Header().Get(RequestIDHeader), body.Error.RequestID)
+	})
+
+	t.Run("request id absent without middleware", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		failingHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.NotContains(t, rec.Body.String(), "request_id")
+	})
+}
+
+func TestRequestIDMiddleware_IncomingHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		reused   bool
+	}{
+		{"valid id is reused", "proxy-abc_123", true},
+		{"unsafe id is replaced", "abc\r\nX-Injected: 1", false},
+		{"long id is replaced", string(make([]byte, maxRequestIDLength+1)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(RequestIDHeader, tt.incoming)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.NotEmpty(t, seen)
+			assert.Equal(t, tt.reused, seen == tt.incoming)
+			assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+		})
+	}
+}
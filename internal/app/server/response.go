@@ -0,0 +1,52 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+)
+
+// errorBody is the JSON envelope used for error responses
+type errorBody struct {
+	Error errorPayload `json:"error"`
+}
+
+// errorPayload describes a single error returned to API clients.
+// RequestID lets users quote the failing request in support tickets.
+type errorPayload struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// jsonOption adjusts how writeJSON encodes a response body
+type jsonOption func(enc *json.Encoder)
+
+// withoutHTMLEscaping leaves <, > and & unescaped so that URLs and author lists reach
+// API consumers verbatim. Only use it for responses that are never embedded in HTML;
+// application/json bodies served with nosniff are safe.
+func withoutHTMLEscaping(enc *json.Encoder) {
+	enc.SetEscapeHTML(false)
+}
+
+// writeJSON encodes v as the JSON response body with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}, opts ...jsonOption) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	for _, opt := range opts {
+		opt(enc)
+	}
+	if err := enc.Encode(v); err != nil {
+		logger.L().WithError(err).Error("Failed to encode JSON response")
+	}
+}
+
+// writeJSONError writes an error envelope with a machine-readable code, a message
+// that is safe to show to users and, when RequestIDMiddleware ran, the request ID
+func writeJSONError(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	writeJSON(w, status, errorBody{
+		Error: errorPayload{Code: code, Message: message, RequestID: RequestIDFromContext(r.Context())},
+	})
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON_HTMLEscaping(t *testing.T) {
+	body := map[string]string{"url": "https://example.org/paper?id=1&format=pdf"}
+
+	t.Run("escaped by default", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writeJSON(rec, http.StatusOK, body)
+
+		assert.Equal(t, `{"url":"https://example.org/paper?id=1\u0026format=pdf"}`+"\n", rec.Body.String())
+	})
+
+	t.Run("unescaped on request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		writeJSON(rec, http.StatusOK, body, withoutHTMLEscaping)
+
+		assert.Equal(t, `{"url":"https://example.org/paper?id=1&format=pdf"}`+"\n", rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	})
+}
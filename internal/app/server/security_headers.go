@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// hstsHeaderValue pins HTTPS for two years, including subdomains
+const hstsHeaderValue = "max-age=63072000; includeSubDomains"
+
+// SecurityHeadersMiddleware sets browser security headers on every response.
+// The Content-Security-Policy comes from cfg.ContentSecurityPolicy and is omitted
+// when that value is empty. Strict-Transport-Security is only sent in production
+// with secure cookies, so development on http://localhost is never pinned to HTTPS.
+func SecurityHeadersMiddleware(cfg *config.Config) func(http.Handler) http.Handler {
+	sendHSTS := cfg.IsProduction() && cfg.CookieSecure
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h := w.Header()
+			h.Set("X-Content-Type-Options", "nosniff")
+			h.Set("X-Frame-Options", "DENY")
+			h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+
+			if cfg.ContentSecurityPolicy != "" {
+				h.Set("Content-Security-Policy", cfg.ContentSecurityPolicy)
+			}
+			if sendHSTS {
+				h.Set("Strict-Transport-Security", hstsHeaderValue)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveWithSecurityHeaders runs a GET request through SecurityHeadersMiddleware
+func serveWithSecurityHeaders(cfg *config.Config) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	SecurityHeadersMiddleware(cfg)(okHandler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec
+}
+
+func TestSecurityHeadersMiddleware_BaseHeaders(t *testing.T) {
+	rec := serveWithSecurityHeaders(&config.Config{})
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+	assert.NotEmpty(t, rec.Header().Get("Referrer-Policy"))
+}
+
+func TestSecurityHeadersMiddleware_ContentSecurityPolicy(t *testing.T) {
+	t.Run("default policy", func(t *testing.T) {
+		rec := serveWithSecurityHeaders(&config.Config{
+			ContentSecurityPolicy: config.DefaultContentSecurityPolicy,
+		})
+		assert.Equal(t, "default-src 'self'", rec.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("custom policy", func(t *testing.T) {
+		policy := "default-src 'self'; img-src 'self' https://cdn.example.com"
+		rec := serveWithSecurityHeaders(&config.Config{ContentSecurityPolicy: policy})
+		assert.Equal(t, policy, rec.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("empty policy omits header", func(t *testing.T) {
+		rec := serveWithSecurityHeaders(&config.Config{ContentSecurityPolicy: ""})
+		_, present := rec.Header()["Content-Security-Policy"]
+		assert.False(t, present)
+	})
+}
+
+func TestSecurityHeadersMiddleware_HSTS(t *testing.T) {
+	t.Run("present in production with secure cookies", func(t *testing.T) {
+		rec := serveWithSecurityHeaders(&config.Config{Env: "production", CookieSecure: true})
+		assert.Equal(t, "max-age=63072000; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("absent in development", func(t *testing.T) {
+		rec := serveWithSecurityHeaders(&config.Config{Env: "development", CookieSecure: true})
+		assert.Empty(t, rec.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("absent in production without secure cookies", func(t *testing.T) {
+		rec := serveWithSecurityHeaders(&config.Config{Env: "production", CookieSecure: false})
+		assert.Empty(t, rec.Header().Get("Strict-Transport-Security"))
+	})
+}
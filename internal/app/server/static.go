@@ -0,0 +1,20 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+)
+
+// StaticPath is the URL prefix static assets are served under
+const StaticPath = "/static/"
+
+// RegisterStatic mounts a file server for dir at StaticPath when cfg.ServeStatic is set.
+// Deployments that serve assets from a CDN turn it off so the app does not expose them;
+// requests under StaticPath then fall through to the mux's other routes.
+func RegisterStatic(mux *http.ServeMux, cfg *config.Config, dir string) {
+	if !cfg.ServeStatic {
+		return
+	}
+	mux.Handle(StaticPath, http.StripPrefix(StaticPath, http.FileServer(http.Dir(dir))))
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterStatic(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "site.css"), []byte("body{}"), 0o644))
+
+	newMux := func(serve bool) *http.ServeMux {
+		mux := http.NewServeMux()
+		RegisterStatic(mux, &config.Config{ServeStatic: serve}, dir)
+		mux.Handle("/", http.NotFoundHandler())
+		return mux
+	}
+
+	t.Run("enabled serves files", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newMux(true).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/site.css", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "body{}", rec.Body.String())
+	})
+
+	t.Run("disabled leaves route unregistered", func(t *testing.T) {
+		mux := newMux(false)
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/static/site.css", nil)
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		_, pattern := mux.Handler(req)
+		assert.Equal(t, "/", pattern)
+	})
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sync"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// Templates holds the HTML templates for the server-rendered pages.
+// They are parsed from TEMPLATES_PATH at startup, so a deployment can restyle the home
+// and error pages by pointing it at its own copies.
+type Templates struct {
+	Home     *template.Template
+	NotFound *template.Template
+}
+
+// templateFuncs are the functions available to every page template. displayTime shows a
+// stored timestamp in DISPLAY_TIMEZONE, e.g. {{displayTime .UpdatedAt}}.
+var templateFuncs = template.FuncMap{
+	"displayTime": models.FormatDisplayTime,
+}
+
+// LoadTemplates parses pages/home.html and errors/404.html from dir
+func LoadTemplates(dir string) (*Templates, error) {
+	home, err := parseTemplate(filepath.Join(dir, "pages", "home.html"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse home template: %w", err)
+	}
+
+	notFound, err := parseTemplate(filepath.Join(dir, "errors", "404.html"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse 404 template: %w", err)
+	}
+
+	return &Templates{Home: home, NotFound: notFound}, nil
+}
+
+// parseTemplate parses the template file at path with templateFuncs available
+func parseTemplate(path string) (*template.Template, error) {
+	return template.New(filepath.Base(path)).Funcs(templateFuncs).ParseFiles(path)
+}
+
+var (
+	templatesMu     sync.RWMutex
+	activeTemplates *Templates
+)
+
+// UseTemplates makes RespondNotFound render HTML pages with t.
+// Until it is called a minimal built-in page is used.
+func UseTemplates(t *Templates) {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	activeTemplates = t
+}
+
+// notFoundTemplate returns the 404 page template in use
+func notFoundTemplate() *template.Template {
+	templatesMu.RLock()
+	defer templatesMu.RUnlock()
+	if activeTemplates == nil || activeTemplates.NotFound == nil {
+		return fallbackNotFoundTemplate
+	}
+	return activeTemplates.NotFound
+}
+
+// fallbackNotFoundTemplate is used when no templates have been loaded
+var fallbackNotFoundTemplate = template.Must(template.New("404").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><title>404 - {{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Message}}</p>
+{{if .RequestID}}<p>Request ID: {{.RequestID}}</p>{{end}}
+</body>
+</html>
+`))
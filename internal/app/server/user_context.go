@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	apperrors "github.com/nekoteoj/lab-cms/internal/pkg/errors"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+)
+
+// userKey and roleKey are the context keys for the authenticated user and their role
+type (
+	userKey struct{}
+	roleKey struct{}
+)
+
+// ContextWithUser returns a copy of ctx carrying the authenticated user and, separately, their
+// role so handlers that only need an authorization check do not have to look at the full user.
+// The user is also set as the actor recorded in the audit log for content changes made with
+// the returned context. It is called once authentication succeeds; a nil user returns ctx unchanged.
+func ContextWithUser(ctx context.Context, user *models.User) context.Context {
+	if user == nil {
+		return ctx
+	}
+	ctx = repository.WithActor(ctx, user.ID)
+	ctx = context.WithValue(ctx, userKey{}, user)
+	return context.WithValue(ctx, roleKey{}, user.Role)
+}
+
+// UserFromContext returns the user stored by ContextWithUser, or nil if the request is not authenticated
+func UserFromContext(ctx context.Context) *models.User {
+	user, _ := ctx.Value(userKey{}).(*models.User)
+	return user
+}
+
+// RoleFromContext returns the authenticated user's role; ok is false if the request is not authenticated
+func RoleFromContext(ctx context.Context) (models.UserRole, bool) {
+	role, ok := ctx.Value(roleKey{}).(models.UserRole)
+	return role, ok
+}
+
+// requireRole writes 401 for unauthenticated requests and 403 when the user's role is not
+// role, reporting whether the handler may go on. action names the operation in the 403 details.
+func requireRole(w http.ResponseWriter, r *http.Request, role models.UserRole, action string) bool {
+	current, ok := RoleFromContext(r.Context())
+	if !ok {
+		err := apperrors.Unauthorized("")
+		writeJSONError(w, r, err.StatusCode, err.Code, err.Message)
+		return false
+	}
+	if current != role {
+		err := apperrors.Forbidden(action)
+		writeJSONError(w, r, err.StatusCode, err.Code, err.Message)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleFromContext(t *testing.T) {
+	t.Run("role present after authentication", func(t *testing.T) {
+		user := &models.User{ID: 7, Email: "admin@example.com", Role: models.UserRoleRoot}
+		ctx := ContextWithUser(context.Background(), user)
+
+		role, ok := RoleFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, models.UserRoleRoot, role)
+		assert.Same(t, user, UserFromContext(ctx))
+	})
+
+	t.Run("role absent without authentication", func(t *testing.T) {
+		role, ok := RoleFromContext(context.Background())
+		assert.False(t, ok)
+		assert.Empty(t, role)
+		assert.Nil(t, UserFromContext(context.Background()))
+	})
+
+	t.Run("nil user leaves context unauthenticated", func(t *testing.T) {
+		ctx := ContextWithUser(context.Background(), nil)
+
+		_, ok := RoleFromContext(ctx)
+		assert.False(t, ok)
+		assert.Nil(t, UserFromContext(ctx))
+	})
+}
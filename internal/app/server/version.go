@@ -0,0 +1,27 @@
+package server
+
+import "net/http"
+
+// HealthPath is where the liveness endpoint is served
+const HealthPath = "/health"
+
+// BuildInfo identifies the running build. The values are injected at link time
+// through -ldflags; see the Makefile build target.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+}
+
+// healthResponse is the response body of the liveness endpoint
+type healthResponse struct {
+	Status string `json:"status"`
+	BuildInfo
+}
+
+// HealthHandler reports that the server is up along with the build it is running
+func HealthHandler(info BuildInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, healthResponse{Status: "healthy", BuildInfo: info})
+	}
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_IncludesBuildInfo(t *testing.T) {
+	info := BuildInfo{Version: "v1.2.3", Commit: "abc1234", BuildDate: "2024-05-01T12:00:00Z"}
+
+	rec := httptest.NewRecorder()
+	HealthHandler(info).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, HealthPath, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]string{
+		"status":     "healthy",
+		"version":    "v1.2.3",
+		"commit":     "abc1234",
+		"build_date": "2024-05-01T12:00:00Z",
+	}, body)
+}
@@ -0,0 +1,176 @@
+// Package auth provides password hashing and credential verification for admin users.
+// Passwords are stored as bcrypt hashes; hashes created with a lower cost than the
+// configured one are transparently upgraded when the user next logs in.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+)
+
+// ErrInvalidCredentials is returned when the email is unknown or the password is wrong.
+// Both cases share one error so callers cannot reveal which accounts exist.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// Service verifies user credentials against the user repository.
+type Service struct {
+	users repository.UserAuthRepository
+	cost  int
+
+	dummyOnce sync.Once
+	dummyHash []byte
+}
+
+// NewService creates an authentication service hashing passwords with the given bcrypt cost.
+func NewService(users repository.UserAuthRepository, cost int) *Service {
+	return &Service{
+		users: users,
+		cost:  cost,
+	}
+}
+
+// HashPassword hashes a plaintext password with the given bcrypt cost.
+func HashPassword(password string, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// NeedsRehash reports whether a stored hash was created with a cost below the given cost.
+// Hashes that cannot be parsed also need rehashing.
+func NeedsRehash(hash string, cost int) bool {
+	hashCost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return hashCost < cost
+}
+
+var (
+	defaultRoleMu sync.RWMutex
+	defaultRole   = models.UserRoleNormal
+)
+
+// SetDefaultUserRole sets the role CreateUser assigns when none is given. It is called
+// once at startup from the configuration; an empty role keeps models.UserRoleNormal.
+func SetDefaultUserRole(role models.UserRole) {
+	if role == "" {
+		role = models.UserRoleNormal
+	}
+
+	defaultRoleMu.Lock()
+	defer defaultRoleMu.Unlock()
+	defaultRole = role
+}
+
+// DefaultUserRole returns the role CreateUser assigns when none is given.
+func DefaultUserRole() models.UserRole {
+	defaultRoleMu.RLock()
+	defer defaultRoleMu.RUnlock()
+	return defaultRole
+}
+
+// CreateUser stores a new user after checking the password against the password policy.
+// An empty role is replaced with DefaultUserRole.
+func (s *Service) CreateUser(ctx context.Context, email string, role models.UserRole, password string) (*models.User, error) {
+	if err := ValidatePasswordStrength(password); err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		role = DefaultUserRole()
+	}
+
+	hash, err := HashPassword(password, s.cost)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: email, Role: role},
+		PasswordHash: hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User, nil
+}
+
+// ChangePassword sets a new password for a user after checking it against the password policy.
+func (s *Service) ChangePassword(ctx context.Context, userID int, password string) error {
+	if err := ValidatePasswordStrength(password); err != nil {
+		return err
+	}
+
+	hash, err := HashPassword(password, s.cost)
+	if err != nil {
+		return err
+	}
+
+	return s.users.UpdatePassword(ctx, userID, hash)
+}
+
+// Login verifies an email and password and returns the authenticated user.
+// Unknown emails still go through a bcrypt comparison against a dummy hash so that
+// response timing does not reveal whether an account exists.
+// When the stored hash uses a lower cost than the service's cost, the password is
+// rehashed and saved; a failed upgrade is logged but does not fail the login. A successful
+// login also records the user's last login time.
+func (s *Service) Login(ctx context.Context, email, password string) (*models.User, error) {
+	user, err := s.users.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		_ = bcrypt.CompareHashAndPassword(s.dummy(), []byte(password))
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if NeedsRehash(user.PasswordHash, s.cost) {
+		s.upgradeHash(ctx, user.ID, password)
+	}
+
+	// A failure to record the login must not lock the user out
+	if err := s.users.RecordLogin(ctx, user.ID); err != nil {
+		logger.L().WithUserID(int64(user.ID)).Warnf("Failed to record login: %v", err)
+	}
+
+	return &user.User, nil
+}
+
+// upgradeHash rehashes the password with the current cost and stores it.
+func (s *Service) upgradeHash(ctx context.Context, userID int, password string) {
+	hash, err := HashPassword(password, s.cost)
+	if err == nil {
+		err = s.users.UpdatePassword(ctx, userID, hash)
+	}
+	if err != nil {
+		logger.L().WithUserID(int64(userID)).Warnf("Failed to upgrade password hash: %v", err)
+		return
+	}
+	logger.L().WithUserID(int64(userID)).WithField("cost", s.cost).Info("Password hash upgraded")
+}
+
+// dummy returns a hash with the service's cost used to equalize timing for unknown users.
+func (s *Service) dummy() []byte {
+	s.dummyOnce.Do(func() {
+		s.dummyHash, _ = bcrypt.GenerateFromPassword([]byte("lab-cms-dummy-password"), s.cost)
+	})
+	return s.dummyHash
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+)
+
+var ctx = context.Background()
+
+// testCost keeps hashing fast in tests while still being above bcrypt.MinCost
+const testCost = bcrypt.MinCost + 1
+
+// setupUsers creates a migrated in-memory database and returns its user repository
+func setupUsers(t *testing.T) *repository.UserRepository {
+	dbManager, err := db.NewManager(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		dbManager.Close()
+	})
+
+	runner := migrations.NewRunner(dbManager.GetDB(), "../../../migrations")
+	require.NoError(t, runner.Run())
+
+	return repository.NewUserRepository(dbManager)
+}
+
+// createUser stores a user whose password is hashed with the given cost
+func createUser(t *testing.T, users *repository.UserRepository, email, password string, cost int) *models.UserWithPassword {
+	hash, err := HashPassword(password, cost)
+	require.NoError(t, err)
+
+	user, err := users.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: email, Role: models.UserRoleNormal},
+		PasswordHash: hash,
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestService_Login(t *testing.T) {
+	users := setupUsers(t)
+	service := NewService(users, testCost)
+	created := createUser(t, users, "user@example.com", "correct-password", testCost)
+
+	t.Run("valid credentials", func(t *testing.T) {
+		user, err := service.Login(ctx, "user@example.com", "correct-password")
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, user.ID)
+
+		stored, err := users.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.LastLoginAt.Valid, "successful login is recorded")
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		_, err := service.Login(ctx, "user@example.com", "wrong-password")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		_, err := service.Login(ctx, "nobody@example.com", "correct-password")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestService_Login_UpgradesLowCostHash(t *testing.T) {
+	users := setupUsers(t)
+	createUser(t, users, "old@example.com", "correct-password", bcrypt.MinCost)
+
+	_, err := NewService(users, testCost).Login(ctx, "old@example.com", "correct-password")
+	require.NoError(t, err)
+
+	stored, err := users.GetByEmail(ctx, "old@example.com")
+	require.NoError(t, err)
+	cost, err := bcrypt.Cost([]byte(stored.PasswordHash))
+	require.NoError(t, err)
+	assert.Equal(t, testCost, cost)
+
+	// The upgraded hash still verifies the same password
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.PasswordHash), []byte("correct-password")))
+}
+
+func TestService_Login_KeepsCurrentCostHash(t *testing.T) {
+	users := setupUsers(t)
+	created := createUser(t, users, "current@example.com", "correct-password", testCost)
+
+	_, err := NewService(users, testCost).Login(ctx, "current@example.com", "correct-password")
+	require.NoError(t, err)
+
+	stored, err := users.GetByEmail(ctx, "current@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, created.PasswordHash, stored.PasswordHash)
+}
+
+func TestService_Login_FailedLoginDoesNotUpgrade(t *testing.T) {
+	users := setupUsers(t)
+	created := createUser(t, users, "old@example.com", "correct-password", bcrypt.MinCost)
+
+	_, err := NewService(users, testCost).Login(ctx, "old@example.com", "wrong-password")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	stored, err := users.GetByEmail(ctx, "old@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, created.PasswordHash, stored.PasswordHash)
+}
+
+func TestService_CreateUser_DefaultRole(t *testing.T) {
+	users := setupUsers(t)
+	service := NewService(users, testCost)
+	t.Cleanup(func() { SetDefaultUserRole("") })
+
+	t.Run("normal by default", func(t *testing.T) {
+		user, err := service.CreateUser(ctx, "first@example.com", "", "password123")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleNormal, user.Role)
+	})
+
+	t.Run("configured default is applied", func(t *testing.T) {
+		SetDefaultUserRole(models.UserRoleRoot)
+
+		user, err := service.CreateUser(ctx, "second@example.com", "", "password123")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleRoot, user.Role)
+
+		stored, err := users.GetByEmail(ctx, "second@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleRoot, stored.Role)
+	})
+
+	t.Run("explicit role overrides the default", func(t *testing.T) {
+		SetDefaultUserRole(models.UserRoleRoot)
+
+		user, err := service.CreateUser(ctx, "third@example.com", models.UserRoleNormal, "password123")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleNormal, user.Role)
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hash, err := HashPassword("password", bcrypt.MinCost)
+	require.NoError(t, err)
+
+	assert.True(t, NeedsRehash(hash, bcrypt.MinCost+1))
+	assert.False(t, NeedsRehash(hash, bcrypt.MinCost))
+	assert.True(t, NeedsRehash("not-a-bcrypt-hash", bcrypt.MinCost))
+}
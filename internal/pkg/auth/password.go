@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ErrWeakPassword is returned when a password does not satisfy the password policy.
+// The wrapping error names the rule that failed and can be shown to the user.
+var ErrWeakPassword = errors.New("password is too weak")
+
+// PasswordPolicy lists the rules a new password must satisfy.
+type PasswordPolicy struct {
+	MinLength     int  // Minimum number of characters
+	RequireDigit  bool // At least one digit
+	RequireLetter bool // At least one letter
+}
+
+// DefaultPasswordPolicy is used until SetPasswordPolicy is called.
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8, RequireDigit: true, RequireLetter: true}
+
+var (
+	policyMu      sync.RWMutex
+	currentPolicy = DefaultPasswordPolicy
+)
+
+// SetPasswordPolicy replaces the policy applied by ValidatePasswordStrength.
+// It is called once at startup from the configuration.
+func SetPasswordPolicy(p PasswordPolicy) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	currentPolicy = p
+}
+
+// ValidatePasswordStrength checks a new password against the configured policy.
+func ValidatePasswordStrength(password string) error {
+	policyMu.RLock()
+	p := currentPolicy
+	policyMu.RUnlock()
+
+	return p.Validate(password)
+}
+
+// Validate checks password against the policy, returning an error wrapping
+// ErrWeakPassword for the first rule that fails.
+func (p PasswordPolicy) Validate(password string) error {
+	if n := len([]rune(password)); n < p.MinLength {
+		return fmt.Errorf("%w: must be at least %d characters", ErrWeakPassword, p.MinLength)
+	}
+	if p.RequireDigit && !strings.ContainsFunc(password, unicode.IsDigit) {
+		return fmt.Errorf("%w: must contain a digit", ErrWeakPassword)
+	}
+	if p.RequireLetter && !strings.ContainsFunc(password, unicode.IsLetter) {
+		return fmt.Errorf("%w: must contain a letter", ErrWeakPassword)
+	}
+	return nil
+}
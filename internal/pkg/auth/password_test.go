@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 10, RequireDigit: true, RequireLetter: true}
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{"valid", "correct-horse-9", ""},
+		{"too short", "short-9", "at least 10 characters"},
+		{"no digit", "no-digits-here", "digit"},
+		{"no letter", "1234567890!", "letter"},
+		{"unicode letters count", "пароль-1234", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrWeakPassword)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPasswordPolicy_OptionalRules(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 8}
+
+	assert.NoError(t, policy.Validate("onlyletters"))
+	assert.NoError(t, policy.Validate("12345678"))
+}
+
+func TestValidatePasswordStrength_UsesConfiguredPolicy(t *testing.T) {
+	t.Cleanup(func() { SetPasswordPolicy(DefaultPasswordPolicy) })
+
+	assert.ErrorIs(t, ValidatePasswordStrength("lettersonly"), ErrWeakPassword)
+
+	SetPasswordPolicy(PasswordPolicy{MinLength: 8, RequireLetter: true})
+	assert.NoError(t, ValidatePasswordStrength("lettersonly"))
+}
+
+func TestService_CreateUser(t *testing.T) {
+	users := setupUsers(t)
+	service := NewService(users, testCost)
+
+	t.Run("weak password is rejected", func(t *testing.T) {
+		_, err := service.CreateUser(ctx, "weak@example.com", models.UserRoleNormal, "password")
+		assert.ErrorIs(t, err, ErrWeakPassword)
+
+		found, err := users.FindByEmail(ctx, "weak@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("strong password can log in", func(t *testing.T) {
+		user, err := service.CreateUser(ctx, "new@example.com", models.UserRoleNormal, "passw0rd-ok")
+		require.NoError(t, err)
+
+		loggedIn, err := service.Login(ctx, "new@example.com", "passw0rd-ok")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, loggedIn.ID)
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	users := setupUsers(t)
+	service := NewService(users, testCost)
+	user := createUser(t, users, "user@example.com", "old-passw0rd", testCost)
+
+	assert.ErrorIs(t, service.ChangePassword(ctx, user.ID, "short1"), ErrWeakPassword)
+	_, err := service.Login(ctx, "user@example.com", "old-passw0rd")
+	require.NoError(t, err, "old password still works after a rejected change")
+
+	require.NoError(t, service.ChangePassword(ctx, user.ID, "new-passw0rd"))
+	_, err = service.Login(ctx, "user@example.com", "new-passw0rd")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,338 @@
+// Package bibtex parses BibTeX files into publications.
+// It supports the subset of BibTeX used by reference managers when exporting:
+// entries of the form @type{key, field = {value}, field = "value", field = 2024}.
+// @comment, @preamble and @string blocks are skipped; string macros are not expanded.
+package bibtex
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// ErrMalformed is returned for entries that cannot be parsed.
+var ErrMalformed = errors.New("malformed bibtex entry")
+
+// Entry is a single parsed BibTeX entry.
+type Entry struct {
+	Type   string            // Entry type in lowercase, e.g. "article"
+	Key    string            // Citation key
+	Fields map[string]string // Field values keyed by lowercase field name
+}
+
+// ParseResult is the outcome of parsing one entry: either Entry or Err is set.
+type ParseResult struct {
+	Index int    // Position of the entry in the file, starting at 1
+	Key   string // Citation key, if it could be read
+	Entry *Entry
+	Err   error
+}
+
+// Parse splits raw BibTeX into entries. A malformed entry does not stop parsing;
+// it is reported in its ParseResult and parsing resumes at the next entry.
+func Parse(raw string) []ParseResult {
+	var results []ParseResult
+	p := &parser{src: raw}
+
+	for {
+		start := strings.IndexByte(p.src[p.pos:], '@')
+		if start < 0 {
+			return results
+		}
+		p.pos += start + 1
+
+		// Text between entries is a comment; only an '@' starting a line opens an entry
+		if !atLineStart(p.src, p.pos-1) {
+			continue
+		}
+
+		entryType := strings.ToLower(p.readIdent())
+		switch entryType {
+		case "comment", "preamble", "string":
+			p.skipBlock()
+			continue
+		}
+
+		result := ParseResult{Index: len(results) + 1}
+		entry, err := p.parseEntry(entryType)
+		if entry != nil {
+			result.Key = entry.Key
+		}
+		if err != nil {
+			result.Err = fmt.Errorf("%w: %v", ErrMalformed, err)
+			p.skipToNextEntry()
+		} else {
+			result.Entry = entry
+		}
+		results = append(results, result)
+	}
+}
+
+// validate checks converted publications against the model's validation tags
+var validate = models.NewValidator()
+
+// Publication converts the entry into a publication. The venue comes from journal or
+// booktitle, and the URL from url or, failing that, doi. Author lists joined with
+// "and" are rewritten as a comma-separated list.
+func (e *Entry) Publication() (*models.Publication, error) {
+	year, err := strconv.Atoi(e.Fields["year"])
+	if err != nil && e.Fields["year"] != "" {
+		return nil, fmt.Errorf("invalid year %q", e.Fields["year"])
+	}
+
+	pub := &models.Publication{
+		Title:       e.Fields["title"],
+		AuthorsText: formatAuthors(e.Fields["author"]),
+		Year:        year,
+	}
+
+	if venue := firstNonEmpty(e.Fields["journal"], e.Fields["booktitle"]); venue != "" {
+		pub.Venue = sql.NullString{String: venue, Valid: true}
+	}
+	if url := e.Fields["url"]; url != "" {
+		pub.URL = sql.NullString{String: url, Valid: true}
+	} else if doi := e.Fields["doi"]; doi != "" {
+		pub.URL = sql.NullString{String: "https://doi.org/" + doi, Valid: true}
+	}
+
+	if err := validate.Struct(pub); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if errors.As(err, &fieldErrs) && len(fieldErrs) > 0 {
+			return nil, fmt.Errorf("invalid %s (%s)", strings.ToLower(fieldErrs[0].Field()), fieldErrs[0].Tag())
+		}
+		return nil, err
+	}
+
+	return pub, nil
+}
+
+// formatAuthors turns "Doe, Jane and Smith, John" into "Jane Doe, John Smith"
+func formatAuthors(raw string) string {
+	var names []string
+	for _, name := range splitAnd(raw) {
+		if last, first, ok := strings.Cut(name, ","); ok {
+			name = strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// splitAnd splits an author list on the " and " separator, ignoring case
+func splitAnd(raw string) []string {
+	var parts []string
+	lower := strings.ToLower(raw)
+	for {
+		i := strings.Index(lower, " and ")
+		if i < 0 {
+			return append(parts, raw)
+		}
+		parts = append(parts, raw[:i])
+		raw, lower = raw[i+5:], lower[i+5:]
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parser is a cursor over the BibTeX source
+type parser struct {
+	src string
+	pos int
+}
+
+// parseEntry parses "{key, field = value, ...}" after the entry type
+func (p *parser) parseEntry(entryType string) (*Entry, error) {
+	if entryType == "" {
+		return nil, errors.New("missing entry type")
+	}
+
+	p.skipSpace()
+	if !p.consume('{') {
+		return nil, fmt.Errorf("expected '{' after @%s", entryType)
+	}
+
+	p.skipSpace()
+	key := p.readUntil(",}")
+	entry := &Entry{Type: entryType, Key: strings.TrimSpace(key), Fields: make(map[string]string)}
+	if entry.Key == "" {
+		return entry, errors.New("missing citation key")
+	}
+
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			return entry, nil
+		}
+		if !p.consume(',') {
+			return entry, errors.New("expected ',' between fields")
+		}
+		p.skipSpace()
+		if p.consume('}') {
+			return entry, nil
+		}
+
+		name := strings.ToLower(p.readIdent())
+		if name == "" {
+			return entry, errors.New("expected field name")
+		}
+		p.skipSpace()
+		if !p.consume('=') {
+			return entry, fmt.Errorf("expected '=' after field %q", name)
+		}
+		p.skipSpace()
+
+		value, err := p.readValue()
+		if err != nil {
+			return entry, fmt.Errorf("field %q: %v", name, err)
+		}
+		entry.Fields[name] = value
+	}
+}
+
+// readValue reads a braced, quoted or bare value
+func (p *parser) readValue() (string, error) {
+	if p.pos >= len(p.src) {
+		return "", errors.New("unexpected end of input")
+	}
+
+	switch p.src[p.pos] {
+	case '{':
+		end := matchingBrace(p.src, p.pos)
+		if end < 0 {
+			return "", errors.New("unbalanced braces")
+		}
+		value := p.src[p.pos+1 : end]
+		p.pos = end + 1
+		return cleanValue(value), nil
+	case '"':
+		end := strings.IndexByte(p.src[p.pos+1:], '"')
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		value := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return cleanValue(value), nil
+	default:
+		value := p.readIdent()
+		if value == "" {
+			return "", errors.New("missing value")
+		}
+		return value, nil
+	}
+}
+
+// cleanValue removes inner braces used for capitalization and collapses whitespace
+func cleanValue(value string) string {
+	value = strings.NewReplacer("{", "", "}", "").Replace(value)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// matchingBrace returns the index of the brace closing the one at open, or -1
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '@':
+			// An entry start inside a value means the value was never closed
+			if i > 0 && s[i-1] == '\n' {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// atLineStart reports whether only spaces precede position i on its line
+func atLineStart(s string, i int) bool {
+	for i--; i >= 0 && s[i] != '\n'; i-- {
+		if s[i] != ' ' && s[i] != '\t' && s[i] != '\r' {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// readIdent reads letters, digits and the punctuation allowed in BibTeX names
+func (p *parser) readIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("_-:.+/", c) >= 0 {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+// readUntil reads up to (not including) the first of the given characters or a newline
+func (p *parser) readUntil(chars string) string {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(chars+"\n", p.src[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// skipBlock skips a braced block such as @comment{...}
+func (p *parser) skipBlock() {
+	p.skipSpace()
+	if p.pos < len(p.src) && p.src[p.pos] == '{' {
+		if end := matchingBrace(p.src, p.pos); end >= 0 {
+			p.pos = end + 1
+			return
+		}
+	}
+	p.skipToNextEntry()
+}
+
+// skipToNextEntry moves to the next '@' that starts a line, so parsing can recover
+func (p *parser) skipToNextEntry() {
+	// The failed entry may have stopped right at the next one
+	if p.pos < len(p.src) && p.src[p.pos] == '@' && atLineStart(p.src, p.pos) {
+		return
+	}
+	next := strings.Index(p.src[p.pos:], "\n@")
+	if next < 0 {
+		p.pos = len(p.src)
+		return
+	}
+	p.pos += next + 1
+}
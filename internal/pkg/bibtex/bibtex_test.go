@@ -0,0 +1,96 @@
+package bibtex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mixedFile = `
+Exported from a reference manager; this line is ignored.
+
+@comment{jabref-meta: databaseType:bibtex;}
+
+@article{doe2024,
+  author  = {Doe, Jane and Smith, John},
+  title   = {A {Study} of   Things},
+  journal = "Nature",
+  year    = 2024,
+  doi     = {10.1000/xyz}
+}
+
+@inproceedings{broken2023,
+  title = {Unclosed value,
+  year  = 2023
+@misc{, title = {No key}}
+
+@inproceedings{lee2023,
+  author    = {Ann Lee},
+  title     = {Workshop Paper},
+  booktitle = {Proc. of Things},
+  year      = {2023},
+  url       = {https://example.com/paper},
+}
+`
+
+func TestParse_MixedEntries(t *testing.T) {
+	results := Parse(mixedFile)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, 1, results[0].Index)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, "article", results[0].Entry.Type)
+	assert.Equal(t, "doe2024", results[0].Key)
+	assert.Equal(t, "A Study of Things", results[0].Entry.Fields["title"])
+
+	assert.Equal(t, "broken2023", results[1].Key)
+	assert.ErrorIs(t, results[1].Err, ErrMalformed)
+	assert.Nil(t, results[1].Entry)
+
+	assert.ErrorIs(t, results[2].Err, ErrMalformed)
+
+	assert.Equal(t, 4, results[3].Index)
+	require.NoError(t, results[3].Err)
+	assert.Equal(t, "lee2023", results[3].Key)
+}
+
+func TestEntry_Publication(t *testing.T) {
+	results := Parse(mixedFile)
+
+	pub, err := results[0].Entry.Publication()
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe, John Smith", pub.AuthorsText)
+	assert.Equal(t, "Nature", pub.Venue.String)
+	assert.Equal(t, 2024, pub.Year)
+	assert.Equal(t, "https://doi.org/10.1000/xyz", pub.URL.String)
+
+	pub, err = results[3].Entry.Publication()
+	require.NoError(t, err)
+	assert.Equal(t, "Proc. of Things", pub.Venue.String)
+	assert.Equal(t, "https://example.com/paper", pub.URL.String)
+}
+
+func TestEntry_Publication_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"missing title", `@article{a, author = {X}, year = 2020}`, "invalid title"},
+		{"non-numeric year", `@article{a, author = {X}, title = {T}, year = {soon}}`, "invalid year"},
+		{"year out of range", `@article{a, author = {X}, title = {T}, year = 1800}`, "invalid year"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Parse(tt.raw)
+			require.Len(t, results, 1)
+			require.NoError(t, results[0].Err)
+
+			_, err := results[0].Entry.Publication()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
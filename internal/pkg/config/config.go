@@ -10,24 +10,78 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // DISPLAY_TIMEZONE must resolve on hosts without a zoneinfo database
+	"unicode"
 
 	"github.com/joho/godotenv"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 )
 
+// DefaultContentSecurityPolicy only allows resources served from the application's own origin.
+const DefaultContentSecurityPolicy = "default-src 'self'"
+
+// DefaultNewsPageLimit is the number of news items shown per page when NEWS_PAGE_LIMIT is not set.
+const DefaultNewsPageLimit = 10
+
+// DefaultSearchResultLimit is the most publication search results returned when SEARCH_RESULT_LIMIT is not set.
+const DefaultSearchResultLimit = 50
+
+// MaxSearchResultLimit is the largest SEARCH_RESULT_LIMIT accepted; it matches the
+// repositories' maximum page size.
+const MaxSearchResultLimit = 100
+
+// DefaultGzipMinBytes is the smallest response body compressed when GZIP_MIN_BYTES is not set.
+const DefaultGzipMinBytes = 1024
+
+// DefaultPasswordMinLength is the shortest password accepted; PASSWORD_MIN_LENGTH can only raise it.
+const DefaultPasswordMinLength = 8
+
+// DefaultUploadAllowedExtensions lists the image formats accepted for uploads by default.
+const DefaultUploadAllowedExtensions = ".jpg,.jpeg,.png,.gif"
+
+// DefaultLogQuietPaths lists the probe endpoints whose requests are logged at debug level by default.
+const DefaultLogQuietPaths = "/health,/readyz"
+
+// DefaultUploadSniffBytes is how much of an uploaded image is buffered to check its header
+// when UPLOAD_SNIFF_BYTES is not set. It covers JPEG files with a full EXIF segment.
+const DefaultUploadSniffBytes = 64 << 10
+
 // Config holds all application configuration loaded from environment variables.
 type Config struct {
 	// Server configuration
-	Port string // Server port (default: 8080)
-	Env  string // Environment: development, production (default: development)
+	Port              string // Server port (default: 8080)
+	Env               string // Environment: development, production (default: development)
+	ReadHeaderTimeout int    // Seconds allowed for reading request headers (default: 5)
+	MaxRequestsPerIP  int    // Simultaneous in-flight requests allowed per client IP, 0 disables the cap (default: 0)
+	ServeStatic       bool   // Serve ./web/static under /static/, off when a CDN serves the assets (default: true)
+	GzipMinBytes      int    // Smallest response body compressed with gzip, 0 compresses all (default: DefaultGzipMinBytes)
+
+	// Maintenance
+	MaintenanceMode bool // Start in read-only maintenance mode (default: false)
+
+	// Content
+	TemplatesPath               string // Directory holding the HTML page templates (default: ./web/templates)
+	NewsPageLimit               int    // Number of news items per page, 0 uses the default (default: DefaultNewsPageLimit)
+	SearchResultLimit           int    // Most publication search results returned, 0 uses the default (default: DefaultSearchResultLimit)
+	NewsMinContentLength        int    // Shortest news content that can be published, in characters (default: models.DefaultNewsMinContentLength)
+	PublicationMinYear          int    // Earliest accepted publication year (default: models.DefaultPublicationMinYear)
+	PublicationMaxYear          int    // Latest accepted publication year (default: models.DefaultPublicationMaxYear)
+	PublicationAuthorsMaxLength int    // Longest accepted authors_text in characters (default: models.DefaultAuthorsTextMaxLength)
+	DisplayTimezone             string // IANA time zone timestamps are shown in on pages, e.g. Europe/Paris (default: UTC)
 
 	// Database configuration
 	DatabaseURL    string // SQLite database file path (default: ./data/lab-cms.db)
 	DBMaxOpenConns int    // Maximum number of open connections (default: 0 = unlimited)
 	DBMaxIdleConns int    // Maximum number of idle connections (default: 0 = Go default)
+	BackupPath     string // Directory database backups are written to (default: ./backups)
 
 	// Session & Security
 	SessionSecret  string // Required: Secret for session signing (no default)
@@ -35,19 +89,42 @@ type Config struct {
 	CookieSecure   bool   // HTTPS only cookies (default: false in dev, true in prod)
 	CookieHttpOnly bool   // Prevent JavaScript access to cookies (default: true)
 	CookieSameSite string // CSRF protection: strict, lax, none (default: strict)
+	CookieDomain   string // Cookie Domain attribute, empty for host-only cookies (default: empty)
+	CookiePath     string // Cookie Path attribute (default: /)
 	CSRFEnabled    bool   // Enable CSRF token validation (default: true)
-	TrustedProxies string // Comma-separated list of trusted proxy IPs (default: empty)
+	TrustedProxies string // Comma-separated list of trusted proxy IPs or CIDR ranges (default: empty)
+	BcryptCost     int    // bcrypt cost for password hashes, 10-15 (default: 12)
+
+	// Password policy for admin users
+	PasswordMinLength     int  // Minimum password length, at least 8 (default: 8)
+	PasswordRequireDigit  bool // Passwords must contain a digit (default: true)
+	PasswordRequireLetter bool // Passwords must contain a letter (default: true)
+
+	// Security headers
+	ContentSecurityPolicy string // Content-Security-Policy value, empty disables the header (default: default-src 'self')
 
 	// Initial admin setup (one-time use for first deployment)
 	RootAdminUsername string // Username for initial root admin (default: admin)
 	RootAdminPassword string // Password for initial root admin (default: empty - must be set)
 
+	// User accounts
+	DefaultUserRole string // Role given to new users created without one: normal, root (default: normal)
+
 	// Upload configuration
-	UploadPath    string // Directory for file uploads (default: ./uploads)
-	MaxUploadSize int64  // Maximum file upload size in bytes (default: 10485760 = 10MB)
+	UploadPath              string // Directory for file uploads (default: ./uploads)
+	MaxUploadSize           int64  // Maximum file upload size in bytes (default: 10485760 = 10MB)
+	UploadAllowedExtensions string // Comma-separated list of accepted file extensions (default: DefaultUploadAllowedExtensions)
+	UploadThumbnailWidth    int    // Maximum thumbnail width in pixels, 0 disables thumbnails (default: 300)
+	UploadThumbnailHeight   int    // Maximum thumbnail height in pixels, 0 disables thumbnails (default: 300)
+	UploadSniffBytes        int    // Bytes of an image buffered to validate its header (default: DefaultUploadSniffBytes)
 
 	// Logging
-	LogLevel string // Log level: debug, info, warn, error (default: info)
+	LogLevel      string // Log level: debug, info, warn, error (default: info)
+	LogTimeFormat string // Log timestamp format: rfc3339, epoch, epochmilli (default: rfc3339)
+	LogBuffered   bool   // Buffer log output and flush it periodically (default: false)
+	LogQuietPaths string // Comma-separated request paths logged at debug instead of info (default: DefaultLogQuietPaths)
+
+	cookieSecureOff bool // COOKIE_SECURE=false was set explicitly; Load still enables secure cookies in production
 }
 
 // Load reads configuration from environment variables and .env file.
@@ -57,27 +134,68 @@ func Load() *Config {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		Port:              getEnv("PORT", "8080"),
-		Env:               getEnv("ENV", "development"),
-		DatabaseURL:       getEnv("DATABASE_URL", "./data/lab-cms.db"),
-		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 0), // 0 = use Go default (unlimited)
-		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 0), // 0 = use Go default (2)
-		SessionSecret:     getEnv("SESSION_SECRET", ""),
-		SessionMaxAge:     getEnvInt("SESSION_MAX_AGE", 24),
-		CookieSecure:      getEnvBool("COOKIE_SECURE", false),
-		CookieHttpOnly:    getEnvBool("COOKIE_HTTPONLY", true),
-		CookieSameSite:    getEnv("COOKIE_SAMESITE", "strict"),
-		CSRFEnabled:       getEnvBool("CSRF_ENABLED", true),
-		TrustedProxies:    getEnv("TRUSTED_PROXIES", ""),
-		RootAdminUsername: getEnv("ROOT_ADMIN_USERNAME", "admin"),
-		RootAdminPassword: getEnv("ROOT_ADMIN_PASSWORD", ""),
-		UploadPath:        getEnv("UPLOAD_PATH", "./uploads"),
-		MaxUploadSize:     getEnvInt64("MAX_UPLOAD_SIZE", 10485760), // 10MB
-		LogLevel:          strings.ToLower(getEnv("LOG_LEVEL", "info")),
-	}
-
-	// Auto-enable secure cookies in production
+		Port:                        getEnv("PORT", "8080"),
+		Env:                         getEnv("ENV", "development"),
+		ReadHeaderTimeout:           getEnvInt("READ_HEADER_TIMEOUT", 5),
+		MaxRequestsPerIP:            getEnvInt("MAX_REQUESTS_PER_IP", 0),
+		MaintenanceMode:             getEnvBool("MAINTENANCE_MODE", false),
+		ServeStatic:                 getEnvBool("SERVE_STATIC", true),
+		GzipMinBytes:                getEnvInt("GZIP_MIN_BYTES", DefaultGzipMinBytes),
+		TemplatesPath:               getEnv("TEMPLATES_PATH", "./web/templates"),
+		NewsPageLimit:               getEnvInt("NEWS_PAGE_LIMIT", DefaultNewsPageLimit),
+		SearchResultLimit:           getEnvInt("SEARCH_RESULT_LIMIT", DefaultSearchResultLimit),
+		NewsMinContentLength:        getEnvInt("NEWS_MIN_CONTENT_LENGTH", models.DefaultNewsMinContentLength),
+		PublicationMinYear:          getEnvInt("PUBLICATION_MIN_YEAR", models.DefaultPublicationMinYear),
+		PublicationMaxYear:          getEnvInt("PUBLICATION_MAX_YEAR", models.DefaultPublicationMaxYear),
+		PublicationAuthorsMaxLength: getEnvInt("PUBLICATION_AUTHORS_MAX_LENGTH", models.DefaultAuthorsTextMaxLength),
+		DisplayTimezone:             getEnv("DISPLAY_TIMEZONE", "UTC"),
+		DatabaseURL:                 getEnv("DATABASE_URL", "./data/lab-cms.db"),
+		DBMaxOpenConns:              getEnvInt("DB_MAX_OPEN_CONNS", 0), // 0 = use Go default (unlimited)
+		DBMaxIdleConns:              getEnvInt("DB_MAX_IDLE_CONNS", 0), // 0 = use Go default (2)
+		BackupPath:                  getEnv("BACKUP_PATH", "./backups"),
+		SessionSecret:               getEnv("SESSION_SECRET", ""),
+		SessionMaxAge:               getEnvInt("SESSION_MAX_AGE", 24),
+		CookieSecure:                getEnvBool("COOKIE_SECURE", false),
+		CookieHttpOnly:              getEnvBool("COOKIE_HTTPONLY", true),
+		CookieSameSite:              getEnv("COOKIE_SAMESITE", "strict"),
+		CookieDomain:                strings.ToLower(getEnv("COOKIE_DOMAIN", "")),
+		CookiePath:                  getEnv("COOKIE_PATH", "/"),
+		CSRFEnabled:                 getEnvBool("CSRF_ENABLED", true),
+		TrustedProxies:              getEnv("TRUSTED_PROXIES", ""),
+		BcryptCost:                  getEnvInt("BCRYPT_COST", 12),
+		PasswordMinLength:           getEnvInt("PASSWORD_MIN_LENGTH", DefaultPasswordMinLength),
+		PasswordRequireDigit:        getEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+		PasswordRequireLetter:       getEnvBool("PASSWORD_REQUIRE_LETTER", true),
+		ContentSecurityPolicy:       getEnvAllowEmpty("CONTENT_SECURITY_POLICY", DefaultContentSecurityPolicy),
+		RootAdminUsername:           getEnv("ROOT_ADMIN_USERNAME", "admin"),
+		RootAdminPassword:           getEnv("ROOT_ADMIN_PASSWORD", ""),
+		DefaultUserRole:             strings.ToLower(getEnv("DEFAULT_USER_ROLE", "normal")),
+		UploadPath:                  getEnv("UPLOAD_PATH", "./uploads"),
+		MaxUploadSize:               getEnvInt64("MAX_UPLOAD_SIZE", 10485760), // 10MB
+		UploadAllowedExtensions:     getEnv("UPLOAD_ALLOWED_EXTENSIONS", DefaultUploadAllowedExtensions),
+		UploadThumbnailWidth:        getEnvInt("UPLOAD_THUMBNAIL_WIDTH", 300),
+		UploadThumbnailHeight:       getEnvInt("UPLOAD_THUMBNAIL_HEIGHT", 300),
+		UploadSniffBytes:            getEnvInt("UPLOAD_SNIFF_BYTES", DefaultUploadSniffBytes),
+		LogLevel:                    strings.ToLower(getEnv("LOG_LEVEL", "info")),
+		LogTimeFormat:               strings.ToLower(getEnv("LOG_TIME_FORMAT", "rfc3339")),
+		LogBuffered:                 getEnvBool("LOG_BUFFERED", false),
+		LogQuietPaths:               getEnvAllowEmpty("LOG_QUIET_PATHS", DefaultLogQuietPaths),
+	}
+
+	if cfg.NewsPageLimit == 0 {
+		cfg.NewsPageLimit = DefaultNewsPageLimit
+	}
+	if cfg.SearchResultLimit == 0 {
+		cfg.SearchResultLimit = DefaultSearchResultLimit
+	}
+	if cfg.PasswordMinLength == 0 {
+		cfg.PasswordMinLength = DefaultPasswordMinLength
+	}
+
+	// Auto-enable secure cookies in production, remembering an explicit
+	// COOKIE_SECURE=false so that Validate can reject it
 	if cfg.Env == "production" {
+		cfg.cookieSecureOff = os.Getenv("COOKIE_SECURE") != "" && !getEnvBool("COOKIE_SECURE", true)
 		cfg.CookieSecure = true
 	}
 
@@ -96,8 +214,8 @@ func (c *Config) Validate() error {
 
 	if c.RootAdminPassword == "" {
 		errors = append(errors, "ROOT_ADMIN_PASSWORD is required for initial setup (minimum 8 characters)")
-	} else if len(c.RootAdminPassword) < 8 {
-		errors = append(errors, "ROOT_ADMIN_PASSWORD must be at least 8 characters")
+	} else if problem := c.passwordPolicyProblem(c.RootAdminPassword); problem != "" {
+		errors = append(errors, "ROOT_ADMIN_PASSWORD "+problem)
 	}
 
 	// Validate port is numeric
@@ -116,6 +234,12 @@ func (c *Config) Validate() error {
 		errors = append(errors, fmt.Sprintf("LOG_LEVEL must be debug, info, warn, or error, got: %s", c.LogLevel))
 	}
 
+	// Validate log timestamp format (empty means the rfc3339 default)
+	validLogTimeFormats := map[string]bool{"": true, "rfc3339": true, "epoch": true, "epochmilli": true}
+	if !validLogTimeFormats[c.LogTimeFormat] {
+		errors = append(errors, fmt.Sprintf("LOG_TIME_FORMAT must be rfc3339, epoch, or epochmilli, got: %s", c.LogTimeFormat))
+	}
+
 	// Validate session max age is positive
 	if c.SessionMaxAge <= 0 {
 		errors = append(errors, "SESSION_MAX_AGE must be a positive number of hours")
@@ -127,13 +251,118 @@ func (c *Config) Validate() error {
 		errors = append(errors, fmt.Sprintf("COOKIE_SAMESITE must be strict, lax, or none, got: %s", c.CookieSameSite))
 	}
 
-	// Validate upload path exists or can be created
-	if c.UploadPath != "" {
-		if err := ensureDir(c.UploadPath); err != nil {
-			errors = append(errors, fmt.Sprintf("UPLOAD_PATH directory cannot be created: %v", err))
+	// Browsers drop SameSite=None cookies that are not also marked Secure
+	if strings.ToLower(c.CookieSameSite) == "none" && !c.CookieSecure {
+		errors = append(errors, "COOKIE_SAMESITE=none requires COOKIE_SECURE=true")
+	}
+
+	// Validate cookie scope (empty domain means host-only, empty path means /)
+	if c.CookieDomain != "" && !validCookieDomain(c.CookieDomain) {
+		errors = append(errors, fmt.Sprintf("COOKIE_DOMAIN must be a host name such as example.com, got: %s", c.CookieDomain))
+	}
+	if c.CookiePath != "" && (!strings.HasPrefix(c.CookiePath, "/") || strings.ContainsAny(c.CookiePath, "; \t")) {
+		errors = append(errors, fmt.Sprintf("COOKIE_PATH must start with / and contain no spaces or semicolons, got: %s", c.CookiePath))
+	}
+
+	// Validate trusted proxies are IP addresses or CIDR ranges
+	for _, entry := range strings.Split(c.TrustedProxies, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		if _, err := parseTrustedProxy(entry); err != nil {
+			errors = append(errors, fmt.Sprintf("TRUSTED_PROXIES entries must be IP addresses or CIDR ranges, got: %s", entry))
 		}
 	}
 
+	// Validate bcrypt cost is strong enough without making logins unreasonably slow
+	if c.BcryptCost < 10 || c.BcryptCost > 15 {
+		errors = append(errors, fmt.Sprintf("BCRYPT_COST must be between 10 and 15, got: %d", c.BcryptCost))
+	}
+
+	// Validate password length policy (0 uses the default)
+	if c.PasswordMinLength != 0 && c.PasswordMinLength < DefaultPasswordMinLength {
+		errors = append(errors, fmt.Sprintf("PASSWORD_MIN_LENGTH must be at least %d, got: %d", DefaultPasswordMinLength, c.PasswordMinLength))
+	}
+
+	// Validate the default role for new users
+	if c.DefaultUserRole != "" && c.DefaultUserRole != "normal" && c.DefaultUserRole != "root" {
+		errors = append(errors, fmt.Sprintf("DEFAULT_USER_ROLE must be normal or root, got: %s", c.DefaultUserRole))
+	}
+
+	// Validate header timeout (0 falls back to the read timeout)
+	if c.ReadHeaderTimeout < 0 {
+		errors = append(errors, "READ_HEADER_TIMEOUT cannot be negative")
+	}
+
+	// Validate the per-IP concurrency cap (0 disables it)
+	if c.MaxRequestsPerIP < 0 {
+		errors = append(errors, "MAX_REQUESTS_PER_IP cannot be negative")
+	}
+
+	// Quiet log paths are matched against request paths, so they must be absolute
+	for _, path := range c.QuietLogPaths() {
+		if !strings.HasPrefix(path, "/") {
+			errors = append(errors, fmt.Sprintf("LOG_QUIET_PATHS entries must start with '/', got: %s", path))
+		}
+	}
+
+	// Validate the upload allowlist (empty falls back to the default list)
+	if c.UploadAllowedExtensions != "" {
+		extensions := c.AllowedUploadExtensions()
+		if len(extensions) == 0 {
+			errors = append(errors, "UPLOAD_ALLOWED_EXTENSIONS must list at least one extension")
+		}
+		for _, ext := range extensions {
+			if !validExtension(ext) {
+				errors = append(errors, fmt.Sprintf("UPLOAD_ALLOWED_EXTENSIONS contains an invalid extension: %q", ext))
+			}
+		}
+	}
+
+	// Validate news page limit (0 uses the default, values above the repository maximum are clamped)
+	if c.NewsPageLimit < 0 {
+		errors = append(errors, "NEWS_PAGE_LIMIT cannot be negative")
+	}
+	if c.SearchResultLimit < 0 {
+		errors = append(errors, "SEARCH_RESULT_LIMIT cannot be negative")
+	}
+	if c.SearchResultLimit > MaxSearchResultLimit {
+		errors = append(errors, fmt.Sprintf("SEARCH_RESULT_LIMIT cannot be more than %d, got: %d", MaxSearchResultLimit, c.SearchResultLimit))
+	}
+
+	// Validate the compression threshold (0 compresses every response)
+	if c.GzipMinBytes < 0 {
+		errors = append(errors, "GZIP_MIN_BYTES cannot be negative")
+	}
+	if c.NewsMinContentLength < 0 {
+		errors = append(errors, "NEWS_MIN_CONTENT_LENGTH cannot be negative")
+	}
+
+	// Validate the publication year range (0 keeps the default bound)
+	if c.PublicationMinYear < 0 || c.PublicationMaxYear < 0 {
+		errors = append(errors, "PUBLICATION_MIN_YEAR and PUBLICATION_MAX_YEAR cannot be negative")
+	} else if c.PublicationMinYear > 0 && c.PublicationMaxYear > 0 && c.PublicationMinYear > c.PublicationMaxYear {
+		errors = append(errors, fmt.Sprintf("PUBLICATION_MIN_YEAR (%d) cannot be after PUBLICATION_MAX_YEAR (%d)", c.PublicationMinYear, c.PublicationMaxYear))
+	}
+	if c.PublicationAuthorsMaxLength < 0 {
+		errors = append(errors, "PUBLICATION_AUTHORS_MAX_LENGTH cannot be negative")
+	}
+
+	// Validate the display time zone against the IANA database
+	if _, err := time.LoadLocation(c.DisplayTimezone); err != nil {
+		errors = append(errors, fmt.Sprintf("DISPLAY_TIMEZONE must be an IANA time zone such as Europe/Paris, got: %s", c.DisplayTimezone))
+	}
+
+	// Validate thumbnail dimensions (0 disables thumbnails)
+	if c.UploadThumbnailWidth < 0 || c.UploadThumbnailHeight < 0 {
+		errors = append(errors, "UPLOAD_THUMBNAIL_WIDTH and UPLOAD_THUMBNAIL_HEIGHT cannot be negative")
+	}
+
+	// Validate the upload sniff limit (0 uses the default)
+	if c.UploadSniffBytes < 0 {
+		errors = append(errors, "UPLOAD_SNIFF_BYTES cannot be negative")
+	}
+
 	// Production-specific security checks
 	if c.Env == "production" {
 		if len(c.SessionSecret) < 32 {
@@ -148,6 +377,12 @@ func (c *Config) Validate() error {
 			errors = append(errors, "COOKIE_HTTPONLY cannot be false in production")
 		}
 
+		// Load forces secure cookies in production; this catches an explicit
+		// COOKIE_SECURE=false and configs that turn them off again afterwards
+		if !c.CookieSecure || c.cookieSecureOff {
+			errors = append(errors, "COOKIE_SECURE cannot be false in production")
+		}
+
 		if strings.ToLower(c.CookieSameSite) != "strict" {
 			errors = append(errors, "COOKIE_SAMESITE must be 'strict' in production")
 		}
@@ -183,6 +418,15 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvAllowEmpty is like getEnv but treats an explicitly empty variable as a value,
+// so settings can be switched off by setting them to "".
+func getEnvAllowEmpty(key, defaultValue string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return strings.TrimSpace(value)
+	}
+	return defaultValue
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if i, err := strconv.Atoi(value); err == nil {
@@ -214,6 +458,175 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+// AllowedUploadExtensions returns the upload allowlist normalized to lowercase extensions
+// with a leading dot, e.g. " PNG, .Pdf" becomes [".png", ".pdf"]. Blank entries are skipped.
+// An unset UploadAllowedExtensions falls back to DefaultUploadAllowedExtensions.
+func (c *Config) AllowedUploadExtensions() []string {
+	raw := c.UploadAllowedExtensions
+	if raw == "" {
+		raw = DefaultUploadAllowedExtensions
+	}
+
+	var extensions []string
+	for _, ext := range strings.Split(raw, ",") {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions = append(extensions, ext)
+	}
+	return extensions
+}
+
+// QuietLogPaths returns the LOG_QUIET_PATHS entries with surrounding whitespace removed.
+// Blank entries are skipped; an empty LogQuietPaths quiets no paths.
+func (c *Config) QuietLogPaths() []string {
+	var paths []string
+	for _, path := range strings.Split(c.LogQuietPaths, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// DisplayLocation returns the DISPLAY_TIMEZONE location, falling back to UTC when it is
+// empty or unknown; Validate reports unknown zones.
+func (c *Config) DisplayLocation() *time.Location {
+	loc, err := time.LoadLocation(c.DisplayTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UploadSniffLimit returns how many bytes of an uploaded image may be buffered to validate
+// its header, falling back to DefaultUploadSniffBytes when UploadSniffBytes is not positive.
+func (c *Config) UploadSniffLimit() int {
+	if c.UploadSniffBytes <= 0 {
+		return DefaultUploadSniffBytes
+	}
+	return c.UploadSniffBytes
+}
+
+// TrustedProxyNets returns the TRUSTED_PROXIES entries as networks. A plain IP address
+// becomes a single-host network. Blank and malformed entries are skipped; Validate reports
+// the malformed ones.
+func (c *Config) TrustedProxyNets() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, entry := range strings.Split(c.TrustedProxies, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if ipNet, err := parseTrustedProxy(entry); err == nil {
+			nets = append(nets, ipNet)
+		}
+	}
+	return nets
+}
+
+// parseTrustedProxy parses a TRUSTED_PROXIES entry, either a CIDR range or a single IP address
+func parseTrustedProxy(entry string) (*net.IPNet, error) {
+	if strings.Contains(entry, "/") {
+		_, ipNet, err := net.ParseCIDR(entry)
+		return ipNet, err
+	}
+
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", entry)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// validExtension reports whether ext is a dot followed by 1-10 lowercase letters or digits
+func validExtension(ext string) bool {
+	if len(ext) < 2 || len(ext) > 11 {
+		return false
+	}
+	for _, c := range ext[1:] {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// passwordPolicyProblem returns the PASSWORD_* rule password breaks, or "" if it complies.
+// The rules match auth.PasswordPolicy, so the initial admin password is rejected at startup
+// rather than when the account is created.
+func (c *Config) passwordPolicyProblem(password string) string {
+	minLength := c.PasswordMinLength
+	if minLength == 0 {
+		minLength = DefaultPasswordMinLength
+	}
+	if len([]rune(password)) < minLength {
+		return fmt.Sprintf("must be at least %d characters", minLength)
+	}
+	if c.PasswordRequireDigit && !strings.ContainsFunc(password, unicode.IsDigit) {
+		return "must contain a digit"
+	}
+	if c.PasswordRequireLetter && !strings.ContainsFunc(password, unicode.IsLetter) {
+		return "must contain a letter"
+	}
+	return ""
+}
+
+// validCookieDomain reports whether domain is a plausible host name: dot-separated labels of
+// letters, digits and inner hyphens, with an optional leading dot. Ports, schemes and paths
+// are rejected.
+func validCookieDomain(domain string) bool {
+	domain = strings.TrimPrefix(domain, ".")
+	if domain == "" || len(domain) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// EnsureDirectories creates the directory holding the database file, the upload directory
+// and the backup directory, including missing parents. It should be called once at startup, after Validate.
+// All directories are attempted; the returned error combines every failure.
+func (c *Config) EnsureDirectories() error {
+	var errs []error
+
+	if dir := filepath.Dir(c.DatabaseURL); c.DatabaseURL != ":memory:" && dir != "." && dir != "/" {
+		if err := ensureDir(dir); err != nil {
+			errs = append(errs, fmt.Errorf("DATABASE_URL directory cannot be created: %w", err))
+		}
+	}
+
+	if c.UploadPath != "" {
+		if err := ensureDir(c.UploadPath); err != nil {
+			errs = append(errs, fmt.Errorf("UPLOAD_PATH directory cannot be created: %w", err))
+		}
+	}
+
+	if c.BackupPath != "" {
+		if err := ensureDir(c.BackupPath); err != nil {
+			errs = append(errs, fmt.Errorf("BACKUP_PATH directory cannot be created: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func ensureDir(path string) error {
 	return os.MkdirAll(path, 0750)
 }
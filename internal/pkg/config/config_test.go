@@ -2,8 +2,11 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
 	"testing"
+	"time"
 )
 
 // TestLoad_DefaultValues verifies that Load() returns sensible defaults
@@ -22,6 +25,9 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.DatabaseURL != "./data/lab-cms.db" {
 		t.Errorf("Expected DatabaseURL to be './data/lab-cms.db', got '%s'", cfg.DatabaseURL)
 	}
+	if cfg.BackupPath != "./backups" {
+		t.Errorf("Expected BackupPath to be './backups', got '%s'", cfg.BackupPath)
+	}
 	if cfg.SessionMaxAge != 24 {
 		t.Errorf("Expected SessionMaxAge to be 24, got %d", cfg.SessionMaxAge)
 	}
@@ -49,6 +55,78 @@ func TestLoad_DefaultValues(t *testing.T) {
 	if cfg.LogLevel != "info" {
 		t.Errorf("Expected LogLevel to be 'info', got '%s'", cfg.LogLevel)
 	}
+	if cfg.PasswordMinLength != 8 || !cfg.PasswordRequireDigit || !cfg.PasswordRequireLetter {
+		t.Errorf("Expected password policy 8/digit/letter, got %d/%v/%v", cfg.PasswordMinLength, cfg.PasswordRequireDigit, cfg.PasswordRequireLetter)
+	}
+	if cfg.TemplatesPath != "./web/templates" {
+		t.Errorf("Expected TemplatesPath to be './web/templates', got '%s'", cfg.TemplatesPath)
+	}
+	if cfg.CookieDomain != "" {
+		t.Errorf("Expected CookieDomain to be empty, got '%s'", cfg.CookieDomain)
+	}
+	if cfg.CookiePath != "/" {
+		t.Errorf("Expected CookiePath to be '/', got '%s'", cfg.CookiePath)
+	}
+	if cfg.PublicationMinYear != 1900 || cfg.PublicationMaxYear != 2100 {
+		t.Errorf("Expected publication years 1900-2100, got %d-%d", cfg.PublicationMinYear, cfg.PublicationMaxYear)
+	}
+	if cfg.NewsMinContentLength != 20 {
+		t.Errorf("Expected NewsMinContentLength to be 20, got %d", cfg.NewsMinContentLength)
+	}
+	if cfg.PublicationAuthorsMaxLength != 2000 {
+		t.Errorf("Expected PublicationAuthorsMaxLength to be 2000, got %d", cfg.PublicationAuthorsMaxLength)
+	}
+	if cfg.DisplayTimezone != "UTC" {
+		t.Errorf("Expected DisplayTimezone to be 'UTC', got '%s'", cfg.DisplayTimezone)
+	}
+	if cfg.LogTimeFormat != "rfc3339" {
+		t.Errorf("Expected LogTimeFormat to be 'rfc3339', got '%s'", cfg.LogTimeFormat)
+	}
+	if cfg.LogBuffered != false {
+		t.Errorf("Expected LogBuffered to be false, got %v", cfg.LogBuffered)
+	}
+	if cfg.LogQuietPaths != DefaultLogQuietPaths {
+		t.Errorf("Expected LogQuietPaths to be '%s', got '%s'", DefaultLogQuietPaths, cfg.LogQuietPaths)
+	}
+	if cfg.DefaultUserRole != "normal" {
+		t.Errorf("Expected DefaultUserRole to be 'normal', got '%s'", cfg.DefaultUserRole)
+	}
+	if cfg.MaintenanceMode != false {
+		t.Errorf("Expected MaintenanceMode to be false, got %v", cfg.MaintenanceMode)
+	}
+	if cfg.ServeStatic != true {
+		t.Errorf("Expected ServeStatic to be true, got %v", cfg.ServeStatic)
+	}
+	if cfg.GzipMinBytes != DefaultGzipMinBytes {
+		t.Errorf("Expected GzipMinBytes to be %d, got %d", DefaultGzipMinBytes, cfg.GzipMinBytes)
+	}
+	if cfg.BcryptCost != 12 {
+		t.Errorf("Expected BcryptCost to be 12, got %d", cfg.BcryptCost)
+	}
+	if cfg.ContentSecurityPolicy != "default-src 'self'" {
+		t.Errorf("Expected ContentSecurityPolicy to be \"default-src 'self'\", got '%s'", cfg.ContentSecurityPolicy)
+	}
+	if cfg.UploadAllowedExtensions != ".jpg,.jpeg,.png,.gif" {
+		t.Errorf("Expected UploadAllowedExtensions to be '.jpg,.jpeg,.png,.gif', got '%s'", cfg.UploadAllowedExtensions)
+	}
+	if cfg.ReadHeaderTimeout != 5 {
+		t.Errorf("Expected ReadHeaderTimeout to be 5, got %d", cfg.ReadHeaderTimeout)
+	}
+	if cfg.MaxRequestsPerIP != 0 {
+		t.Errorf("Expected MaxRequestsPerIP to be 0, got %d", cfg.MaxRequestsPerIP)
+	}
+	if cfg.UploadThumbnailWidth != 300 || cfg.UploadThumbnailHeight != 300 {
+		t.Errorf("Expected thumbnail size to be 300x300, got %dx%d", cfg.UploadThumbnailWidth, cfg.UploadThumbnailHeight)
+	}
+	if cfg.UploadSniffBytes != DefaultUploadSniffBytes {
+		t.Errorf("Expected UploadSniffBytes to be %d, got %d", DefaultUploadSniffBytes, cfg.UploadSniffBytes)
+	}
+	if cfg.NewsPageLimit != DefaultNewsPageLimit {
+		t.Errorf("Expected NewsPageLimit to be %d, got %d", DefaultNewsPageLimit, cfg.NewsPageLimit)
+	}
+	if cfg.SearchResultLimit != DefaultSearchResultLimit {
+		t.Errorf("Expected SearchResultLimit to be %d, got %d", DefaultSearchResultLimit, cfg.SearchResultLimit)
+	}
 }
 
 // TestLoad_EnvironmentValues verifies that Load() reads from environment variables
@@ -58,6 +136,7 @@ func TestLoad_EnvironmentValues(t *testing.T) {
 	os.Setenv("PORT", "3000")
 	os.Setenv("ENV", "production")
 	os.Setenv("DATABASE_URL", "/custom/path/db.db")
+	os.Setenv("BACKUP_PATH", "/custom/backups")
 	os.Setenv("SESSION_SECRET", "test-secret-32-chars-long-ok")
 	os.Setenv("SESSION_MAX_AGE", "48")
 	os.Setenv("COOKIE_SECURE", "true")
@@ -69,7 +148,20 @@ func TestLoad_EnvironmentValues(t *testing.T) {
 	os.Setenv("ROOT_ADMIN_PASSWORD", "testpass123")
 	os.Setenv("UPLOAD_PATH", "/custom/uploads")
 	os.Setenv("MAX_UPLOAD_SIZE", "20971520")
+	os.Setenv("UPLOAD_ALLOWED_EXTENSIONS", ".png,.pdf")
+	os.Setenv("UPLOAD_THUMBNAIL_WIDTH", "120")
+	os.Setenv("READ_HEADER_TIMEOUT", "10")
+	os.Setenv("MAX_REQUESTS_PER_IP", "8")
+	os.Setenv("UPLOAD_THUMBNAIL_HEIGHT", "0")
+	os.Setenv("UPLOAD_SNIFF_BYTES", "4096")
 	os.Setenv("LOG_LEVEL", "debug")
+	os.Setenv("NEWS_PAGE_LIMIT", "25")
+	os.Setenv("SEARCH_RESULT_LIMIT", "20")
+	os.Setenv("GZIP_MIN_BYTES", "512")
+	os.Setenv("DISPLAY_TIMEZONE", "Europe/Paris")
+	os.Setenv("LOG_BUFFERED", "true")
+	os.Setenv("LOG_QUIET_PATHS", "/livez")
+	os.Setenv("DEFAULT_USER_ROLE", "Root")
 
 	cfg := Load()
 
@@ -82,6 +174,9 @@ func TestLoad_EnvironmentValues(t *testing.T) {
 	if cfg.DatabaseURL != "/custom/path/db.db" {
 		t.Errorf("Expected DatabaseURL to be '/custom/path/db.db', got '%s'", cfg.DatabaseURL)
 	}
+	if cfg.BackupPath != "/custom/backups" {
+		t.Errorf("Expected BackupPath to be '/custom/backups', got '%s'", cfg.BackupPath)
+	}
 	if cfg.SessionSecret != "test-secret-32-chars-long-ok" {
 		t.Errorf("Expected SessionSecret to be set correctly")
 	}
@@ -112,12 +207,48 @@ func TestLoad_EnvironmentValues(t *testing.T) {
 	if cfg.UploadPath != "/custom/uploads" {
 		t.Errorf("Expected UploadPath to be '/custom/uploads', got '%s'", cfg.UploadPath)
 	}
+	if cfg.UploadAllowedExtensions != ".png,.pdf" {
+		t.Errorf("Expected UploadAllowedExtensions to be '.png,.pdf', got '%s'", cfg.UploadAllowedExtensions)
+	}
+	if cfg.ReadHeaderTimeout != 10 {
+		t.Errorf("Expected ReadHeaderTimeout to be 10, got %d", cfg.ReadHeaderTimeout)
+	}
+	if cfg.MaxRequestsPerIP != 8 {
+		t.Errorf("Expected MaxRequestsPerIP to be 8, got %d", cfg.MaxRequestsPerIP)
+	}
+	if cfg.UploadThumbnailWidth != 120 || cfg.UploadThumbnailHeight != 0 {
+		t.Errorf("Expected thumbnail size to be 120x0, got %dx%d", cfg.UploadThumbnailWidth, cfg.UploadThumbnailHeight)
+	}
+	if cfg.UploadSniffBytes != 4096 {
+		t.Errorf("Expected UploadSniffBytes to be 4096, got %d", cfg.UploadSniffBytes)
+	}
 	if cfg.MaxUploadSize != 20971520 {
 		t.Errorf("Expected MaxUploadSize to be 20971520, got %d", cfg.MaxUploadSize)
 	}
 	if cfg.LogLevel != "debug" {
 		t.Errorf("Expected LogLevel to be 'debug', got '%s'", cfg.LogLevel)
 	}
+	if cfg.NewsPageLimit != 25 {
+		t.Errorf("Expected NewsPageLimit to be 25, got %d", cfg.NewsPageLimit)
+	}
+	if cfg.SearchResultLimit != 20 {
+		t.Errorf("Expected SearchResultLimit to be 20, got %d", cfg.SearchResultLimit)
+	}
+	if cfg.GzipMinBytes != 512 {
+		t.Errorf("Expected GzipMinBytes to be 512, got %d", cfg.GzipMinBytes)
+	}
+	if cfg.DisplayTimezone != "Europe/Paris" {
+		t.Errorf("Expected DisplayTimezone to be 'Europe/Paris', got '%s'", cfg.DisplayTimezone)
+	}
+	if cfg.LogBuffered != true {
+		t.Errorf("Expected LogBuffered to be true, got %v", cfg.LogBuffered)
+	}
+	if cfg.LogQuietPaths != "/livez" {
+		t.Errorf("Expected LogQuietPaths to be '/livez', got '%s'", cfg.LogQuietPaths)
+	}
+	if cfg.DefaultUserRole != "root" {
+		t.Errorf("Expected DefaultUserRole to be 'root', got '%s'", cfg.DefaultUserRole)
+	}
 }
 
 // TestLoad_ProductionCookieSecure verifies that production mode auto-enables secure cookies
@@ -133,6 +264,154 @@ func TestLoad_ProductionCookieSecure(t *testing.T) {
 	}
 }
 
+// TestConfig_Validate_Production_CookieSecure verifies production rejects insecure cookies,
+// whether COOKIE_SECURE=false is set or they are switched off again after Load enabled them
+func TestConfig_Validate_Production_CookieSecure(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		cfg := &Config{
+			Port:              "8080",
+			Env:               "production",
+			SessionSecret:     "this-secret-is-at-least-32-characters",
+			RootAdminPassword: "validpass8",
+			CookieSecure:      false,
+			CookieHttpOnly:    true,
+			CSRFEnabled:       true,
+			CookieSameSite:    "strict",
+			SessionMaxAge:     24,
+			BcryptCost:        12,
+			LogLevel:          "info",
+		}
+
+		err := cfg.Validate()
+		if err == nil || !contains(err.Error(), "COOKIE_SECURE cannot be false in production") {
+			t.Errorf("Expected error to mention COOKIE_SECURE, got: %v", err)
+		}
+
+		cfg.CookieSecure = true
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected secure production config to be valid, got: %v", err)
+		}
+	})
+
+	t.Run("disabled in the environment", func(t *testing.T) {
+		clearEnvVars()
+		os.Setenv("ENV", "production")
+		os.Setenv("COOKIE_SECURE", "false")
+		os.Setenv("SESSION_SECRET", "this-secret-is-at-least-32-characters")
+		os.Setenv("ROOT_ADMIN_PASSWORD", "validpass8")
+
+		cfg := Load()
+		if !cfg.CookieSecure {
+			t.Error("Expected CookieSecure to stay true in production mode")
+		}
+
+		err := cfg.Validate()
+		if err == nil || !contains(err.Error(), "COOKIE_SECURE cannot be false in production") {
+			t.Errorf("Expected error to mention COOKIE_SECURE, got: %v", err)
+		}
+	})
+
+	t.Run("overridden after load", func(t *testing.T) {
+		clearEnvVars()
+		os.Setenv("ENV", "production")
+		os.Setenv("SESSION_SECRET", "this-secret-is-at-least-32-characters")
+		os.Setenv("ROOT_ADMIN_PASSWORD", "validpass8")
+
+		cfg := Load()
+		if err := cfg.Validate(); err != nil {
+			t.Fatalf("Expected loaded production config to be valid, got: %v", err)
+		}
+
+		cfg.CookieSecure = false
+		err := cfg.Validate()
+		if err == nil || !contains(err.Error(), "COOKIE_SECURE") {
+			t.Errorf("Expected error to mention COOKIE_SECURE, got: %v", err)
+		}
+	})
+
+	t.Run("development allows insecure cookies", func(t *testing.T) {
+		cfg := &Config{
+			Port:              "8080",
+			Env:               "development",
+			SessionSecret:     "dev-secret",
+			RootAdminPassword: "validpass8",
+			CookieHttpOnly:    true,
+			CSRFEnabled:       true,
+			CookieSameSite:    "strict",
+			SessionMaxAge:     24,
+			BcryptCost:        12,
+			LogLevel:          "info",
+		}
+
+		if err := cfg.Validate(); err != nil && contains(err.Error(), "COOKIE_SECURE") {
+			t.Errorf("Expected no COOKIE_SECURE error in development, got: %v", err)
+		}
+	})
+}
+
+// TestLoad_MaintenanceMode verifies that MAINTENANCE_MODE is read from the environment
+func TestLoad_MaintenanceMode(t *testing.T) {
+	clearEnvVars()
+	os.Setenv("MAINTENANCE_MODE", "true")
+
+	cfg := Load()
+
+	if !cfg.MaintenanceMode {
+		t.Error("Expected MaintenanceMode to be true when MAINTENANCE_MODE=true")
+	}
+}
+
+// TestLoad_ServeStatic verifies that SERVE_STATIC can turn off the static file server
+func TestLoad_ServeStatic(t *testing.T) {
+	clearEnvVars()
+	os.Setenv("SERVE_STATIC", "false")
+
+	cfg := Load()
+
+	if cfg.ServeStatic {
+		t.Error("Expected ServeStatic to be false when SERVE_STATIC=false")
+	}
+}
+
+// TestLoad_PasswordMinLengthZero verifies that PASSWORD_MIN_LENGTH=0 keeps the default minimum
+// instead of disabling the length check
+func TestLoad_PasswordMinLengthZero(t *testing.T) {
+	clearEnvVars()
+	os.Setenv("PASSWORD_MIN_LENGTH", "0")
+
+	cfg := Load()
+
+	if cfg.PasswordMinLength != DefaultPasswordMinLength {
+		t.Errorf("Expected PasswordMinLength %d when PASSWORD_MIN_LENGTH=0, got %d", DefaultPasswordMinLength, cfg.PasswordMinLength)
+	}
+}
+
+// TestLoad_ContentSecurityPolicy verifies custom and explicitly empty CSP values
+func TestLoad_ContentSecurityPolicy(t *testing.T) {
+	t.Run("custom value", func(t *testing.T) {
+		clearEnvVars()
+		os.Setenv("CONTENT_SECURITY_POLICY", "default-src 'self'; img-src *")
+
+		cfg := Load()
+
+		if cfg.ContentSecurityPolicy != "default-src 'self'; img-src *" {
+			t.Errorf("Expected custom ContentSecurityPolicy, got '%s'", cfg.ContentSecurityPolicy)
+		}
+	})
+
+	t.Run("empty value disables header", func(t *testing.T) {
+		clearEnvVars()
+		os.Setenv("CONTENT_SECURITY_POLICY", "")
+		defer os.Unsetenv("CONTENT_SECURITY_POLICY")
+
+		cfg := Load()
+
+		if cfg.ContentSecurityPolicy != "" {
+			t.Errorf("Expected empty ContentSecurityPolicy, got '%s'", cfg.ContentSecurityPolicy)
+		}
+	})
+}
+
 // TestLoad_BoolVariations tests various boolean string formats
 func TestLoad_BoolVariations(t *testing.T) {
 	truthyValues := []string{"true", "TRUE", "True", "1", "yes", "YES", "on", "ON"}
@@ -177,6 +456,7 @@ func TestConfig_Validate_Success(t *testing.T) {
 		RootAdminPassword: "validpass8",
 		UploadPath:        "./uploads",
 		MaxUploadSize:     10485760,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -196,6 +476,7 @@ func TestConfig_Validate_MissingSessionSecret(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -219,6 +500,7 @@ func TestConfig_Validate_MissingRootAdminPassword(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -242,6 +524,7 @@ func TestConfig_Validate_ShortPassword(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -254,6 +537,55 @@ func TestConfig_Validate_ShortPassword(t *testing.T) {
 	}
 }
 
+// TestConfig_Validate_RootPasswordPolicy verifies the root admin password must satisfy the PASSWORD_* rules
+func TestConfig_Validate_RootPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		policy   func(*Config)
+		wantErr  string
+	}{
+		{"complies", "validpass8", nil, ""},
+		{"raised minimum length", "validpass8", func(c *Config) { c.PasswordMinLength = 12 }, "ROOT_ADMIN_PASSWORD must be at least 12 characters"},
+		{"missing digit", "validpassword", nil, "ROOT_ADMIN_PASSWORD must contain a digit"},
+		{"missing letter", "1234567890", nil, "ROOT_ADMIN_PASSWORD must contain a letter"},
+		{"digit not required", "validpassword", func(c *Config) { c.PasswordRequireDigit = false }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:                  "8080",
+				Env:                   "development",
+				SessionSecret:         "valid-secret-32-chars-minimum-req",
+				RootAdminPassword:     tt.password,
+				CookieHttpOnly:        true,
+				CSRFEnabled:           true,
+				CookieSameSite:        "strict",
+				SessionMaxAge:         24,
+				BcryptCost:            12,
+				LogLevel:              "info",
+				PasswordRequireDigit:  true,
+				PasswordRequireLetter: true,
+			}
+			if tt.policy != nil {
+				tt.policy(cfg)
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected validation to pass, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error to mention %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 // TestConfig_Validate_InvalidPort verifies invalid port number fails
 func TestConfig_Validate_InvalidPort(t *testing.T) {
 	cfg := &Config{
@@ -265,6 +597,7 @@ func TestConfig_Validate_InvalidPort(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -288,6 +621,7 @@ func TestConfig_Validate_InvalidEnv(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -311,6 +645,7 @@ func TestConfig_Validate_InvalidLogLevel(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "invalid",
 	}
 
@@ -334,6 +669,7 @@ func TestConfig_Validate_InvalidSameSite(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "invalid",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -346,6 +682,44 @@ func TestConfig_Validate_InvalidSameSite(t *testing.T) {
 	}
 }
 
+// TestConfig_Validate_SameSiteNoneRequiresSecure verifies SameSite=None is only accepted with secure cookies
+func TestConfig_Validate_SameSiteNoneRequiresSecure(t *testing.T) {
+	tests := []struct {
+		name   string
+		secure bool
+		valid  bool
+	}{
+		{"none without secure", false, false},
+		{"none with secure", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CookieSecure:      tt.secure,
+				CSRFEnabled:       true,
+				CookieSameSite:    "None",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected validation to pass, got: %v", err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "COOKIE_SECURE")) {
+				t.Errorf("Expected error to mention COOKIE_SECURE, got: %v", err)
+			}
+		})
+	}
+}
+
 // TestConfig_Validate_InvalidSessionMaxAge verifies invalid session max age fails
 func TestConfig_Validate_InvalidSessionMaxAge(t *testing.T) {
 	cfg := &Config{
@@ -357,6 +731,7 @@ func TestConfig_Validate_InvalidSessionMaxAge(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     0,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -369,6 +744,452 @@ func TestConfig_Validate_InvalidSessionMaxAge(t *testing.T) {
 	}
 }
 
+// TestConfig_Validate_BcryptCost verifies the bcrypt cost must be between 10 and 15
+func TestConfig_Validate_BcryptCost(t *testing.T) {
+	tests := []struct {
+		cost  int
+		valid bool
+	}{
+		{9, false},
+		{10, true},
+		{12, true},
+		{15, true},
+		{16, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.cost), func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        tt.cost,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected BCRYPT_COST=%d to be valid, got: %v", tt.cost, err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "BCRYPT_COST")) {
+				t.Errorf("Expected BCRYPT_COST=%d to be rejected, got: %v", tt.cost, err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_NegativeThumbnailSize verifies thumbnail dimensions cannot be negative
+func TestConfig_Validate_NegativeThumbnailSize(t *testing.T) {
+	cfg := &Config{
+		Port:                 "8080",
+		Env:                  "development",
+		SessionSecret:        "valid-secret-32-chars-minimum-req",
+		RootAdminPassword:    "validpass8",
+		CookieHttpOnly:       true,
+		CSRFEnabled:          true,
+		CookieSameSite:       "strict",
+		SessionMaxAge:        24,
+		BcryptCost:           12,
+		UploadThumbnailWidth: -1,
+		LogLevel:             "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Error("Expected validation to fail with negative thumbnail width")
+	}
+	if err != nil && !contains(err.Error(), "UPLOAD_THUMBNAIL_WIDTH") {
+		t.Errorf("Expected error to mention UPLOAD_THUMBNAIL_WIDTH, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_NegativeNewsPageLimit verifies the news page limit cannot be negative
+func TestConfig_Validate_NegativeNewsPageLimit(t *testing.T) {
+	cfg := &Config{
+		Port:              "8080",
+		Env:               "development",
+		SessionSecret:     "valid-secret-32-chars-minimum-req",
+		RootAdminPassword: "validpass8",
+		CookieHttpOnly:    true,
+		CSRFEnabled:       true,
+		CookieSameSite:    "strict",
+		SessionMaxAge:     24,
+		BcryptCost:        12,
+		NewsPageLimit:     -5,
+		LogLevel:          "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "NEWS_PAGE_LIMIT") {
+		t.Errorf("Expected error to mention NEWS_PAGE_LIMIT, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_NegativeSearchResultLimit verifies the search result cap cannot be negative
+func TestConfig_Validate_NegativeSearchResultLimit(t *testing.T) {
+	cfg := &Config{
+		Port:              "8080",
+		Env:               "development",
+		SessionSecret:     "valid-secret-32-chars-minimum-req",
+		RootAdminPassword: "validpass8",
+		CookieHttpOnly:    true,
+		CSRFEnabled:       true,
+		CookieSameSite:    "strict",
+		SessionMaxAge:     24,
+		BcryptCost:        12,
+		SearchResultLimit: -1,
+		LogLevel:          "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "SEARCH_RESULT_LIMIT") {
+		t.Errorf("Expected error to mention SEARCH_RESULT_LIMIT, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_SearchResultLimitTooLarge verifies the search result cap cannot exceed the page size maximum
+func TestConfig_Validate_SearchResultLimitTooLarge(t *testing.T) {
+	cfg := &Config{
+		Port:              "8080",
+		Env:               "development",
+		SessionSecret:     "valid-secret-32-chars-minimum-req",
+		RootAdminPassword: "validpass8",
+		CookieHttpOnly:    true,
+		CSRFEnabled:       true,
+		CookieSameSite:    "strict",
+		SessionMaxAge:     24,
+		BcryptCost:        12,
+		SearchResultLimit: MaxSearchResultLimit + 1,
+		LogLevel:          "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "SEARCH_RESULT_LIMIT cannot be more than") {
+		t.Errorf("Expected error to mention SEARCH_RESULT_LIMIT, got: %v", err)
+	}
+
+	cfg.SearchResultLimit = MaxSearchResultLimit
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected the maximum to be valid, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_NegativeGzipMinBytes verifies the compression threshold cannot be negative
+func TestConfig_Validate_NegativeGzipMinBytes(t *testing.T) {
+	cfg := &Config{
+		Port:              "8080",
+		Env:               "development",
+		SessionSecret:     "valid-secret-32-chars-minimum-req",
+		RootAdminPassword: "validpass8",
+		CookieHttpOnly:    true,
+		CSRFEnabled:       true,
+		CookieSameSite:    "strict",
+		SessionMaxAge:     24,
+		BcryptCost:        12,
+		GzipMinBytes:      -1,
+		LogLevel:          "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "GZIP_MIN_BYTES") {
+		t.Errorf("Expected error to mention GZIP_MIN_BYTES, got: %v", err)
+	}
+
+	cfg.GzipMinBytes = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected GZIP_MIN_BYTES=0 to be valid, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_NegativeMaxRequestsPerIP verifies the per-IP concurrency cap cannot be negative
+func TestConfig_Validate_NegativeMaxRequestsPerIP(t *testing.T) {
+	cfg := &Config{
+		Port:              "8080",
+		Env:               "development",
+		SessionSecret:     "valid-secret-32-chars-minimum-req",
+		RootAdminPassword: "validpass8",
+		CookieHttpOnly:    true,
+		CSRFEnabled:       true,
+		CookieSameSite:    "strict",
+		SessionMaxAge:     24,
+		BcryptCost:        12,
+		MaxRequestsPerIP:  -1,
+		LogLevel:          "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "MAX_REQUESTS_PER_IP") {
+		t.Errorf("Expected error to mention MAX_REQUESTS_PER_IP, got: %v", err)
+	}
+}
+
+// TestConfig_Validate_NegativeNewsMinContentLength verifies the publish minimum cannot be negative
+func TestConfig_Validate_NegativeNewsMinContentLength(t *testing.T) {
+	cfg := &Config{
+		Port:                 "8080",
+		Env:                  "development",
+		SessionSecret:        "valid-secret-32-chars-minimum-req",
+		RootAdminPassword:    "validpass8",
+		CookieHttpOnly:       true,
+		CSRFEnabled:          true,
+		CookieSameSite:       "strict",
+		SessionMaxAge:        24,
+		BcryptCost:           12,
+		NewsMinContentLength: -1,
+		LogLevel:             "info",
+	}
+
+	err := cfg.Validate()
+	if err == nil || !contains(err.Error(), "NEWS_MIN_CONTENT_LENGTH") {
+		t.Errorf("Expected error to mention NEWS_MIN_CONTENT_LENGTH, got: %v", err)
+	}
+}
+
+func TestConfig_Validate_LogTimeFormat(t *testing.T) {
+	for _, format := range []string{"", "rfc3339", "epoch", "epochmilli", "iso8601"} {
+		t.Run(format, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogLevel:          "info",
+				LogTimeFormat:     format,
+			}
+
+			err := cfg.Validate()
+			if format == "iso8601" {
+				if err == nil || !contains(err.Error(), "LOG_TIME_FORMAT") {
+					t.Errorf("Expected error to mention LOG_TIME_FORMAT, got: %v", err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", format, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_CookieScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		path    string
+		wantErr string
+	}{
+		{"defaults", "", "", ""},
+		{"domain", "example.org", "/", ""},
+		{"leading dot", ".lab.example.org", "/", ""},
+		{"single label", "localhost", "/cms", ""},
+		{"scheme", "https://example.org", "/", "COOKIE_DOMAIN"},
+		{"port", "example.org:8080", "/", "COOKIE_DOMAIN"},
+		{"empty label", "example..org", "/", "COOKIE_DOMAIN"},
+		{"hyphen edge", "-example.org", "/", "COOKIE_DOMAIN"},
+		{"relative path", "", "admin", "COOKIE_PATH"},
+		{"semicolon in path", "", "/a;b", "COOKIE_PATH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				CookieDomain:      tt.domain,
+				CookiePath:        tt.path,
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			} else if err == nil || !contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error to mention %s, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"addresses", "127.0.0.1, 10.0.0.1", false},
+		{"cidr", "10.0.0.0/8,::1", false},
+		{"host name", "proxy.local", true},
+		{"bad cidr", "10.0.0.0/40", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				TrustedProxies:    tt.proxies,
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr && (err == nil || !contains(err.Error(), "TRUSTED_PROXIES")) {
+				t.Errorf("Expected TRUSTED_PROXIES error, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_TrustedProxyNets(t *testing.T) {
+	cfg := &Config{TrustedProxies: " 127.0.0.1, ,10.0.0.0/8, bogus"}
+
+	nets := cfg.TrustedProxyNets()
+	if len(nets) != 2 {
+		t.Fatalf("Expected 2 networks, got %d: %v", len(nets), nets)
+	}
+	if got := nets[0].String(); got != "127.0.0.1/32" {
+		t.Errorf("Expected 127.0.0.1/32, got %s", got)
+	}
+	if got := nets[1].String(); got != "10.0.0.0/8" {
+		t.Errorf("Expected 10.0.0.0/8, got %s", got)
+	}
+}
+
+func TestConfig_Validate_PublicationYearRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		wantErr  bool
+	}{
+		{"unset", 0, 0, false},
+		{"widened", 1600, 2100, false},
+		{"single year", 2000, 2000, false},
+		{"min after max", 2100, 1900, true},
+		{"negative", -1, 2100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Port:               "8080",
+				Env:                "development",
+				SessionSecret:      "valid-secret-32-chars-minimum-req",
+				RootAdminPassword:  "validpass8",
+				CookieHttpOnly:     true,
+				CSRFEnabled:        true,
+				CookieSameSite:     "strict",
+				SessionMaxAge:      24,
+				BcryptCost:         12,
+				LogLevel:           "info",
+				PublicationMinYear: tt.min,
+				PublicationMaxYear: tt.max,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr && (err == nil || !contains(err.Error(), "PUBLICATION_M")) {
+				t.Errorf("Expected error about the publication year range, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_PublicationAuthorsMaxLength(t *testing.T) {
+	for _, tt := range []struct {
+		max     int
+		wantErr bool
+	}{{0, false}, {500, false}, {-1, true}} {
+		cfg := &Config{
+			Port:                        "8080",
+			Env:                         "development",
+			SessionSecret:               "valid-secret-32-chars-minimum-req",
+			RootAdminPassword:           "validpass8",
+			CookieHttpOnly:              true,
+			CSRFEnabled:                 true,
+			CookieSameSite:              "strict",
+			SessionMaxAge:               24,
+			BcryptCost:                  12,
+			LogLevel:                    "info",
+			PublicationAuthorsMaxLength: tt.max,
+		}
+
+		err := cfg.Validate()
+		if tt.wantErr && (err == nil || !contains(err.Error(), "PUBLICATION_AUTHORS_MAX_LENGTH")) {
+			t.Errorf("max=%d: expected PUBLICATION_AUTHORS_MAX_LENGTH error, got: %v", tt.max, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("max=%d: expected no error, got: %v", tt.max, err)
+		}
+	}
+}
+
+func TestConfig_Validate_PasswordMinLength(t *testing.T) {
+	tests := []struct {
+		length  int
+		wantErr bool
+	}{
+		{0, false},
+		{8, false},
+		{12, false},
+		{6, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.length), func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "valid-password-123",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogLevel:          "info",
+				PasswordMinLength: tt.length,
+			}
+
+			err := cfg.Validate()
+			if tt.wantErr && (err == nil || !contains(err.Error(), "PASSWORD_MIN_LENGTH")) {
+				t.Errorf("Expected error to mention PASSWORD_MIN_LENGTH, got: %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
 // TestConfig_Validate_Production_ShortSecret verifies production requires long secret
 func TestConfig_Validate_Production_ShortSecret(t *testing.T) {
 	cfg := &Config{
@@ -380,6 +1201,7 @@ func TestConfig_Validate_Production_ShortSecret(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -403,6 +1225,7 @@ func TestConfig_Validate_Production_CSRFDisabled(t *testing.T) {
 		CSRFEnabled:       false,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -426,6 +1249,7 @@ func TestConfig_Validate_Production_HttpOnlyDisabled(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -449,6 +1273,7 @@ func TestConfig_Validate_Production_LaxSameSite(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "lax",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "info",
 	}
 
@@ -472,6 +1297,7 @@ func TestConfig_Validate_Production_DebugLogLevel(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "strict",
 		SessionMaxAge:     24,
+		BcryptCost:        12,
 		LogLevel:          "debug",
 	}
 
@@ -521,6 +1347,7 @@ func TestConfig_Validate_MultipleErrors(t *testing.T) {
 		CSRFEnabled:       true,
 		CookieSameSite:    "invalid",
 		SessionMaxAge:     -1,
+		BcryptCost:        12,
 		LogLevel:          "invalid",
 	}
 
@@ -565,6 +1392,290 @@ func TestEnsureDir(t *testing.T) {
 	}
 }
 
+// TestConfig_AllowedUploadExtensions verifies the upload allowlist is normalized
+func TestConfig_AllowedUploadExtensions(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected []string
+	}{
+		{".jpg,.png", []string{".jpg", ".png"}},
+		{" SVG, .Pdf ,", []string{".svg", ".pdf"}},
+		{"", []string{".jpg", ".jpeg", ".png", ".gif"}},
+		{" , ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			cfg := &Config{UploadAllowedExtensions: tt.raw}
+			if got := cfg.AllowedUploadExtensions(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("AllowedUploadExtensions() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConfig_QuietLogPaths verifies LOG_QUIET_PATHS is split and trimmed
+func TestConfig_QuietLogPaths(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected []string
+	}{
+		{"/health,/readyz", []string{"/health", "/readyz"}},
+		{" /livez , ,/metrics ", []string{"/livez", "/metrics"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			cfg := &Config{LogQuietPaths: tt.raw}
+			if got := cfg.QuietLogPaths(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("QuietLogPaths() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_UploadAllowedExtensions verifies the upload allowlist must be usable
+func TestConfig_Validate_UploadAllowedExtensions(t *testing.T) {
+	tests := []struct {
+		raw   string
+		valid bool
+	}{
+		{".jpg,.svg", true},
+		{"pdf", true},
+		{" , ", false},
+		{".png,../x", false},
+		{".tar.gz", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			cfg := &Config{
+				Port:                    "8080",
+				Env:                     "development",
+				SessionSecret:           "valid-secret-32-chars-minimum-req",
+				RootAdminPassword:       "validpass8",
+				CookieHttpOnly:          true,
+				CSRFEnabled:             true,
+				CookieSameSite:          "strict",
+				SessionMaxAge:           24,
+				BcryptCost:              12,
+				UploadAllowedExtensions: tt.raw,
+				LogLevel:                "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", tt.raw, err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "UPLOAD_ALLOWED_EXTENSIONS")) {
+				t.Errorf("Expected %q to be rejected, got: %v", tt.raw, err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_LogQuietPaths verifies quiet log paths must be absolute
+func TestConfig_Validate_LogQuietPaths(t *testing.T) {
+	tests := []struct {
+		raw   string
+		valid bool
+	}{
+		{"/health,/readyz", true},
+		{"", true},
+		{"/health,readyz", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				LogQuietPaths:     tt.raw,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", tt.raw, err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "LOG_QUIET_PATHS")) {
+				t.Errorf("Expected %q to be rejected, got: %v", tt.raw, err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_DefaultUserRole verifies only known roles are accepted
+func TestConfig_Validate_DefaultUserRole(t *testing.T) {
+	tests := []struct {
+		role  string
+		valid bool
+	}{
+		{"normal", true},
+		{"root", true},
+		{"", true},
+		{"admin", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				DefaultUserRole:   tt.role,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", tt.role, err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "DEFAULT_USER_ROLE")) {
+				t.Errorf("Expected %q to be rejected, got: %v", tt.role, err)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate_DisplayTimezone verifies only IANA zone names are accepted
+func TestConfig_Validate_DisplayTimezone(t *testing.T) {
+	tests := []struct {
+		zone  string
+		valid bool
+	}{
+		{"UTC", true},
+		{"Europe/Paris", true},
+		{"America/New_York", true},
+		{"Mars/Olympus_Mons", false},
+		{"CEST+2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			cfg := &Config{
+				Port:              "8080",
+				Env:               "development",
+				SessionSecret:     "valid-secret-32-chars-minimum-req",
+				RootAdminPassword: "validpass8",
+				CookieHttpOnly:    true,
+				CSRFEnabled:       true,
+				CookieSameSite:    "strict",
+				SessionMaxAge:     24,
+				BcryptCost:        12,
+				DisplayTimezone:   tt.zone,
+				LogLevel:          "info",
+			}
+
+			err := cfg.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got: %v", tt.zone, err)
+			}
+			if !tt.valid && (err == nil || !contains(err.Error(), "DISPLAY_TIMEZONE")) {
+				t.Errorf("Expected error to mention DISPLAY_TIMEZONE for %q, got: %v", tt.zone, err)
+			}
+		})
+	}
+}
+
+// TestConfig_DisplayLocation verifies the configured zone is loaded and unknown zones fall back to UTC
+func TestConfig_DisplayLocation(t *testing.T) {
+	cfg := &Config{DisplayTimezone: "Europe/Paris"}
+	if got := cfg.DisplayLocation().String(); got != "Europe/Paris" {
+		t.Errorf("Expected location Europe/Paris, got %s", got)
+	}
+
+	// 12:00 UTC is 14:00 in Paris during summer time
+	noon := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	if got := noon.In(cfg.DisplayLocation()).Format("15:04"); got != "14:00" {
+		t.Errorf("Expected 14:00 in Europe/Paris, got %s", got)
+	}
+
+	cfg.DisplayTimezone = "Mars/Olympus_Mons"
+	if cfg.DisplayLocation() != time.UTC {
+		t.Errorf("Expected unknown zone to fall back to UTC, got %s", cfg.DisplayLocation())
+	}
+}
+
+// TestConfig_UploadSniffLimit verifies non-positive sniff sizes fall back to the default
+func TestConfig_UploadSniffLimit(t *testing.T) {
+	tests := []struct {
+		bytes    int
+		expected int
+	}{
+		{0, DefaultUploadSniffBytes},
+		{-1, DefaultUploadSniffBytes},
+		{512, 512},
+	}
+
+	for _, tt := range tests {
+		cfg := &Config{UploadSniffBytes: tt.bytes}
+		if got := cfg.UploadSniffLimit(); got != tt.expected {
+			t.Errorf("UploadSniffLimit() with %d = %d, want %d", tt.bytes, got, tt.expected)
+		}
+	}
+}
+
+// TestConfig_EnsureDirectories verifies the database and upload directories are created
+func TestConfig_EnsureDirectories(t *testing.T) {
+	root := t.TempDir()
+	cfg := &Config{
+		DatabaseURL: filepath.Join(root, "data", "db", "lab-cms.db"),
+		UploadPath:  filepath.Join(root, "files", "uploads"),
+		BackupPath:  filepath.Join(root, "files", "backups"),
+	}
+
+	if err := cfg.EnsureDirectories(); err != nil {
+		t.Fatalf("Expected EnsureDirectories to succeed, got error: %v", err)
+	}
+
+	for _, dir := range []string{filepath.Join(root, "data", "db"), cfg.UploadPath, cfg.BackupPath} {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			t.Errorf("Expected directory %s to exist, got error: %v", dir, err)
+		}
+	}
+	if _, err := os.Stat(cfg.DatabaseURL); !os.IsNotExist(err) {
+		t.Error("Expected the database file itself not to be created")
+	}
+}
+
+// TestConfig_EnsureDirectories_CombinedError verifies every failing directory is reported
+func TestConfig_EnsureDirectories_CombinedError(t *testing.T) {
+	root := t.TempDir()
+	blocker := filepath.Join(root, "file")
+	if err := os.WriteFile(blocker, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{
+		DatabaseURL: filepath.Join(blocker, "data", "lab-cms.db"),
+		UploadPath:  filepath.Join(blocker, "uploads"),
+	}
+
+	err := cfg.EnsureDirectories()
+	if err == nil {
+		t.Fatal("Expected EnsureDirectories to fail below a regular file")
+	}
+	if !contains(err.Error(), "DATABASE_URL") || !contains(err.Error(), "UPLOAD_PATH") {
+		t.Errorf("Expected error to mention both directories, got: %v", err)
+	}
+}
+
 // TestLogLevelCaseInsensitive verifies log level is case insensitive
 func TestLogLevelCaseInsensitive(t *testing.T) {
 	levels := []string{"DEBUG", "INFO", "WARN", "ERROR", "Debug", "Info"}
@@ -604,7 +1715,15 @@ func clearEnvVars() {
 		"PORT", "ENV", "DATABASE_URL", "SESSION_SECRET", "SESSION_MAX_AGE",
 		"COOKIE_SECURE", "COOKIE_HTTPONLY", "COOKIE_SAMESITE", "CSRF_ENABLED",
 		"TRUSTED_PROXIES", "ROOT_ADMIN_USERNAME", "ROOT_ADMIN_PASSWORD",
-		"UPLOAD_PATH", "MAX_UPLOAD_SIZE", "LOG_LEVEL",
+		"UPLOAD_PATH", "MAX_UPLOAD_SIZE", "LOG_LEVEL", "MAINTENANCE_MODE",
+		"CONTENT_SECURITY_POLICY", "BCRYPT_COST", "UPLOAD_ALLOWED_EXTENSIONS",
+		"UPLOAD_THUMBNAIL_WIDTH", "UPLOAD_THUMBNAIL_HEIGHT", "READ_HEADER_TIMEOUT",
+		"NEWS_PAGE_LIMIT", "LOG_TIME_FORMAT", "COOKIE_DOMAIN", "COOKIE_PATH",
+		"PUBLICATION_MIN_YEAR", "PUBLICATION_MAX_YEAR", "TEMPLATES_PATH",
+		"PASSWORD_MIN_LENGTH", "PASSWORD_REQUIRE_DIGIT", "PASSWORD_REQUIRE_LETTER",
+		"LOG_BUFFERED", "PUBLICATION_AUTHORS_MAX_LENGTH", "NEWS_MIN_CONTENT_LENGTH",
+		"MAX_REQUESTS_PER_IP", "LOG_QUIET_PATHS", "DEFAULT_USER_ROLE",
+		"UPLOAD_SNIFF_BYTES", "SEARCH_RESULT_LIMIT", "SERVE_STATIC", "GZIP_MIN_BYTES", "BACKUP_PATH", "DISPLAY_TIMEZONE",
 	}
 	for _, v := range vars {
 		os.Unsetenv(v)
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backup writes a consistent copy of the database to path using VACUUM INTO. The copy is
+// taken inside a single read transaction, so it is safe while the application is serving
+// requests. path must not exist yet; its directory must.
+func (m *DBManager) Backup(ctx context.Context, path string) error {
+	if _, err := m.db.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to back up database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBManager_Backup(t *testing.T) {
+	dir := t.TempDir()
+	dbManager, err := NewManager(filepath.Join(dir, "source.db"))
+	require.NoError(t, err)
+	defer dbManager.Close()
+
+	_, err = dbManager.GetDB().Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+	_, err = dbManager.GetDB().Exec(`INSERT INTO items (name) VALUES ('a'), ('b')`)
+	require.NoError(t, err)
+
+	backupPath := filepath.Join(dir, "backup.db")
+	require.NoError(t, dbManager.Backup(context.Background(), backupPath))
+
+	backup, err := NewManager(backupPath)
+	require.NoError(t, err)
+	defer backup.Close()
+
+	var count int
+	require.NoError(t, backup.GetDB().QueryRow(`SELECT COUNT(*) FROM items`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	t.Run("existing file is not overwritten", func(t *testing.T) {
+		assert.Error(t, dbManager.Backup(context.Background(), backupPath))
+	})
+}
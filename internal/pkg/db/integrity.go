@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// IntegrityCheck runs PRAGMA integrity_check and returns the problems SQLite reports.
+// An empty slice means the database file is consistent.
+func (m *DBManager) IntegrityCheck(ctx context.Context) ([]string, error) {
+	rows, err := m.db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run integrity check: %w", err)
+	}
+	defer rows.Close()
+
+	problems := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		// A healthy database yields a single "ok" row
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read integrity check result: %w", err)
+	}
+
+	return problems, nil
+}
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBManager_IntegrityCheck(t *testing.T) {
+	dbManager, err := NewManager(":memory:")
+	require.NoError(t, err)
+	defer dbManager.Close()
+
+	_, err = dbManager.GetDB().Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL UNIQUE)`)
+	require.NoError(t, err)
+	_, err = dbManager.GetDB().Exec(`INSERT INTO items (name) VALUES ('a'), ('b')`)
+	require.NoError(t, err)
+
+	problems, err := dbManager.IntegrityCheck(context.Background())
+	require.NoError(t, err)
+	assert.NotNil(t, problems)
+	assert.Empty(t, problems)
+}
@@ -8,7 +8,9 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
 	_ "modernc.org/sqlite"
 )
 
@@ -80,12 +82,25 @@ type TransactionFunc func(ctx context.Context) error
 // WithTransaction executes the given function within a database transaction.
 // The transaction is committed if the function returns nil, otherwise it's rolled back.
 // The transaction is stored in the context and can be retrieved using GetTx(ctx).
+// At debug level, begin, commit and rollback are logged with the transaction's duration
+// and the request ID found in ctx. A transaction already in ctx is joined rather than
+// nested: fn runs in it and the outer call decides whether it is committed.
 func (m *DBManager) WithTransaction(ctx context.Context, fn TransactionFunc) error {
+	if GetTx(ctx) != nil {
+		return fn(ctx)
+	}
+
 	tx, err := m.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	start := time.Now()
+	debug := logger.L().IsLevelEnabled(logger.DebugLevel)
+	if debug {
+		logger.FromContext(ctx).Debug("Transaction started")
+	}
+
 	// Store transaction in context
 	txCtx := context.WithValue(ctx, txContextKey, tx)
 
@@ -95,6 +110,9 @@ func (m *DBManager) WithTransaction(ctx context.Context, fn TransactionFunc) err
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("transaction failed: %w; rollback also failed: %v", err, rbErr)
 		}
+		if debug {
+			txLog(ctx, start).WithError(err).Debug("Transaction rolled back")
+		}
 		return err
 	}
 
@@ -102,10 +120,18 @@ func (m *DBManager) WithTransaction(ctx context.Context, fn TransactionFunc) err
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	if debug {
+		txLog(ctx, start).Debug("Transaction committed")
+	}
 
 	return nil
 }
 
+// txLog returns a logger carrying the request ID from ctx and the time since start
+func txLog(ctx context.Context, start time.Time) *logger.Logger {
+	return logger.FromContext(ctx).WithField("duration_ms", float64(time.Since(start).Microseconds())/1000)
+}
+
 // GetTx retrieves the transaction from the context.
 // Returns nil if no transaction is in the context.
 func GetTx(ctx context.Context) *sql.Tx {
@@ -1,9 +1,14 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/nekoteoj/lab-cms/internal/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -118,12 +123,9 @@ func TestDBManager_WithTransaction(t *testing.T) {
 			_, err := outerTx.ExecContext(txCtx, "INSERT INTO test_items (name) VALUES (?)", "nested_test")
 			require.NoError(t, err)
 
-			// Try to start another transaction - should use savepoint in SQLite
+			// Starting another transaction joins the outer one
 			err = dbManager.WithTransaction(txCtx, func(innerCtx context.Context) error {
-				innerTx := GetTx(innerCtx)
-				// In SQLite, nested transactions create savepoints but GetTx should return a transaction
-				assert.NotNil(t, innerTx)
-				// The inner context should have transaction from context
+				assert.Same(t, outerTx, GetTx(innerCtx))
 				return nil
 			})
 			require.NoError(t, err)
@@ -140,6 +142,84 @@ func TestDBManager_WithTransaction(t *testing.T) {
 	})
 }
 
+// txLogLine is the part of a JSON log line the transaction logging tests look at
+type txLogLine struct {
+	Level     string                 `json:"level"`
+	Message   string                 `json:"message"`
+	RequestID string                 `json:"request_id"`
+	Error     string                 `json:"error"`
+	Fields    map[string]interface{} `json:"fields"`
+}
+
+// captureTxLogs runs fn with the global logger at level and returns the JSON log lines written
+func captureTxLogs(t *testing.T, level string, fn func()) []txLogLine {
+	var buf bytes.Buffer
+	logger.Init(level, true, "", "")
+	logger.SetOutput(&buf)
+	t.Cleanup(func() {
+		logger.Init("info", false, "", "")
+		logger.SetOutput(os.Stdout)
+	})
+
+	fn()
+
+	var lines []txLogLine
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if raw == "" {
+			continue
+		}
+		var line txLogLine
+		require.NoError(t, json.Unmarshal([]byte(raw), &line), raw)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestDBManager_WithTransaction_Logging(t *testing.T) {
+	dbManager, err := NewManager(":memory:")
+	require.NoError(t, err)
+	defer dbManager.Close()
+
+	ctx := logger.ContextWithRequestID(context.Background(), "req-42")
+
+	t.Run("commit logged at debug", func(t *testing.T) {
+		lines := captureTxLogs(t, "debug", func() {
+			require.NoError(t, dbManager.WithTransaction(ctx, func(context.Context) error { return nil }))
+		})
+
+		require.Len(t, lines, 2)
+		assert.Equal(t, "Transaction started", lines[0].Message)
+		assert.Equal(t, "Transaction committed", lines[1].Message)
+		for _, line := range lines {
+			assert.Equal(t, "debug", line.Level)
+			assert.Equal(t, "req-42", line.RequestID)
+		}
+		assert.Contains(t, lines[1].Fields, "duration_ms")
+	})
+
+	t.Run("rollback logged with error", func(t *testing.T) {
+		lines := captureTxLogs(t, "debug", func() {
+			err := dbManager.WithTransaction(ctx, func(context.Context) error { return assert.AnError })
+			require.ErrorIs(t, err, assert.AnError)
+		})
+
+		require.Len(t, lines, 2)
+		assert.Equal(t, "Transaction started", lines[0].Message)
+		assert.Equal(t, "Transaction rolled back", lines[1].Message)
+		assert.Equal(t, "debug", lines[1].Level)
+		assert.Equal(t, assert.AnError.Error(), lines[1].Error)
+		assert.Contains(t, lines[1].Fields, "duration_ms")
+	})
+
+	t.Run("silent above debug", func(t *testing.T) {
+		lines := captureTxLogs(t, "info", func() {
+			require.NoError(t, dbManager.WithTransaction(ctx, func(context.Context) error { return nil }))
+		})
+
+		assert.Empty(t, lines)
+	})
+}
+
 func TestGetTx(t *testing.T) {
 	t.Run("returns nil when no transaction", func(t *testing.T) {
 		ctx := context.Background()
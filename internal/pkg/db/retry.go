@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// ErrBusy marks an error as a transient lock conflict worth retrying.
+// Errors returned by SQLite with SQLITE_BUSY or SQLITE_LOCKED are detected automatically;
+// wrap other errors with ErrBusy to have WithTransactionRetry retry them too.
+var ErrBusy = errors.New("database is busy")
+
+// retryBaseDelay is the wait before the first retry; it doubles after each attempt.
+var retryBaseDelay = 20 * time.Millisecond
+
+// IsBusyError reports whether err is a transient SQLITE_BUSY/SQLITE_LOCKED failure.
+func IsBusyError(err error) bool {
+	if errors.Is(err, ErrBusy) {
+		return true
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		// Extended result codes keep the primary code in the low byte
+		switch sqliteErr.Code() & 0xff {
+		case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithTransactionRetry runs fn in a transaction like WithTransaction, re-running the whole
+// transaction up to attempts times when it fails with a busy error, with exponential backoff.
+// Each failed attempt is rolled back before the next one starts, so fn must be safe to run
+// more than once: it should only change state through the transaction in its context and
+// must not have side effects (sending email, writing files) that cannot be repeated.
+// Inside a transaction already in ctx fn runs once: only the outer transaction can be retried.
+func (m *DBManager) WithTransactionRetry(ctx context.Context, attempts int, fn TransactionFunc) error {
+	if GetTx(ctx) != nil || attempts < 1 {
+		attempts = 1
+	}
+
+	delay := retryBaseDelay
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = m.WithTransaction(ctx, fn)
+		if err == nil || !IsBusyError(err) || attempt == attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return err
+}
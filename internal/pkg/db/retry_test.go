@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRetryTest(t *testing.T) *DBManager {
+	dbManager, err := NewManager(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { dbManager.Close() })
+
+	_, err = dbManager.GetDB().Exec(`CREATE TABLE retry_items (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL)`)
+	require.NoError(t, err)
+
+	// Keep retries fast in tests
+	original := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = original })
+
+	return dbManager
+}
+
+func countRetryItems(t *testing.T, dbManager *DBManager) int {
+	var count int
+	require.NoError(t, dbManager.GetDB().QueryRow("SELECT COUNT(*) FROM retry_items").Scan(&count))
+	return count
+}
+
+func TestDBManager_WithTransactionRetry(t *testing.T) {
+	t.Run("commits on retry after busy error", func(t *testing.T) {
+		dbManager := setupRetryTest(t)
+
+		calls := 0
+		err := dbManager.WithTransactionRetry(context.Background(), 3, func(txCtx context.Context) error {
+			calls++
+			if _, err := GetTx(txCtx).ExecContext(txCtx, "INSERT INTO retry_items (name) VALUES (?)", "item"); err != nil {
+				return err
+			}
+			if calls == 1 {
+				return fmt.Errorf("insert item: %w", ErrBusy)
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		// The first attempt was rolled back, so only one row exists
+		assert.Equal(t, 1, countRetryItems(t, dbManager))
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		dbManager := setupRetryTest(t)
+
+		calls := 0
+		err := dbManager.WithTransactionRetry(context.Background(), 3, func(txCtx context.Context) error {
+			calls++
+			return assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after attempts", func(t *testing.T) {
+		dbManager := setupRetryTest(t)
+
+		calls := 0
+		err := dbManager.WithTransactionRetry(context.Background(), 3, func(txCtx context.Context) error {
+			calls++
+			return ErrBusy
+		})
+
+		assert.ErrorIs(t, err, ErrBusy)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 0, countRetryItems(t, dbManager))
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		dbManager := setupRetryTest(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		err := dbManager.WithTransactionRetry(ctx, 5, func(txCtx context.Context) error {
+			calls++
+			cancel()
+			return ErrBusy
+		})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("not retried inside an outer transaction", func(t *testing.T) {
+		dbManager := setupRetryTest(t)
+
+		calls := 0
+		err := dbManager.WithTransaction(context.Background(), func(txCtx context.Context) error {
+			return dbManager.WithTransactionRetry(txCtx, 3, func(context.Context) error {
+				calls++
+				return ErrBusy
+			})
+		})
+
+		assert.ErrorIs(t, err, ErrBusy)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestIsBusyError(t *testing.T) {
+	assert.True(t, IsBusyError(ErrBusy))
+	assert.True(t, IsBusyError(fmt.Errorf("wrapped: %w", ErrBusy)))
+	assert.False(t, IsBusyError(errors.New("database is busy")))
+	assert.False(t, IsBusyError(nil))
+}
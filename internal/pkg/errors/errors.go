@@ -185,6 +185,17 @@ func Database(err error) *AppError {
 	}
 }
 
+// StorageFull creates an error for writes rejected because the disk is full.
+// 507 tells clients the request was valid and can be retried once space is freed.
+func StorageFull(err error) *AppError {
+	return &AppError{
+		Code:       "STORAGE_FULL",
+		Message:    "The server is out of storage space. Please contact an administrator.",
+		StatusCode: http.StatusInsufficientStorage,
+		Cause:      err,
+	}
+}
+
 // Error checking helpers
 
 // IsNotFound returns true if the error is a not found error
@@ -215,6 +215,21 @@ func TestDatabase(t *testing.T) {
 	}
 }
 
+func TestStorageFull(t *testing.T) {
+	cause := errors.New("database or disk is full")
+	err := StorageFull(cause)
+
+	if err.Code != "STORAGE_FULL" {
+		t.Errorf("Code = %v, want STORAGE_FULL", err.Code)
+	}
+	if err.StatusCode != http.StatusInsufficientStorage {
+		t.Errorf("StatusCode = %v, want 507", err.StatusCode)
+	}
+	if err.Cause != cause {
+		t.Error("Should wrap original error")
+	}
+}
+
 func TestIsNotFound(t *testing.T) {
 	tests := []struct {
 		name     string
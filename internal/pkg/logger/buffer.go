@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultFlushInterval is how often buffered log output is written out
+const DefaultFlushInterval = time.Second
+
+// bufferedWriter batches log lines in memory and writes them to the underlying
+// writer when the buffer fills, on every tick of the flush interval, and on Flush.
+type bufferedWriter struct {
+	mu   sync.Mutex
+	buf  *bufio.Writer
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newBufferedWriter wraps w and starts the periodic flush; Close stops it
+func newBufferedWriter(w io.Writer, interval time.Duration) *bufferedWriter {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	b := &bufferedWriter{
+		buf:  bufio.NewWriter(w),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(b.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				_ = b.Flush()
+			case <-b.stop:
+				return
+			}
+		}
+	}()
+
+	return b
+}
+
+// Write implements io.Writer
+func (b *bufferedWriter) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Flush writes any buffered output to the underlying writer
+func (b *bufferedWriter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Flush()
+}
+
+// Close stops the periodic flush and writes out what is left
+func (b *bufferedWriter) Close() error {
+	close(b.stop)
+	<-b.done
+	return b.Flush()
+}
+
+// EnableBuffering makes the global logger buffer its output and flush it every interval
+// (DefaultFlushInterval when interval is not positive), instead of writing each line
+// with its own syscall. Call Flush before the program exits; Fatal does so itself.
+// Calling it again while buffering is already enabled has no effect.
+func EnableBuffering(interval time.Duration) {
+	l := L()
+	if _, ok := l.output.Writer().(*bufferedWriter); ok {
+		return
+	}
+	l.output.SetOutput(newBufferedWriter(l.output.Writer(), interval))
+}
+
+// Flush writes out any log output buffered by the global logger. It is a no-op
+// when buffering is not enabled.
+func Flush() error {
+	return L().Flush()
+}
+
+// Flush writes out any log output buffered by l. It is a no-op when buffering is
+// not enabled.
+func (l *Logger) Flush() error {
+	if b, ok := l.output.Writer().(*bufferedWriter); ok {
+		return b.Flush()
+	}
+	return nil
+}
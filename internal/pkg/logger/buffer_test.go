@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while the flush goroutine writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
+
+func TestBufferedWriter_FlushesPeriodically(t *testing.T) {
+	var out syncBuffer
+	bw := newBufferedWriter(&out, 10*time.Millisecond)
+	defer bw.Close()
+
+	logger := &Logger{
+		level:  InfoLevel,
+		output: log.New(bw, "", 0),
+		fields: make(map[string]interface{}),
+	}
+	logger.Info("buffered line")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(out.String(), "buffered line") {
+		if time.Now().After(deadline) {
+			t.Fatal("buffered output was never flushed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBufferedWriter_FlushAndClose(t *testing.T) {
+	var out syncBuffer
+	bw := newBufferedWriter(&out, time.Hour)
+
+	logger := &Logger{
+		level:  InfoLevel,
+		output: log.New(bw, "", 0),
+		fields: make(map[string]interface{}),
+	}
+	logger.Info("first")
+	if out.String() != "" {
+		t.Fatalf("output should stay buffered until flushed, got: %q", out.String())
+	}
+
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "first") {
+		t.Errorf("Flush should write buffered output, got: %q", out.String())
+	}
+
+	logger.Info("second")
+	if err := bw.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "second") {
+		t.Errorf("Close should write remaining output, got: %q", out.String())
+	}
+}
+
+func TestLogger_FlushUnbuffered(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &Logger{
+		level:  InfoLevel,
+		output: log.New(&buf, "", 0),
+		fields: make(map[string]interface{}),
+	}
+
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Flush without buffering should be a no-op, got: %v", err)
+	}
+}
+
+func TestLogger_FatalFlushesBeforeExit(t *testing.T) {
+	var out syncBuffer
+	bw := newBufferedWriter(&out, time.Hour)
+	defer bw.Close()
+
+	logger := &Logger{
+		level:  InfoLevel,
+		output: log.New(bw, "", 0),
+		fields: make(map[string]interface{}),
+	}
+
+	var flushedAtExit string
+	exitCode := -1
+	origExit := ExitFunc
+	ExitFunc = func(code int) {
+		exitCode = code
+		flushedAtExit = out.String()
+	}
+	defer func() { ExitFunc = origExit }()
+
+	logger.Fatal("cannot continue")
+
+	if exitCode != 1 {
+		t.Errorf("Expected exit code 1, got %d", exitCode)
+	}
+	if !strings.Contains(flushedAtExit, "cannot continue") {
+		t.Errorf("Fatal message should be flushed before exit, got: %q", flushedAtExit)
+	}
+}
@@ -0,0 +1,26 @@
+package logger
+
+import "context"
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID, so that packages
+// below the HTTP layer (such as db) can tag their log lines with it
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by ContextWithRequestID, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext returns the global logger tagged with the request ID found in ctx, if any
+func FromContext(ctx context.Context) *Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return L().WithRequestID(id)
+	}
+	return L()
+}
@@ -5,12 +5,16 @@ package logger
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 	"sync"
 	"time"
+
+	apperrors "github.com/nekoteoj/lab-cms/internal/pkg/errors"
 )
 
 // LogLevel represents the severity of a log entry
@@ -59,15 +63,54 @@ func ParseLogLevel(level string) LogLevel {
 	}
 }
 
+// TimeFormat selects how log timestamps are written
+type TimeFormat int
+
+const (
+	// TimeFormatRFC3339 writes timestamps as RFC3339 strings in UTC (the default)
+	TimeFormatRFC3339 TimeFormat = iota
+	// TimeFormatEpoch writes timestamps as Unix seconds
+	TimeFormatEpoch
+	// TimeFormatEpochMilli writes timestamps as Unix milliseconds
+	TimeFormatEpochMilli
+)
+
+// ParseTimeFormat converts a string to TimeFormat, defaulting to RFC3339
+func ParseTimeFormat(format string) TimeFormat {
+	switch strings.ToLower(format) {
+	case "epoch":
+		return TimeFormatEpoch
+	case "epochmilli":
+		return TimeFormatEpochMilli
+	default:
+		return TimeFormatRFC3339
+	}
+}
+
+// timestamp formats t; epoch formats are numbers so JSON output carries them unquoted
+func (f TimeFormat) timestamp(t time.Time) interface{} {
+	switch f {
+	case TimeFormatEpoch:
+		return t.Unix()
+	case TimeFormatEpochMilli:
+		return t.UnixMilli()
+	default:
+		return t.UTC().Format(time.RFC3339)
+	}
+}
+
 // Logger provides structured logging with fields
 type Logger struct {
-	level     LogLevel
-	isJSON    bool
-	output    *log.Logger
-	fields    map[string]interface{}
-	fieldsMu  sync.RWMutex
-	requestID string
-	userID    int64
+	level      LogLevel
+	isJSON     bool
+	timeFormat TimeFormat
+	output     *log.Logger
+	fields     map[string]interface{}
+	fieldsMu   sync.RWMutex
+	env        string
+	requestID  string
+	userID     int64
+	err        error
 }
 
 var (
@@ -76,18 +119,23 @@ var (
 	mu           sync.RWMutex
 )
 
-// Init initializes the global logger with the specified configuration
-func Init(level string, isProduction bool) {
+// Init initializes the global logger with the specified configuration.
+// A non-empty env (e.g. "development", "production") is attached to every log line
+// so that logs aggregated from several environments can be told apart.
+// timeFormat is parsed with ParseTimeFormat and applies to both text and JSON output.
+func Init(level string, isProduction bool, env string, timeFormat string) {
 	mu.Lock()
 	defer mu.Unlock()
 
 	logLevel := ParseLogLevel(level)
 
 	globalLogger = &Logger{
-		level:  logLevel,
-		isJSON: isProduction,
-		output: log.New(os.Stdout, "", 0),
-		fields: make(map[string]interface{}),
+		level:      logLevel,
+		isJSON:     isProduction,
+		timeFormat: ParseTimeFormat(timeFormat),
+		output:     log.New(os.Stdout, "", 0),
+		fields:     make(map[string]interface{}),
+		env:        env,
 	}
 }
 
@@ -117,6 +165,12 @@ func L() *Logger {
 	return l
 }
 
+// SetOutput redirects the global logger, e.g. to capture log lines in tests.
+// It replaces any buffering set up by EnableBuffering without flushing it.
+func SetOutput(w io.Writer) {
+	L().output.SetOutput(w)
+}
+
 // WithRequestID returns a new logger with the request ID set
 func (l *Logger) WithRequestID(requestID string) *Logger {
 	newLogger := l.clone()
@@ -131,6 +185,14 @@ func (l *Logger) WithUserID(userID int64) *Logger {
 	return newLogger
 }
 
+// WithError returns a new logger that attaches err to its entries as the error field.
+// If an *errors.AppError is found in the error chain, its code is added as error_code.
+func (l *Logger) WithError(err error) *Logger {
+	newLogger := l.clone()
+	newLogger.err = err
+	return newLogger
+}
+
 // WithField returns a new logger with an additional field
 func (l *Logger) WithField(key string, value interface{}) *Logger {
 	newLogger := l.clone()
@@ -162,12 +224,15 @@ func (l *Logger) clone() *Logger {
 	}
 
 	return &Logger{
-		level:     l.level,
-		isJSON:    l.isJSON,
-		output:    l.output,
-		fields:    newFields,
-		requestID: l.requestID,
-		userID:    l.userID,
+		level:      l.level,
+		isJSON:     l.isJSON,
+		timeFormat: l.timeFormat,
+		output:     l.output,
+		fields:     newFields,
+		env:        l.env,
+		requestID:  l.requestID,
+		userID:     l.userID,
+		err:        l.err,
 	}
 }
 
@@ -211,16 +276,23 @@ func (l *Logger) Errorf(format string, args ...interface{}) {
 	l.log(ErrorLevel, fmt.Sprintf(format, args...), nil)
 }
 
-// Fatal logs a message at error level and exits the program
+// ExitFunc is called by Fatal and Fatalf with exit code 1 once the message has been
+// logged and flushed. It defaults to os.Exit; tests can replace it to observe fatal
+// errors, and programs can wrap it to run cleanup before exiting.
+var ExitFunc = os.Exit
+
+// Fatal logs a message at error level, flushes buffered output and exits the program
 func (l *Logger) Fatal(msg string) {
 	l.log(ErrorLevel, msg, nil)
-	os.Exit(1)
+	_ = l.Flush()
+	ExitFunc(1)
 }
 
-// Fatalf logs a formatted message at error level and exits the program
+// Fatalf logs a formatted message at error level, flushes buffered output and exits the program
 func (l *Logger) Fatalf(format string, args ...interface{}) {
 	l.log(ErrorLevel, fmt.Sprintf(format, args...), nil)
-	os.Exit(1)
+	_ = l.Flush()
+	ExitFunc(1)
 }
 
 // log writes a log entry with the given level and message
@@ -230,9 +302,10 @@ func (l *Logger) log(level LogLevel, msg string, err error) {
 	}
 
 	entry := logEntry{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: l.timeFormat.timestamp(time.Now()),
 		Level:     level.String(),
 		Message:   msg,
+		Env:       l.env,
 	}
 
 	// Add request ID if set
@@ -245,9 +318,17 @@ func (l *Logger) log(level LogLevel, msg string, err error) {
 		entry.UserID = l.userID
 	}
 
-	// Add error if present
+	// Add error if present, falling back to the one set with WithError
+	if err == nil {
+		err = l.err
+	}
 	if err != nil {
 		entry.Error = err.Error()
+
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) {
+			entry.ErrorCode = appErr.Code
+		}
 	}
 
 	// Add custom fields
@@ -271,12 +352,14 @@ func (l *Logger) log(level LogLevel, msg string, err error) {
 
 // logEntry represents a structured log entry
 type logEntry struct {
-	Timestamp string                 `json:"timestamp"`
+	Timestamp interface{}            `json:"timestamp"`
 	Level     string                 `json:"level"`
 	Message   string                 `json:"message"`
+	Env       string                 `json:"env,omitempty"`
 	RequestID string                 `json:"request_id,omitempty"`
 	UserID    int64                  `json:"user_id,omitempty"`
 	Error     string                 `json:"error,omitempty"`
+	ErrorCode string                 `json:"error_code,omitempty"`
 	Fields    map[string]interface{} `json:"fields,omitempty"`
 }
 
@@ -285,9 +368,14 @@ func (e logEntry) String() string {
 	var parts []string
 
 	// Format: [TIMESTAMP] [LEVEL] message
-	parts = append(parts, fmt.Sprintf("[%s]", e.Timestamp))
+	parts = append(parts, fmt.Sprintf("[%v]", e.Timestamp))
 	parts = append(parts, fmt.Sprintf("[%s]", strings.ToUpper(e.Level)))
 
+	// Add environment if present
+	if e.Env != "" {
+		parts = append(parts, fmt.Sprintf("[env:%s]", e.Env))
+	}
+
 	// Add request ID if present
 	if e.RequestID != "" {
 		parts = append(parts, fmt.Sprintf("[req:%s]", e.RequestID))
@@ -304,6 +392,9 @@ func (e logEntry) String() string {
 	// Add error if present
 	if e.Error != "" {
 		parts = append(parts, fmt.Sprintf("| error: %s", e.Error))
+		if e.ErrorCode != "" {
+			parts = append(parts, fmt.Sprintf("(code: %s)", e.ErrorCode))
+		}
 	}
 
 	// Add fields
@@ -3,9 +3,15 @@ package logger
 import (
 	"bytes"
 	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"log"
+	"os"
+	"regexp"
 	"strings"
 	"testing"
+
+	apperrors "github.com/nekoteoj/lab-cms/internal/pkg/errors"
 )
 
 func TestLogLevel_String(t *testing.T) {
@@ -276,7 +282,7 @@ func TestGlobalLogger(t *testing.T) {
 }
 
 func TestInit(t *testing.T) {
-	Init("debug", true)
+	Init("debug", true, "production", "")
 
 	if globalLogger == nil {
 		t.Fatal("Global logger should be initialized")
@@ -289,6 +295,128 @@ func TestInit(t *testing.T) {
 	if !globalLogger.isJSON {
 		t.Error("Should be JSON mode in production")
 	}
+
+	if globalLogger.env != "production" {
+		t.Errorf("Env should be production, got %q", globalLogger.env)
+	}
+}
+
+func TestLogger_Env(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &Logger{
+			level:  InfoLevel,
+			isJSON: false,
+			output: log.New(&buf, "", 0),
+			fields: make(map[string]interface{}),
+			env:    "staging",
+		}
+
+		logger.WithField("key", "value").Info("test message")
+
+		output := buf.String()
+		if !strings.Contains(output, "[env:staging]") {
+			t.Errorf("Env should be in log output, got: %s", output)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &Logger{
+			level:  InfoLevel,
+			isJSON: true,
+			output: log.New(&buf, "", 0),
+			fields: make(map[string]interface{}),
+			env:    "production",
+		}
+
+		logger.WithRequestID("req-123").Info("test message")
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, buf.String())
+		}
+		if entry["env"] != "production" {
+			t.Errorf("Expected env 'production', got %v", entry["env"])
+		}
+	})
+
+	t.Run("omitted when unset", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := &Logger{
+			level:  InfoLevel,
+			isJSON: true,
+			output: log.New(&buf, "", 0),
+			fields: make(map[string]interface{}),
+		}
+
+		logger.Info("test message")
+
+		if strings.Contains(buf.String(), `"env"`) {
+			t.Errorf("Env should be omitted when not configured, got: %s", buf.String())
+		}
+	})
+}
+
+func TestLogger_WithError(t *testing.T) {
+	newLogger := func(buf *bytes.Buffer) *Logger {
+		return &Logger{
+			level:  InfoLevel,
+			isJSON: true,
+			output: log.New(buf, "", 0),
+			fields: make(map[string]interface{}),
+		}
+	}
+
+	t.Run("app error", func(t *testing.T) {
+		var buf bytes.Buffer
+		appErr := apperrors.NewAppError("NOT_FOUND", "Member not found", 404).Wrap(stderrors.New("no rows"))
+
+		newLogger(&buf).WithError(fmt.Errorf("load member: %w", appErr)).Error("request failed")
+
+		var entry logEntry
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, buf.String())
+		}
+		if entry.Error != "load member: Member not found: no rows" {
+			t.Errorf("Unexpected error field: %q", entry.Error)
+		}
+		if entry.ErrorCode != "NOT_FOUND" {
+			t.Errorf("Expected error_code 'NOT_FOUND', got %q", entry.ErrorCode)
+		}
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		newLogger(&buf).WithError(stderrors.New("disk full")).Errorf("save %s failed", "photo")
+
+		var entry map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Failed to parse JSON output: %v\nOutput: %s", err, buf.String())
+		}
+		if entry["error"] != "disk full" {
+			t.Errorf("Expected error 'disk full', got %v", entry["error"])
+		}
+		if _, ok := entry["error_code"]; ok {
+			t.Errorf("error_code should be omitted for plain errors, got %v", entry["error_code"])
+		}
+		if entry["message"] != "save photo failed" {
+			t.Errorf("Unexpected message: %v", entry["message"])
+		}
+	})
+
+	t.Run("does not leak into parent", func(t *testing.T) {
+		var buf bytes.Buffer
+		parent := newLogger(&buf)
+		parent.WithError(stderrors.New("boom"))
+
+		parent.Error("unrelated")
+
+		if strings.Contains(buf.String(), "boom") {
+			t.Errorf("Parent logger should not carry the error, got: %s", buf.String())
+		}
+	})
 }
 
 func TestLogger_IsLevelEnabled(t *testing.T) {
@@ -347,3 +475,139 @@ func TestLogEntry_String(t *testing.T) {
 		}
 	}
 }
+
+func TestParseTimeFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TimeFormat
+	}{
+		{"rfc3339", TimeFormatRFC3339},
+		{"epoch", TimeFormatEpoch},
+		{"EPOCHMILLI", TimeFormatEpochMilli},
+		{"", TimeFormatRFC3339},        // default
+		{"unknown", TimeFormatRFC3339}, // default
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ParseTimeFormat(tt.input); got != tt.expected {
+				t.Errorf("ParseTimeFormat(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLogger_TimeFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format TimeFormat
+		// json matches the timestamp as it appears in JSON output, text in text output
+		json *regexp.Regexp
+		text *regexp.Regexp
+	}{
+		{
+			name:   "rfc3339",
+			format: TimeFormatRFC3339,
+			json:   regexp.MustCompile(`"timestamp":"\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z"`),
+			text:   regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z\] `),
+		},
+		{
+			name:   "epoch",
+			format: TimeFormatEpoch,
+			json:   regexp.MustCompile(`"timestamp":\d{10},`),
+			text:   regexp.MustCompile(`^\[\d{10}\] `),
+		},
+		{
+			name:   "epochmilli",
+			format: TimeFormatEpochMilli,
+			json:   regexp.MustCompile(`"timestamp":\d{13},`),
+			text:   regexp.MustCompile(`^\[\d{13}\] `),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, isJSON := range []bool{true, false} {
+				var buf bytes.Buffer
+				logger := &Logger{
+					level:      InfoLevel,
+					isJSON:     isJSON,
+					timeFormat: tt.format,
+					output:     log.New(&buf, "", 0),
+					fields:     make(map[string]interface{}),
+				}
+
+				// Derived loggers keep the format
+				logger.WithField("k", "v").Info("message")
+
+				want := tt.text
+				if isJSON {
+					want = tt.json
+				}
+				if !want.MatchString(buf.String()) {
+					t.Errorf("isJSON=%v: output %q does not match %s", isJSON, buf.String(), want)
+				}
+			}
+		})
+	}
+}
+
+// overrideExit replaces ExitFunc for the duration of the test and records the codes it was called with
+func overrideExit(t *testing.T) *[]int {
+	var codes []int
+	orig := ExitFunc
+	ExitFunc = func(code int) { codes = append(codes, code) }
+	t.Cleanup(func() { ExitFunc = orig })
+	return &codes
+}
+
+func TestLogger_Fatal(t *testing.T) {
+	codes := overrideExit(t)
+
+	var buf bytes.Buffer
+	logger := &Logger{
+		level:  InfoLevel,
+		output: log.New(&buf, "", 0),
+		fields: make(map[string]interface{}),
+	}
+	logger.Fatal("fatal message")
+
+	if !strings.Contains(buf.String(), "[ERROR] fatal message") {
+		t.Errorf("Fatal should log at error level, got: %s", buf.String())
+	}
+	if len(*codes) != 1 || (*codes)[0] != 1 {
+		t.Errorf("Fatal should request exit code 1 once, got: %v", *codes)
+	}
+}
+
+func TestLogger_Fatalf(t *testing.T) {
+	codes := overrideExit(t)
+
+	var buf bytes.Buffer
+	logger := &Logger{
+		level:  ErrorLevel,
+		output: log.New(&buf, "", 0),
+		fields: make(map[string]interface{}),
+	}
+	logger.Fatalf("failed after %d attempts", 3)
+
+	if !strings.Contains(buf.String(), "failed after 3 attempts") {
+		t.Errorf("Fatalf should log the formatted message, got: %s", buf.String())
+	}
+	if len(*codes) != 1 || (*codes)[0] != 1 {
+		t.Errorf("Fatalf should request exit code 1 once, got: %v", *codes)
+	}
+}
+
+func TestSetOutput(t *testing.T) {
+	Init("info", false, "", "")
+	defer SetOutput(os.Stdout)
+
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	L().Info("redirected message")
+
+	if !strings.Contains(buf.String(), "redirected message") {
+		t.Errorf("Log line should be written to the new output, got: %q", buf.String())
+	}
+}
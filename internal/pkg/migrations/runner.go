@@ -78,6 +78,63 @@ func (r *Runner) Run() error {
 	return nil
 }
 
+// DryRun returns the migrations Run would apply, in order, without executing them.
+// The database is not modified: if schema_migrations does not exist yet, every
+// migration is reported as pending.
+func (r *Runner) DryRun() ([]Migration, error) {
+	migrations, err := r.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	var tableExists bool
+	err = r.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations')",
+	).Scan(&tableExists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+
+	applied := map[int]bool{}
+	if tableExists {
+		if applied, err = r.getAppliedMigrations(); err != nil {
+			return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+		}
+	}
+
+	var pending []Migration
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// DetectGaps returns the versions missing from the sequence of migration files, counting
+// from 1 up to the highest version found, e.g. [2] for 001, 003 and 004. A gap usually
+// means a migration file was lost. The result is empty when the sequence is contiguous.
+func (r *Runner) DetectGaps() ([]int, error) {
+	migrations, err := r.loadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	var gaps []int
+	next := 1
+	for _, m := range migrations {
+		for ; next < m.Version; next++ {
+			gaps = append(gaps, next)
+		}
+		if m.Version >= next {
+			next = m.Version + 1
+		}
+	}
+
+	return gaps, nil
+}
+
 // createMigrationsTable creates the schema_migrations table if it doesn't exist.
 func (r *Runner) createMigrationsTable() error {
 	_, err := r.db.Exec(`
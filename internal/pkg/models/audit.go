@@ -0,0 +1,23 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuditEntry records a single content change made by an admin user
+type AuditEntry struct {
+	ID        int           `json:"id"`
+	UserID    sql.NullInt64 `json:"user_id,omitempty"`
+	Action    string        `json:"action" validate:"required"`
+	Entity    string        `json:"entity" validate:"required"`
+	EntityID  int           `json:"entity_id" validate:"required"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Audit actions for content changes
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntry_Validation(t *testing.T) {
+	v := newValidator()
+
+	entry := AuditEntry{
+		UserID:   sql.NullInt64{Int64: 1, Valid: true},
+		Action:   AuditActionCreate,
+		Entity:   "publication",
+		EntityID: 42,
+	}
+	assert.NoError(t, validateStruct(v, entry), "valid audit entry should pass validation")
+
+	entry.Action = ""
+	assert.Error(t, validateStruct(v, entry), "empty action should fail validation")
+}
+
+func TestAuditActionConstants(t *testing.T) {
+	assert.Equal(t, "create", AuditActionCreate)
+	assert.Equal(t, "update", AuditActionUpdate)
+	assert.Equal(t, "delete", AuditActionDelete)
+}
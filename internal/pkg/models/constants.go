@@ -20,6 +20,16 @@ const (
 	LabMemberRoleResearcher LabMemberRole = "Researcher"
 )
 
+// LabMemberRoleOrder lists lab member roles in the canonical order used on the team page
+var LabMemberRoleOrder = []LabMemberRole{
+	LabMemberRolePI,
+	LabMemberRolePostdoc,
+	LabMemberRoleResearcher,
+	LabMemberRolePhD,
+	LabMemberRoleMaster,
+	LabMemberRoleBachelor,
+}
+
 // ProjectStatus defines the possible statuses for projects
 type ProjectStatus string
 
@@ -42,6 +42,19 @@ func TestConstants_LabMemberRole(t *testing.T) {
 	}
 }
 
+func TestConstants_LabMemberRoleOrder(t *testing.T) {
+	expected := []LabMemberRole{
+		LabMemberRolePI,
+		LabMemberRolePostdoc,
+		LabMemberRoleResearcher,
+		LabMemberRolePhD,
+		LabMemberRoleMaster,
+		LabMemberRoleBachelor,
+	}
+
+	assert.Equal(t, expected, LabMemberRoleOrder)
+}
+
 func TestConstants_ProjectStatus(t *testing.T) {
 	tests := []struct {
 		status   ProjectStatus
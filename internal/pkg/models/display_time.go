@@ -0,0 +1,43 @@
+package models
+
+import (
+	"sync"
+	"time"
+)
+
+// DisplayTimeLayout is how timestamps are shown to visitors, e.g. "2024-03-01 14:30 CET"
+const DisplayTimeLayout = "2006-01-02 15:04 MST"
+
+var (
+	displayLocationMu sync.RWMutex
+	displayLocation   = time.UTC
+)
+
+// SetDisplayLocation sets the time zone human-facing timestamps are shown in.
+// It is called once at startup from the configuration; nil keeps UTC.
+func SetDisplayLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	displayLocationMu.Lock()
+	defer displayLocationMu.Unlock()
+	displayLocation = loc
+}
+
+// DisplayLocation returns the time zone human-facing timestamps are shown in
+func DisplayLocation() *time.Location {
+	displayLocationMu.RLock()
+	defer displayLocationMu.RUnlock()
+	return displayLocation
+}
+
+// FormatDisplayTime formats a stored (UTC) timestamp in the display time zone using
+// DisplayTimeLayout. The zero time formats as "" so unset dates render blank.
+// API responses keep RFC 3339 timestamps; this is only for pages read by people.
+func FormatDisplayTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(DisplayLocation()).Format(DisplayTimeLayout)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatDisplayTime(t *testing.T) {
+	t.Cleanup(func() { SetDisplayLocation(nil) })
+
+	stored := time.Date(2024, 7, 1, 22, 30, 0, 0, time.UTC)
+
+	t.Run("defaults to UTC", func(t *testing.T) {
+		assert.Equal(t, "2024-07-01 22:30 UTC", FormatDisplayTime(stored))
+	})
+
+	t.Run("configured zone", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Paris")
+		require.NoError(t, err)
+		SetDisplayLocation(loc)
+
+		// Summer time: UTC+2, which moves the timestamp to the next day
+		assert.Equal(t, "2024-07-02 00:30 CEST", FormatDisplayTime(stored))
+		assert.Equal(t, "2024-01-15 13:00 CET", FormatDisplayTime(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("nil resets to UTC", func(t *testing.T) {
+		SetDisplayLocation(nil)
+		assert.Equal(t, time.UTC, DisplayLocation())
+	})
+
+	t.Run("zero time is blank", func(t *testing.T) {
+		assert.Empty(t, FormatDisplayTime(time.Time{}))
+	})
+}
@@ -7,9 +7,11 @@ type ProjectMember struct {
 }
 
 // PublicationAuthor represents the many-to-many relationship between publications and lab members
+// AuthorOrder is the 0-based position of the member in the publication's author list
 type PublicationAuthor struct {
 	PublicationID int `json:"publication_id" validate:"required"`
 	MemberID      int `json:"member_id" validate:"required"`
+	AuthorOrder   int `json:"author_order" validate:"min=0"`
 }
 
 // ProjectPublication represents the many-to-many relationship between projects and publications
@@ -10,6 +10,7 @@ type LabSetting struct {
 	ID           int       `json:"id"`
 	SettingKey   string    `json:"setting_key" validate:"required,max=255"`
 	SettingValue string    `json:"setting_value" validate:"required"`
+	Locale       string    `json:"locale,omitempty" validate:"max=35"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -19,3 +20,10 @@ const (
 	LabSettingName        = "lab_name"
 	LabSettingDescription = "lab_description"
 )
+
+// DefaultSettingLocale is the locale of a setting's untranslated value, used as the
+// fallback when no value exists for the requested locale
+const DefaultSettingLocale = ""
+
+// DefaultLabName is shown in page titles and feeds when no lab name has been set
+const DefaultLabName = "Research Lab"
@@ -8,6 +8,7 @@ import (
 type Project struct {
 	ID          int           `json:"id"`
 	Title       string        `json:"title" validate:"required,max=255"`
+	Slug        string        `json:"slug,omitempty" validate:"omitempty,max=100"`
 	Description string        `json:"description" validate:"required"`
 	Status      ProjectStatus `json:"status" validate:"required,oneof=active completed"`
 	CreatedAt   time.Time     `json:"created_at"`
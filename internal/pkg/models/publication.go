@@ -9,9 +9,9 @@ import (
 type Publication struct {
 	ID          int            `json:"id"`
 	Title       string         `json:"title" validate:"required,max=500"`
-	AuthorsText string         `json:"authors_text" validate:"required"`
+	AuthorsText string         `json:"authors_text" validate:"required,authorslen"`
 	Venue       sql.NullString `json:"venue,omitempty"`
-	Year        int            `json:"year" validate:"required,min=1900,max=2100"`
+	Year        int            `json:"year" validate:"required,pubyear"`
 	URL         sql.NullString `json:"url,omitempty"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
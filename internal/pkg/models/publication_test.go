@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -180,3 +181,43 @@ func TestPublication_JSON_NullableOmit(t *testing.T) {
 	assert.Contains(t, jsonStr, "\"url\"")
 	assert.Contains(t, jsonStr, "\"Valid\":false")
 }
+
+func TestPublication_Validation_ConfiguredYearRange(t *testing.T) {
+	t.Cleanup(func() {
+		SetPublicationYearRange(DefaultPublicationMinYear, DefaultPublicationMaxYear)
+	})
+	SetPublicationYearRange(1600, 0)
+
+	min, max := PublicationYearRange()
+	assert.Equal(t, 1600, min)
+	assert.Equal(t, DefaultPublicationMaxYear, max, "zero bound keeps the default")
+
+	v := newValidator()
+	valid := func(year int) error {
+		return validateStruct(v, Publication{Title: "Principia", AuthorsText: "Isaac Newton", Year: year})
+	}
+
+	assert.NoError(t, valid(1687), "year allowed by the widened range")
+	assert.NoError(t, valid(1600), "lower bound is inclusive")
+	assert.Error(t, valid(1599), "year before the widened range")
+	assert.Error(t, valid(2101), "year after the range")
+}
+
+func TestPublication_Validation_AuthorsTextLength(t *testing.T) {
+	t.Cleanup(func() {
+		SetAuthorsTextMaxLength(DefaultAuthorsTextMaxLength)
+	})
+	SetAuthorsTextMaxLength(20)
+
+	v := newValidator()
+	valid := func(authors string) error {
+		return validateStruct(v, Publication{Title: "Title", AuthorsText: authors, Year: 2024})
+	}
+
+	assert.NoError(t, valid("Ada Lovelace"), "short author list")
+	assert.NoError(t, valid(strings.Repeat("é", 20)), "limit counts characters, not bytes")
+	assert.Error(t, valid(strings.Repeat("a", 21)), "author list over the limit")
+
+	SetAuthorsTextMaxLength(0)
+	assert.Equal(t, DefaultAuthorsTextMaxLength, AuthorsTextMaxLength(), "zero keeps the default")
+}
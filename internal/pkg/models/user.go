@@ -1,17 +1,19 @@
 package models
 
 import (
+	"database/sql"
 	"time"
 )
 
 // User represents an admin user in the system
 // Password hash is handled separately for security
 type User struct {
-	ID        int       `json:"id"`
-	Email     string    `json:"email" validate:"required,email,max=255"`
-	Role      UserRole  `json:"role" validate:"required,oneof=normal root"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          int          `json:"id"`
+	Email       string       `json:"email" validate:"required,email,max=255"`
+	Role        UserRole     `json:"role" validate:"required,oneof=normal root"`
+	LastLoginAt sql.NullTime `json:"last_login_at"` // Unset until the user first logs in
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
 }
 
 // UserWithPassword extends User to include password for authentication
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Default bounds for publication years, used when no range is configured
+const (
+	DefaultPublicationMinYear = 1900
+	DefaultPublicationMaxYear = 2100
+)
+
+// DefaultAuthorsTextMaxLength is the longest authors_text accepted, in characters, when
+// no limit is configured. It fits a large collaboration's author list without letting an
+// imported entry break page layouts.
+const DefaultAuthorsTextMaxLength = 2000
+
+// DefaultNewsMinContentLength is the shortest news content, in characters, that can be
+// published when no minimum is configured. Drafts may be shorter.
+const DefaultNewsMinContentLength = 20
+
+var (
+	publicationYearMu  sync.RWMutex
+	publicationMinYear = DefaultPublicationMinYear
+	publicationMaxYear = DefaultPublicationMaxYear
+
+	authorsTextMu        sync.RWMutex
+	authorsTextMaxLength = DefaultAuthorsTextMaxLength
+
+	newsMinContentMu     sync.RWMutex
+	newsMinContentLength = DefaultNewsMinContentLength
+)
+
+// SetPublicationYearRange sets the years accepted by the pubyear validation rule.
+// It is called once at startup from the configuration; a zero bound keeps its default.
+func SetPublicationYearRange(min, max int) {
+	if min == 0 {
+		min = DefaultPublicationMinYear
+	}
+	if max == 0 {
+		max = DefaultPublicationMaxYear
+	}
+
+	publicationYearMu.Lock()
+	defer publicationYearMu.Unlock()
+	publicationMinYear, publicationMaxYear = min, max
+}
+
+// PublicationYearRange returns the inclusive range of accepted publication years
+func PublicationYearRange() (min, max int) {
+	publicationYearMu.RLock()
+	defer publicationYearMu.RUnlock()
+	return publicationMinYear, publicationMaxYear
+}
+
+// SetAuthorsTextMaxLength sets the length accepted by the authorslen validation rule.
+// It is called once at startup from the configuration; zero keeps the default.
+func SetAuthorsTextMaxLength(max int) {
+	if max == 0 {
+		max = DefaultAuthorsTextMaxLength
+	}
+
+	authorsTextMu.Lock()
+	defer authorsTextMu.Unlock()
+	authorsTextMaxLength = max
+}
+
+// AuthorsTextMaxLength returns the longest accepted authors_text, in characters
+func AuthorsTextMaxLength() int {
+	authorsTextMu.RLock()
+	defer authorsTextMu.RUnlock()
+	return authorsTextMaxLength
+}
+
+// AuthorsTextTooLong reports whether authors exceeds AuthorsTextMaxLength
+func AuthorsTextTooLong(authors string) bool {
+	return utf8.RuneCountInString(authors) > AuthorsTextMaxLength()
+}
+
+// SetNewsMinContentLength sets the shortest content, in characters, that news items need
+// before they can be published. It is called once at startup from the configuration;
+// zero keeps the default.
+func SetNewsMinContentLength(min int) {
+	if min == 0 {
+		min = DefaultNewsMinContentLength
+	}
+
+	newsMinContentMu.Lock()
+	defer newsMinContentMu.Unlock()
+	newsMinContentLength = min
+}
+
+// NewsMinContentLength returns the shortest publishable news content, in characters
+func NewsMinContentLength() int {
+	newsMinContentMu.RLock()
+	defer newsMinContentMu.RUnlock()
+	return newsMinContentLength
+}
+
+// NewValidator returns a validator for the models, with the custom rules used in their
+// validate tags registered:
+//   - pubyear: the year lies within PublicationYearRange
+//   - authorslen: the string is at most AuthorsTextMaxLength characters long
+func NewValidator() *validator.Validate {
+	v := validator.New()
+	_ = v.RegisterValidation("pubyear", func(fl validator.FieldLevel) bool {
+		min, max := PublicationYearRange()
+		year := int(fl.Field().Int())
+		return year >= min && year <= max
+	})
+	_ = v.RegisterValidation("authorslen", func(fl validator.FieldLevel) bool {
+		return !AuthorsTextTooLong(fl.Field().String())
+	})
+	return v
+}
@@ -4,9 +4,9 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
-// newValidator creates a new validator instance with default settings
+// newValidator creates a validator with the model rules registered
 func newValidator() *validator.Validate {
-	return validator.New()
+	return NewValidator()
 }
 
 // validateStruct validates a struct using the validator
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// Entity names recorded in the audit log by the content repositories
+const (
+	AuditEntityPublication     = "publication"
+	AuditEntityLabMember       = "lab_member"
+	AuditEntityProject         = "project"
+	AuditEntityNews            = "news"
+	AuditEntityHomepageSection = "homepage_section"
+)
+
+// actorKey is the context key for the ID of the user making a content change
+type actorKey struct{}
+
+// WithActor returns a copy of ctx that attributes content changes made with it to userID
+// in the audit log. Changes made without an actor are recorded as made by the system.
+func WithActor(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// actorFromContext returns the user ID stored by WithActor, or 0 for the system
+func actorFromContext(ctx context.Context) int {
+	userID, _ := ctx.Value(actorKey{}).(int)
+	return userID
+}
+
+// AuditRepository provides data access for the content change audit log.
+// Entries are append-only; there are no update or delete methods.
+type AuditRepository struct {
+	*BaseRepository
+}
+
+// NewAuditRepository creates a new audit repository.
+func NewAuditRepository(dbManager *db.DBManager) *AuditRepository {
+	return &AuditRepository{
+		BaseRepository: NewBaseRepository(dbManager, "audit_log"),
+	}
+}
+
+// Record appends an audit entry for a change to an entity.
+// A userID of 0 records the change as made by the system.
+// When called with a transaction context, the entry commits or rolls back with the change.
+func (r *AuditRepository) Record(ctx context.Context, userID int, action, entity string, entityID int) error {
+	if action == "" || entity == "" {
+		return ErrInvalidInput
+	}
+
+	return insertAuditEntry(ctx, r.GetExecer(ctx), userID, action, entity, entityID)
+}
+
+// recordAudit appends an audit entry attributed to the actor in ctx (see WithActor).
+func recordAudit(ctx context.Context, execer db.Execer, action, entity string, entityID int) error {
+	return insertAuditEntry(ctx, execer, actorFromContext(ctx), action, entity, entityID)
+}
+
+// insertAuditEntry writes a single audit_log row; a userID of 0 is stored as NULL.
+func insertAuditEntry(ctx context.Context, execer db.Execer, userID int, action, entity string, entityID int) error {
+	query := `
+		INSERT INTO audit_log (user_id, action, entity, entity_id, created_at)
+		VALUES ($1, $2, $3, $4, datetime('now'))
+	`
+
+	var user sql.NullInt64
+	if userID > 0 {
+		user = sql.NullInt64{Int64: int64(userID), Valid: true}
+	}
+
+	_, err := execer.ExecContext(ctx, query, user, action, entity, entityID)
+	if err != nil {
+		return WrapError(err, "record audit entry")
+	}
+
+	return nil
+}
+
+// GetRecent retrieves the most recent audit entries, newest first.
+func (r *AuditRepository) GetRecent(ctx context.Context, limit int) ([]models.AuditEntry, error) {
+	if limit <= 0 {
+		return nil, ErrInvalidInput
+	}
+
+	query := `
+		SELECT id, user_id, action, entity, entity_id, created_at
+		FROM audit_log
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`
+
+	return r.query(ctx, "get recent audit entries", query, limit)
+}
+
+// GetByEntity retrieves the change history of a single entity in chronological order.
+func (r *AuditRepository) GetByEntity(ctx context.Context, entity string, entityID int) ([]models.AuditEntry, error) {
+	query := `
+		SELECT id, user_id, action, entity, entity_id, created_at
+		FROM audit_log
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+
+	return r.query(ctx, "get audit entries by entity", query, entity, entityID)
+}
+
+// query runs an audit log SELECT and scans all resulting rows.
+func (r *AuditRepository) query(ctx context.Context, operation, query string, args ...interface{}) ([]models.AuditEntry, error) {
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, WrapError(err, operation)
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		err := rows.Scan(
+			&e.ID,
+			&e.UserID,
+			&e.Action,
+			&e.Entity,
+			&e.EntityID,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, WrapError(err, "scan audit entry")
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate audit entries")
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepository_Record(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewAuditRepository(dbManager)
+	users := NewUserRepository(dbManager)
+
+	user, err := users.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: "editor@example.com", Role: models.UserRoleNormal},
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Record(ctx, user.ID, models.AuditActionCreate, "publication", 7))
+	require.NoError(t, repo.Record(ctx, user.ID, models.AuditActionUpdate, "publication", 7))
+	require.NoError(t, repo.Record(ctx, 0, models.AuditActionCreate, "news", 3))
+	require.NoError(t, repo.Record(ctx, user.ID, models.AuditActionDelete, "publication", 7))
+
+	t.Run("entity history is chronological", func(t *testing.T) {
+		entries, err := repo.GetByEntity(ctx, "publication", 7)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, models.AuditActionCreate, entries[0].Action)
+		assert.Equal(t, models.AuditActionUpdate, entries[1].Action)
+		assert.Equal(t, models.AuditActionDelete, entries[2].Action)
+		for _, e := range entries {
+			assert.True(t, e.UserID.Valid)
+			assert.Equal(t, int64(user.ID), e.UserID.Int64)
+		}
+	})
+
+	t.Run("recent entries are newest first", func(t *testing.T) {
+		entries, err := repo.GetRecent(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.Equal(t, models.AuditActionDelete, entries[0].Action)
+		assert.Equal(t, "news", entries[1].Entity)
+		assert.False(t, entries[1].UserID.Valid, "system changes have no user")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Record(ctx, user.ID, "", "publication", 1), ErrInvalidInput)
+		_, err := repo.GetRecent(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestAuditRepository_ContentChanges(t *testing.T) {
+	dbManager := setupTestDB(t)
+	audit := NewAuditRepository(dbManager)
+	pubs := NewPublicationRepository(dbManager)
+	users := NewUserRepository(dbManager)
+
+	user, err := users.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: "editor@example.com", Role: models.UserRoleNormal},
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+	actorCtx := WithActor(ctx, user.ID)
+
+	pub, err := pubs.Create(actorCtx, &models.Publication{Title: "Audited", AuthorsText: "A. Author", Year: 2024})
+	require.NoError(t, err)
+	pub.Title = "Audited, revised"
+	_, err = pubs.Update(actorCtx, pub)
+	require.NoError(t, err)
+	require.NoError(t, pubs.Delete(ctx, pub.ID))
+
+	t.Run("writes are recorded in order", func(t *testing.T) {
+		entries, err := audit.GetByEntity(ctx, AuditEntityPublication, pub.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, models.AuditActionCreate, entries[0].Action)
+		assert.Equal(t, models.AuditActionUpdate, entries[1].Action)
+		assert.Equal(t, models.AuditActionDelete, entries[2].Action)
+	})
+
+	t.Run("entries are attributed to the actor", func(t *testing.T) {
+		entries, err := audit.GetByEntity(ctx, AuditEntityPublication, pub.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.Equal(t, int64(user.ID), entries[0].UserID.Int64)
+		assert.Equal(t, int64(user.ID), entries[1].UserID.Int64)
+		assert.False(t, entries[2].UserID.Valid, "changes without an actor are recorded as the system")
+	})
+
+	t.Run("failed writes are not recorded", func(t *testing.T) {
+		assert.ErrorIs(t, pubs.Delete(actorCtx, pub.ID), ErrNotFound)
+
+		entries, err := audit.GetByEntity(ctx, AuditEntityPublication, pub.ID)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+	})
+}
+
+func TestAuditRepository_BulkChanges(t *testing.T) {
+	dbManager := setupTestDB(t)
+	audit := NewAuditRepository(dbManager)
+	pubs := NewPublicationRepository(dbManager)
+	members := NewLabMemberRepository(dbManager)
+	projects := NewProjectRepository(dbManager)
+
+	lastAction := func(t *testing.T, entity string, id int) string {
+		entries, err := audit.GetByEntity(ctx, entity, id)
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		return entries[len(entries)-1].Action
+	}
+
+	t.Run("bulk delete records a delete for each id", func(t *testing.T) {
+		var ids []int
+		for i := 0; i < 3; i++ {
+			pub, err := pubs.Create(ctx, &models.Publication{Title: "Bulk", AuthorsText: "A. Author", Year: 2024})
+			require.NoError(t, err)
+			ids = append(ids, pub.ID)
+		}
+
+		deleted, err := pubs.DeleteBulk(ctx, append(ids, 9999))
+		require.NoError(t, err)
+		require.Equal(t, 3, deleted)
+
+		for _, id := range ids {
+			assert.Equal(t, models.AuditActionDelete, lastAction(t, AuditEntityPublication, id))
+		}
+		entries, err := audit.GetByEntity(ctx, AuditEntityPublication, 9999)
+		require.NoError(t, err)
+		assert.Empty(t, entries, "missing ids are not recorded")
+	})
+
+	t.Run("member merge records an update of each publication", func(t *testing.T) {
+		from, err := members.Create(ctx, &models.LabMember{Name: "Duplicate", Role: models.LabMemberRolePhD})
+		require.NoError(t, err)
+		to, err := members.Create(ctx, &models.LabMember{Name: "Original", Role: models.LabMemberRolePhD})
+		require.NoError(t, err)
+		pub, err := pubs.Create(ctx, &models.Publication{Title: "Merged", AuthorsText: "Duplicate", Year: 2024})
+		require.NoError(t, err)
+		require.NoError(t, pubs.LinkAuthor(ctx, pub.ID, from.ID))
+
+		before, err := audit.GetByEntity(ctx, AuditEntityPublication, pub.ID)
+		require.NoError(t, err)
+
+		_, err = members.ReassignPublications(ctx, from.ID, to.ID)
+		require.NoError(t, err)
+
+		after, err := audit.GetByEntity(ctx, AuditEntityPublication, pub.ID)
+		require.NoError(t, err)
+		require.Len(t, after, len(before)+1)
+		assert.Equal(t, models.AuditActionUpdate, after[len(after)-1].Action)
+	})
+
+	t.Run("project links are recorded", func(t *testing.T) {
+		project, err := projects.Create(ctx, &models.Project{Title: "Linked", Description: "Project", Status: models.ProjectStatusActive})
+		require.NoError(t, err)
+		pub, err := pubs.Create(ctx, &models.Publication{Title: "Linked", AuthorsText: "A. Author", Year: 2024})
+		require.NoError(t, err)
+
+		require.NoError(t, projects.SetPublications(ctx, project.ID, []int{pub.ID}))
+		require.NoError(t, projects.UnlinkPublication(ctx, project.ID, pub.ID))
+
+		entries, err := audit.GetByEntity(ctx, AuditEntityProject, project.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+		assert.Equal(t, models.AuditActionUpdate, entries[1].Action)
+		assert.Equal(t, models.AuditActionUpdate, entries[2].Action)
+	})
+}
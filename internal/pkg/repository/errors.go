@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"modernc.org/sqlite"
 )
@@ -15,6 +16,9 @@ const (
 	sqliteConstraintForeignKey = 787  // SQLITE_CONSTRAINT_FOREIGNKEY
 	sqliteConstraintNotNull    = 1299 // SQLITE_CONSTRAINT_NOTNULL
 	sqliteConstraintPrimaryKey = 1555 // SQLITE_CONSTRAINT_PRIMARYKEY
+
+	// Primary result code; extended codes keep it in the low byte
+	sqliteFull = 13 // SQLITE_FULL
 )
 
 // Common errors that can be returned by repositories.
@@ -25,9 +29,21 @@ var (
 	// ErrDuplicate is returned when attempting to create an entity that already exists.
 	ErrDuplicate = errors.New("entity already exists")
 
+	// ErrDisplayOrderTaken is returned when a homepage section is created at a display
+	// order another section already uses. It wraps ErrDuplicate.
+	ErrDisplayOrderTaken = fmt.Errorf("%w: display order is already taken", ErrDuplicate)
+
+	// ErrConflict is returned when a row changed between being read and being written,
+	// so the write was not applied. The caller may reload and retry.
+	ErrConflict = errors.New("entity changed concurrently")
+
 	// ErrInvalidInput is returned when the input data is invalid.
 	ErrInvalidInput = errors.New("invalid input")
 
+	// ErrStorageFull is returned when a write fails because the disk (or the database's
+	// page limit) is full. Handlers should answer 507 rather than a generic 500.
+	ErrStorageFull = errors.New("storage full")
+
 	// ErrDatabase is returned for general database errors.
 	ErrDatabase = errors.New("database error")
 )
@@ -65,6 +81,21 @@ func IsNotNullError(err error) bool {
 	return isConstraintViolation(err, sqliteConstraintNotNull)
 }
 
+// IsDiskFullError returns true if the error is SQLite reporting that the database or disk is full
+func IsDiskFullError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code()&0xff == sqliteFull
+	}
+
+	// Errors that lost their code on the way, e.g. from a failed commit
+	return strings.Contains(err.Error(), "database or disk is full")
+}
+
 // WrapError wraps an error with operation context
 // Returns specific error types for known error cases
 func WrapError(err error, operation string) error {
@@ -81,5 +112,10 @@ func WrapError(err error, operation string) error {
 		return ErrDuplicate
 	}
 
+	// Keep the cause so the log shows the underlying SQLite message
+	if IsDiskFullError(err) {
+		return fmt.Errorf("%s failed: %w: %w", operation, ErrStorageFull, err)
+	}
+
 	return fmt.Errorf("%s failed: %w", operation, err)
 }
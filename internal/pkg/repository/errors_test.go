@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -180,3 +181,36 @@ func TestErrorConstants(t *testing.T) {
 		assert.Equal(t, "database error", ErrDatabase.Error())
 	})
 }
+
+func TestIsDiskFullError(t *testing.T) {
+	t.Run("returns false for nil and unrelated errors", func(t *testing.T) {
+		assert.False(t, IsDiskFullError(nil))
+		assert.False(t, IsDiskFullError(errors.New("some random error")))
+	})
+
+	t.Run("matches the SQLite message", func(t *testing.T) {
+		assert.True(t, IsDiskFullError(errors.New("commit: database or disk is full (13)")))
+	})
+
+	t.Run("detects a full database and maps it to ErrStorageFull", func(t *testing.T) {
+		dbManager := setupTestDB(t)
+		db := dbManager.GetDB()
+
+		_, err := db.Exec(`CREATE TABLE test_full (data BLOB)`)
+		require.NoError(t, err)
+
+		// Cap the database at its current size so the next large write cannot fit
+		var pages int
+		require.NoError(t, db.QueryRow(`PRAGMA page_count`).Scan(&pages))
+		_, err = db.Exec(fmt.Sprintf(`PRAGMA max_page_count = %d`, pages))
+		require.NoError(t, err)
+
+		_, err = db.Exec(`INSERT INTO test_full (data) VALUES (zeroblob(1000000))`)
+		require.Error(t, err)
+		assert.True(t, IsDiskFullError(err), "Should detect a full database, got error: %v", err)
+
+		wrapped := WrapError(err, "insert data")
+		assert.ErrorIs(t, wrapped, ErrStorageFull)
+		assert.Contains(t, wrapped.Error(), "insert data failed")
+	})
+}
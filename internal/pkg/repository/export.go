@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// ExportJSON writes every content entity to w as a single JSON document, for migrating
+// the site to another system:
+//
+//	{
+//	  "exported_at": "...",
+//	  "lab_members": [...],
+//	  "publications": [{..., "authors": [...]}],
+//	  "projects": [{..., "members": [...], "publications": [...]}],
+//	  "news": [...],
+//	  "homepage_sections": [...],
+//	  "settings": [...]
+//	}
+//
+// All sections are read in one transaction so they are consistent with each other.
+// Publications and projects are written one at a time as their relations are loaded.
+// User accounts are not exported, so password hashes never leave the database.
+// ImportJSON reads the document back.
+func (f *Factory) ExportJSON(ctx context.Context, w io.Writer) error {
+	return f.DBManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		out := &exportWriter{w: w, enc: json.NewEncoder(w)}
+
+		out.raw(`{"exported_at":`)
+		out.value(time.Now().UTC())
+
+		members, err := f.LabMembers.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"lab_members":`)
+		out.array(len(members), func(i int) (interface{}, error) { return members[i], nil })
+
+		publications, err := f.Publications.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"publications":`)
+		out.array(len(publications), func(i int) (interface{}, error) {
+			authors, err := f.Publications.GetAuthors(txCtx, publications[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			return models.PublicationWithAuthors{Publication: publications[i], Authors: nonNil(authors)}, nil
+		})
+
+		projects, err := f.Projects.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"projects":`)
+		out.array(len(projects), func(i int) (interface{}, error) {
+			project, err := f.Projects.GetWithRelations(txCtx, projects[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			project.Members = nonNil(project.Members)
+			project.Publications = nonNil(project.Publications)
+			return project, nil
+		})
+
+		news, err := f.News.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"news":`)
+		out.array(len(news), func(i int) (interface{}, error) { return news[i], nil })
+
+		sections, err := f.HomepageSections.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"homepage_sections":`)
+		out.array(len(sections), func(i int) (interface{}, error) { return sections[i], nil })
+
+		settings, err := f.LabSettings.GetAll(txCtx)
+		if err != nil {
+			return err
+		}
+		out.raw(`,"settings":`)
+		out.array(len(settings), func(i int) (interface{}, error) { return settings[i], nil })
+
+		out.raw("}\n")
+		return out.err
+	})
+}
+
+// exportDocument is the shape written by ExportJSON, as read back by ImportJSON
+type exportDocument struct {
+	LabMembers       []models.LabMember              `json:"lab_members"`
+	Publications     []models.PublicationWithAuthors `json:"publications"`
+	Projects         []models.ProjectWithRelations   `json:"projects"`
+	News             []models.News                   `json:"news"`
+	HomepageSections []models.HomepageSection        `json:"homepage_sections"`
+	Settings         []models.LabSetting             `json:"settings"`
+}
+
+// ImportJSON restores a document written by ExportJSON. Entities are created with new IDs
+// and their author, member and publication links are remapped to them; author order is
+// kept. Settings overwrite existing values of the same key and locale. Creation timestamps are those
+// of the import, not of the original rows.
+//
+// Everything happens in one transaction: a malformed document, a link to an entity that is
+// not in the document, or any database error (e.g. a clashing homepage section key) rolls
+// the whole import back.
+func (f *Factory) ImportJSON(ctx context.Context, r io.Reader) error {
+	var doc exportDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("%w: malformed export document: %v", ErrInvalidInput, err)
+	}
+
+	return f.DBManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		memberIDs := make(map[int]int, len(doc.LabMembers))
+		for _, member := range doc.LabMembers {
+			oldID := member.ID
+			created, err := f.LabMembers.Create(txCtx, &member)
+			if err != nil {
+				return err
+			}
+			memberIDs[oldID] = created.ID
+		}
+
+		publicationIDs := make(map[int]int, len(doc.Publications))
+		for _, pub := range doc.Publications {
+			created, err := f.Publications.Create(txCtx, &models.Publication{
+				Title:       pub.Title,
+				AuthorsText: pub.AuthorsText,
+				Venue:       pub.Venue,
+				Year:        pub.Year,
+				URL:         pub.URL,
+			})
+			if err != nil {
+				return err
+			}
+			publicationIDs[pub.ID] = created.ID
+
+			// LinkAuthor appends, so linking in export order keeps the author order
+			for _, author := range pub.Authors {
+				memberID, err := remapID(memberIDs, author.ID, "member")
+				if err != nil {
+					return err
+				}
+				if err := f.Publications.LinkAuthor(txCtx, created.ID, memberID); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, proj := range doc.Projects {
+			project := proj.Project
+			created, err := f.Projects.Create(txCtx, &project)
+			if err != nil {
+				return err
+			}
+
+			for _, member := range proj.Members {
+				memberID, err := remapID(memberIDs, member.ID, "member")
+				if err != nil {
+					return err
+				}
+				if err := f.Projects.LinkMember(txCtx, created.ID, memberID); err != nil {
+					return err
+				}
+			}
+			for _, pub := range proj.Publications {
+				publicationID, err := remapID(publicationIDs, pub.ID, "publication")
+				if err != nil {
+					return err
+				}
+				if err := f.Projects.LinkPublication(txCtx, created.ID, publicationID); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, news := range doc.News {
+			if _, err := f.News.Create(txCtx, &news); err != nil {
+				return err
+			}
+		}
+
+		for _, section := range doc.HomepageSections {
+			if _, err := f.HomepageSections.Create(txCtx, &section); err != nil {
+				return err
+			}
+		}
+
+		for _, setting := range doc.Settings {
+			if err := f.LabSettings.SetLocalized(txCtx, setting.SettingKey, setting.Locale, setting.SettingValue); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// remapID translates an exported ID to the ID the entity received on import
+func remapID(ids map[int]int, oldID int, entity string) (int, error) {
+	newID, ok := ids[oldID]
+	if !ok {
+		return 0, fmt.Errorf("%w: export links to %s %d which is not in the document", ErrInvalidInput, entity, oldID)
+	}
+	return newID, nil
+}
+
+// exportWriter writes a JSON document piece by piece, remembering the first error so
+// callers can check once at the end.
+type exportWriter struct {
+	w   io.Writer
+	enc *json.Encoder
+	err error
+}
+
+// raw writes s verbatim
+func (e *exportWriter) raw(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s)
+	}
+}
+
+// value writes v as JSON
+func (e *exportWriter) value(v interface{}) {
+	if e.err == nil {
+		if err := e.enc.Encode(v); err != nil {
+			e.err = fmt.Errorf("encode export: %w", err)
+		}
+	}
+}
+
+// array writes a JSON array of n elements, each produced by item
+func (e *exportWriter) array(n int, item func(i int) (interface{}, error)) {
+	e.raw("[")
+	for i := 0; i < n && e.err == nil; i++ {
+		if i > 0 {
+			e.raw(",")
+		}
+		v, err := item(i)
+		if err != nil {
+			e.err = err
+			return
+		}
+		e.value(v)
+	}
+	e.raw("]")
+}
+
+// nonNil returns an empty slice for nil so that empty relations export as [] rather than null
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
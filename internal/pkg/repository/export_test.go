@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_ExportJSON(t *testing.T) {
+	dbManager := setupTestDB(t)
+	f := NewFactory(dbManager)
+
+	pi, err := f.LabMembers.Create(ctx, &models.LabMember{Name: "Principal", Role: models.LabMemberRolePI})
+	require.NoError(t, err)
+	_, err = f.LabMembers.Create(ctx, &models.LabMember{Name: "Student", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+
+	paper, err := f.Publications.Create(ctx, &models.Publication{Title: "Linked Paper", AuthorsText: "Principal", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, f.Publications.LinkAuthor(ctx, paper.ID, pi.ID))
+	_, err = f.Publications.Create(ctx, &models.Publication{Title: "External Paper", AuthorsText: "Someone Else", Year: 2023})
+	require.NoError(t, err)
+
+	project, err := f.Projects.Create(ctx, &models.Project{Title: "Project", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+	require.NoError(t, f.Projects.LinkMember(ctx, project.ID, pi.ID))
+	require.NoError(t, f.Projects.LinkPublication(ctx, project.ID, paper.ID))
+
+	for _, title := range []string{"First", "Second", "Third"} {
+		_, err := f.News.Create(ctx, &models.News{Title: title, Content: "content"})
+		require.NoError(t, err)
+	}
+
+	_, err = f.HomepageSections.Create(ctx, &models.HomepageSection{SectionKey: "intro", Title: "Welcome", Content: "Hello"})
+	require.NoError(t, err)
+
+	require.NoError(t, f.LabSettings.Set(ctx, "contact_email", "lab@example.com"))
+
+	var buf bytes.Buffer
+	require.NoError(t, f.ExportJSON(ctx, &buf))
+
+	var doc struct {
+		ExportedAt       string                          `json:"exported_at"`
+		LabMembers       []models.LabMember              `json:"lab_members"`
+		Publications     []models.PublicationWithAuthors `json:"publications"`
+		Projects         []models.ProjectWithRelations   `json:"projects"`
+		News             []models.News                   `json:"news"`
+		HomepageSections []models.HomepageSection        `json:"homepage_sections"`
+		Settings         []models.LabSetting             `json:"settings"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc), buf.String())
+
+	assert.NotEmpty(t, doc.ExportedAt)
+	assert.Len(t, doc.LabMembers, 2)
+	assert.Len(t, doc.News, 3)
+	assert.Len(t, doc.HomepageSections, 1)
+	// Two default settings from the migrations plus the one set above
+	assert.Len(t, doc.Settings, 3)
+
+	t.Run("publications carry their authors", func(t *testing.T) {
+		require.Len(t, doc.Publications, 2)
+		authors := map[string]int{}
+		for _, pub := range doc.Publications {
+			authors[pub.Title] = len(pub.Authors)
+		}
+		assert.Equal(t, map[string]int{"Linked Paper": 1, "External Paper": 0}, authors)
+	})
+
+	t.Run("projects carry their relations", func(t *testing.T) {
+		require.Len(t, doc.Projects, 1)
+		require.Len(t, doc.Projects[0].Members, 1)
+		assert.Equal(t, pi.ID, doc.Projects[0].Members[0].ID)
+		require.Len(t, doc.Projects[0].Publications, 1)
+		assert.Equal(t, paper.ID, doc.Projects[0].Publications[0].ID)
+	})
+
+	t.Run("users are not exported", func(t *testing.T) {
+		assert.NotContains(t, buf.String(), "password")
+	})
+}
+
+func TestFactory_ImportJSON_RoundTrip(t *testing.T) {
+	source := NewFactory(setupTestDB(t))
+
+	first, err := source.LabMembers.Create(ctx, &models.LabMember{Name: "First Author", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+	second, err := source.LabMembers.Create(ctx, &models.LabMember{Name: "Second Author", Role: models.LabMemberRolePI})
+	require.NoError(t, err)
+
+	paper, err := source.Publications.Create(ctx, &models.Publication{Title: "Joint Paper", AuthorsText: "First, Second", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, source.Publications.LinkAuthors(ctx, paper.ID, []int{second.ID, first.ID}))
+
+	project, err := source.Projects.Create(ctx, &models.Project{Title: "Project", Slug: "project", Description: "d", Status: models.ProjectStatusCompleted})
+	require.NoError(t, err)
+	require.NoError(t, source.Projects.LinkMember(ctx, project.ID, first.ID))
+	require.NoError(t, source.Projects.LinkPublication(ctx, project.ID, paper.ID))
+
+	_, err = source.News.Create(ctx, &models.News{Title: "News", Content: "content"})
+	require.NoError(t, err)
+	require.NoError(t, source.LabSettings.Set(ctx, models.LabSettingName, "Imported Lab"))
+
+	var buf bytes.Buffer
+	require.NoError(t, source.ExportJSON(ctx, &buf))
+
+	// The target already has a member so that imported IDs differ from exported ones
+	target := NewFactory(setupTestDB(t))
+	_, err = target.LabMembers.Create(ctx, &models.LabMember{Name: "Existing", Role: models.LabMemberRoleMaster})
+	require.NoError(t, err)
+
+	require.NoError(t, target.ImportJSON(ctx, &buf))
+
+	members, err := target.LabMembers.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, members, 3)
+
+	pubs, err := target.Publications.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+
+	news, err := target.News.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, news, 1)
+
+	name, err := target.LabSettings.LabName(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Imported Lab", name)
+
+	t.Run("author order survives", func(t *testing.T) {
+		authors, err := target.Publications.GetAuthors(ctx, pubs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, authors, 2)
+		assert.Equal(t, "Second Author", authors[0].Name)
+		assert.Equal(t, "First Author", authors[1].Name)
+	})
+
+	t.Run("project links are remapped", func(t *testing.T) {
+		imported, err := target.Projects.GetBySlugWithRelations(ctx, "project")
+		require.NoError(t, err)
+		assert.Equal(t, models.ProjectStatusCompleted, imported.Status)
+		require.Len(t, imported.Members, 1)
+		assert.Equal(t, "First Author", imported.Members[0].Name)
+		require.Len(t, imported.Publications, 1)
+		assert.Equal(t, pubs[0].ID, imported.Publications[0].ID)
+	})
+}
+
+func TestFactory_ImportJSON_RollsBack(t *testing.T) {
+	f := NewFactory(setupTestDB(t))
+
+	doc := `{
+		"lab_members": [{"id": 1, "name": "Member", "role": "PhD"}],
+		"publications": [{"id": 1, "title": "Paper", "authors_text": "Member", "year": 2024, "authors": [{"id": 2}]}]
+	}`
+
+	err := f.ImportJSON(ctx, strings.NewReader(doc))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	members, err := f.LabMembers.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, members, "members created before the error are rolled back")
+
+	t.Run("malformed document", func(t *testing.T) {
+		assert.ErrorIs(t, f.ImportJSON(ctx, strings.NewReader("{")), ErrInvalidInput)
+	})
+}
@@ -3,7 +3,12 @@
 package repository
 
 import (
+	"context"
+	"regexp"
+	"strings"
+
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 )
 
 // Factory manages all repository instances and provides centralized access.
@@ -15,6 +20,8 @@ type Factory struct {
 	Projects         *ProjectRepository
 	News             *NewsRepository
 	HomepageSections *HomepageRepository
+	LabSettings      *LabSettingRepository
+	Audit            *AuditRepository
 }
 
 // NewFactory creates and initializes all repositories with a shared database connection.
@@ -27,7 +34,80 @@ func NewFactory(dbManager *db.DBManager) *Factory {
 		Projects:         NewProjectRepository(dbManager),
 		News:             NewNewsRepository(dbManager),
 		HomepageSections: NewHomepageRepository(dbManager),
+		LabSettings:      NewLabSettingRepository(dbManager),
+		Audit:            NewAuditRepository(dbManager),
+	}
+}
+
+// CreatePublicationWithAuthors creates a publication and links it to the lab members in
+// authorIDs, in authorship order (first author first). Both steps run in one transaction,
+// so when linking fails (e.g. an unknown member) the publication is not created either.
+// A member listed twice returns ErrInvalidInput.
+func (f *Factory) CreatePublicationWithAuthors(ctx context.Context, pub *models.Publication, authorIDs []int) (*models.Publication, error) {
+	var created *models.Publication
+	err := f.DBManager.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		created, err = f.Publications.Create(txCtx, pub)
+		if err != nil {
+			return err
+		}
+		return f.Publications.LinkAuthors(txCtx, created.ID, authorIDs)
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return created, nil
+}
+
+// uploadURLPattern matches links to uploaded files embedded in content text
+var uploadURLPattern = regexp.MustCompile(`/uploads/[^\s"'()<>\[\]?#]+`)
+
+// uploadReferencesQuery returns member photo URLs in the first column and, in the second,
+// every content text that links to an uploaded file
+const uploadReferencesQuery = `
+	SELECT photo_url, '' FROM lab_members WHERE photo_url IS NOT NULL AND photo_url != ''
+	UNION ALL SELECT '', bio FROM lab_members WHERE bio LIKE '%/uploads/%'
+	UNION ALL SELECT '', personal_page_content FROM lab_members WHERE personal_page_content LIKE '%/uploads/%'
+	UNION ALL SELECT '', research_interests FROM lab_members WHERE research_interests LIKE '%/uploads/%'
+	UNION ALL SELECT '', url FROM publications WHERE url LIKE '%/uploads/%'
+	UNION ALL SELECT '', description FROM projects WHERE description LIKE '%/uploads/%'
+	UNION ALL SELECT '', content FROM news WHERE content LIKE '%/uploads/%'
+	UNION ALL SELECT '', content FROM homepage_sections WHERE content LIKE '%/uploads/%'
+	UNION ALL SELECT '', setting_value FROM lab_settings WHERE setting_value LIKE '%/uploads/%'
+`
+
+// UploadReferences returns every reference to an uploaded file stored in the database:
+// member photo URLs as stored, and the /uploads/ links found in the text of members,
+// publications, projects, news, homepage sections and lab settings. Used to find uploaded
+// files that are no longer referenced anywhere.
+func (f *Factory) UploadReferences(ctx context.Context) ([]string, error) {
+	rows, err := f.DBManager.GetExecer(ctx).QueryContext(ctx, uploadReferencesQuery)
+	if err != nil {
+		return nil, WrapError(err, "get upload references")
+	}
+	defer rows.Close()
+
+	var refs []string
+	for rows.Next() {
+		var photoURL, text string
+		if err := rows.Scan(&photoURL, &text); err != nil {
+			return nil, WrapError(err, "scan upload reference")
+		}
+		if photoURL != "" {
+			refs = append(refs, photoURL)
+		}
+		for _, link := range uploadURLPattern.FindAllString(text, -1) {
+			// Drop sentence punctuation that follows a link in prose
+			refs = append(refs, strings.TrimRight(link, ".,;:!"))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate upload references")
+	}
+
+	return refs, nil
 }
 
 // Close closes the database connection.
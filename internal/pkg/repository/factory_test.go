@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_CreatePublicationWithAuthors(t *testing.T) {
+	f := NewFactory(setupTestDB(t))
+
+	first, err := f.LabMembers.Create(ctx, &models.LabMember{Name: "First", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+	second, err := f.LabMembers.Create(ctx, &models.LabMember{Name: "Second", Role: models.LabMemberRolePI})
+	require.NoError(t, err)
+
+	t.Run("creates and links in author order", func(t *testing.T) {
+		pub, err := f.CreatePublicationWithAuthors(ctx,
+			&models.Publication{Title: "Joint Paper", AuthorsText: "First, Second", Year: 2024},
+			[]int{second.ID, first.ID})
+		require.NoError(t, err)
+		assert.Greater(t, pub.ID, 0)
+
+		authors, err := f.Publications.GetAuthors(ctx, pub.ID)
+		require.NoError(t, err)
+		require.Len(t, authors, 2)
+		assert.Equal(t, second.ID, authors[0].ID)
+		assert.Equal(t, first.ID, authors[1].ID)
+	})
+
+	t.Run("failed link rolls back the publication", func(t *testing.T) {
+		before, err := f.Publications.GetAll(ctx)
+		require.NoError(t, err)
+
+		_, err = f.CreatePublicationWithAuthors(ctx,
+			&models.Publication{Title: "Orphan Paper", AuthorsText: "Nobody", Year: 2024},
+			[]int{first.ID, 9999})
+		require.Error(t, err)
+
+		after, err := f.Publications.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, after, len(before), "the publication is not kept")
+	})
+
+	t.Run("duplicate author", func(t *testing.T) {
+		_, err := f.CreatePublicationWithAuthors(ctx,
+			&models.Publication{Title: "Paper", AuthorsText: "First", Year: 2024},
+			[]int{first.ID, first.ID})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("joins an outer transaction", func(t *testing.T) {
+		before, err := f.Publications.GetAll(ctx)
+		require.NoError(t, err)
+
+		err = f.DBManager.WithTransaction(ctx, func(txCtx context.Context) error {
+			pub, err := f.CreatePublicationWithAuthors(txCtx,
+				&models.Publication{Title: "Nested Paper", AuthorsText: "First", Year: 2024},
+				[]int{first.ID})
+			require.NoError(t, err)
+
+			authors, err := f.Publications.GetAuthors(txCtx, pub.ID)
+			require.NoError(t, err)
+			assert.Len(t, authors, 1, "the links are visible inside the outer transaction")
+			return assert.AnError
+		})
+		require.ErrorIs(t, err, assert.AnError)
+
+		after, err := f.Publications.GetAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, after, len(before), "rolling back the outer transaction drops the publication")
+	})
+}
+
+func TestFactory_UploadReferences(t *testing.T) {
+	f := NewFactory(setupTestDB(t))
+
+	_, err := f.LabMembers.Create(ctx, &models.LabMember{
+		Name:     "Photographed",
+		Role:     models.LabMemberRolePhD,
+		PhotoURL: sql.NullString{String: "/uploads/member-1a2b.png", Valid: true},
+		Bio:      sql.NullString{String: "See /uploads/poster-3c4d.jpg.", Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = f.News.Create(ctx, &models.News{Title: "News", Content: `<img src="/uploads/event-5e6f.gif"> and /uploads/report.pdf`})
+	require.NoError(t, err)
+	_, err = f.Projects.Create(ctx, &models.Project{Title: "Project", Description: "No uploads here", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+
+	refs, err := f.UploadReferences(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"/uploads/member-1a2b.png",
+		"/uploads/poster-3c4d.jpg",
+		"/uploads/event-5e6f.gif",
+		"/uploads/report.pdf",
+	}, refs)
+}
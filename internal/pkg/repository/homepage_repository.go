@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -50,7 +52,7 @@ func (r *HomepageRepository) GetByID(ctx context.Context, id int) (*models.Homep
 	return &section, nil
 }
 
-// GetByKey retrieves a homepage section by its unique section key.
+// GetByKey retrieves a homepage section by its unique section key, ignoring case.
 func (r *HomepageRepository) GetByKey(ctx context.Context, key string) (*models.HomepageSection, error) {
 	query := `
 		SELECT id, section_key, title, content, display_order, updated_at
@@ -58,7 +60,7 @@ func (r *HomepageRepository) GetByKey(ctx context.Context, key string) (*models.
 		WHERE section_key = $1
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(ctx, query, key)
+	row := r.GetExecer(ctx).QueryRowContext(ctx, query, normalizeSectionKey(key))
 
 	var section models.HomepageSection
 	err := row.Scan(
@@ -91,6 +93,35 @@ func (r *HomepageRepository) GetAll(ctx context.Context) ([]models.HomepageSecti
 	}
 	defer rows.Close()
 
+	return scanHomepageSections(rows)
+}
+
+// GetVisible retrieves up to limit homepage sections ordered by display order, for
+// rendering the public homepage. Limit must be positive and is capped at MaxPageSize.
+func (r *HomepageRepository) GetVisible(ctx context.Context, limit int) ([]models.HomepageSection, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, section_key, title, content, display_order, updated_at
+		FROM homepage_sections
+		ORDER BY display_order ASC, id ASC
+		LIMIT $1
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, WrapError(err, "get visible homepage sections")
+	}
+	defer rows.Close()
+
+	return scanHomepageSections(rows)
+}
+
+// scanHomepageSections reads all rows of a homepage section query
+func scanHomepageSections(rows *sql.Rows) ([]models.HomepageSection, error) {
 	var sections []models.HomepageSection
 	for rows.Next() {
 		var s models.HomepageSection
@@ -115,36 +146,102 @@ func (r *HomepageRepository) GetAll(ctx context.Context) ([]models.HomepageSecti
 	return sections, nil
 }
 
+// LastUpdated returns the most recent updated_at across all homepage sections, for use as
+// a cache key or Last-Modified header. It returns the zero time when there are no sections.
+func (r *HomepageRepository) LastUpdated(ctx context.Context) (time.Time, error) {
+	// ORDER BY/LIMIT rather than MAX() so the driver still sees a DATETIME column
+	query := `
+		SELECT updated_at
+		FROM homepage_sections
+		ORDER BY updated_at DESC
+		LIMIT 1
+	`
+
+	var updatedAt time.Time
+	err := r.GetExecer(ctx).QueryRowContext(ctx, query).Scan(&updatedAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, WrapError(err, "get homepage last updated")
+	}
+
+	return updatedAt, nil
+}
+
 // Create inserts a new homepage section.
 // Note: In practice, sections are typically seeded at initialization,
 // but this method allows dynamic creation if needed.
+// The section key is stored lowercase; a key that differs from an existing one only in
+// case returns ErrDuplicate. A zero display order appends the section after the last one,
+// while an explicit order that is already used returns ErrDisplayOrderTaken.
 func (r *HomepageRepository) Create(ctx context.Context, section *models.HomepageSection) (*models.HomepageSection, error) {
+	section.SectionKey = normalizeSectionKey(section.SectionKey)
+
 	query := `
 		INSERT INTO homepage_sections (section_key, title, content, display_order, updated_at)
 		VALUES ($1, $2, $3, $4, datetime('now'))
 		RETURNING id, updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		section.SectionKey,
-		section.Title,
-		section.Content,
-		section.DisplayOrder,
-	)
+	// The display order is resolved in the same transaction as the insert, so two
+	// sections created at once cannot both take the same slot.
+	err := r.withAudit(ctx, models.AuditActionCreate, AuditEntityHomepageSection, func(ctx context.Context) (int, error) {
+		order, err := r.resolveDisplayOrder(ctx, section.DisplayOrder)
+		if err != nil {
+			return 0, err
+		}
+		section.DisplayOrder = order
+
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			section.SectionKey,
+			section.Title,
+			section.Content,
+			section.DisplayOrder,
+		)
 
-	err := row.Scan(&section.ID, &section.UpdatedAt)
-	if err != nil {
-		if isDuplicateKeyError(err) {
-			return nil, ErrDuplicate
+		if err := row.Scan(&section.ID, &section.UpdatedAt); err != nil {
+			if isDuplicateKeyError(err) {
+				return 0, ErrDuplicate
+			}
+			return 0, WrapError(err, "create homepage section")
 		}
-		return nil, WrapError(err, "create homepage section")
+		return section.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return section, nil
 }
 
+// resolveDisplayOrder returns the display order a new section should use: the next free
+// slot after the last section when order is zero, or order itself when no section uses it.
+func (r *HomepageRepository) resolveDisplayOrder(ctx context.Context, order int) (int, error) {
+	if order == 0 {
+		query := `SELECT COALESCE(MAX(display_order), 0) + 1 FROM homepage_sections`
+
+		var next int
+		if err := r.GetExecer(ctx).QueryRowContext(ctx, query).Scan(&next); err != nil {
+			return 0, WrapError(err, "get next homepage display order")
+		}
+		return next, nil
+	}
+
+	query := `SELECT EXISTS(SELECT 1 FROM homepage_sections WHERE display_order = $1)`
+
+	var taken bool
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, query, order).Scan(&taken); err != nil {
+		return 0, WrapError(err, "check homepage display order")
+	}
+	if taken {
+		return 0, ErrDisplayOrderTaken
+	}
+	return order, nil
+}
+
 // Update modifies an existing homepage section.
 func (r *HomepageRepository) Update(ctx context.Context, section *models.HomepageSection) (*models.HomepageSection, error) {
 	query := `
@@ -154,21 +251,26 @@ func (r *HomepageRepository) Update(ctx context.Context, section *models.Homepag
 		RETURNING updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		section.Title,
-		section.Content,
-		section.DisplayOrder,
-		section.ID,
-	)
+	err := r.withAudit(ctx, models.AuditActionUpdate, AuditEntityHomepageSection, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			section.Title,
+			section.Content,
+			section.DisplayOrder,
+			section.ID,
+		)
 
-	err := row.Scan(&section.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+		if err := row.Scan(&section.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update homepage section")
 		}
-		return nil, WrapError(err, "update homepage section")
+		return section.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return section, nil
@@ -179,12 +281,13 @@ func (r *HomepageRepository) Update(ctx context.Context, section *models.Homepag
 func (r *HomepageRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM homepage_sections WHERE id = $1`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
-	if err != nil {
-		return WrapError(err, "delete homepage section")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityHomepageSection, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "delete homepage section")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
 // UpdateContent updates just the content and title of a section.
@@ -196,28 +299,41 @@ func (r *HomepageRepository) UpdateContent(ctx context.Context, id int, title, c
 		WHERE id = $3
 	`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, title, content, id)
-	if err != nil {
-		return WrapError(err, "update section content")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityHomepageSection, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, title, content, id)
+		if err != nil {
+			return 0, WrapError(err, "update section content")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
-// UpdateContentByKey updates content by section key (useful for known sections like 'overview').
+// UpdateContentByKey updates content by section key, ignoring case (useful for known sections like 'overview').
 func (r *HomepageRepository) UpdateContentByKey(ctx context.Context, key, title, content string) error {
 	query := `
 		UPDATE homepage_sections
 		SET title = $1, content = $2, updated_at = datetime('now')
 		WHERE section_key = $3
+		RETURNING id
 	`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, title, content, key)
-	if err != nil {
-		return WrapError(err, "update section content by key")
-	}
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityHomepageSection, func(ctx context.Context) (int, error) {
+		var id int
+		err := r.GetExecer(ctx).QueryRowContext(ctx, query, title, content, normalizeSectionKey(key)).Scan(&id)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update section content by key")
+		}
+		return id, nil
+	})
+}
 
-	return CheckRowsAffected(result, 1)
+// normalizeSectionKey trims and lowercases a section key; keys are stored in this form
+// so that lookups are case-insensitive and differently-cased duplicates collide.
+func normalizeSectionKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
 }
 
 // isDuplicateKeyError checks if the error is a duplicate key violation.
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -172,10 +173,149 @@ func TestHomepageRepository_CRUD(t *testing.T) {
 			SectionKey:   "duplicate_key",
 			Title:        "Second",
 			Content:      "Content",
-			DisplayOrder: 10,
+			DisplayOrder: 13,
 		}
 
 		_, err = repo.Create(ctx, section2)
 		assert.Equal(t, ErrDuplicate, err)
 	})
 }
+
+func TestHomepageRepository_LastUpdated(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewHomepageRepository(dbManager)
+	db := dbManager.GetDB()
+
+	// Start from a known state, independent of seeded sections
+	_, err := db.Exec(`DELETE FROM homepage_sections`)
+	require.NoError(t, err)
+
+	t.Run("no sections", func(t *testing.T) {
+		last, err := repo.LastUpdated(ctx)
+		require.NoError(t, err)
+		assert.True(t, last.IsZero())
+	})
+
+	for i, key := range []string{"a", "b", "c"} {
+		section, err := repo.Create(ctx, &models.HomepageSection{SectionKey: key, Title: key, Content: key, DisplayOrder: i + 1})
+		require.NoError(t, err)
+		_, err = db.Exec(`UPDATE homepage_sections SET updated_at = $1 WHERE id = $2`,
+			time.Date(2025, 1, 1+i, 0, 0, 0, 0, time.UTC), section.ID)
+		require.NoError(t, err)
+	}
+
+	t.Run("newest section", func(t *testing.T) {
+		last, err := repo.LastUpdated(ctx)
+		require.NoError(t, err)
+		assert.True(t, last.Equal(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)), "got %v", last)
+	})
+
+	t.Run("reflects a later update", func(t *testing.T) {
+		require.NoError(t, repo.UpdateContentByKey(ctx, "a", "A", "updated"))
+
+		section, err := repo.GetByKey(ctx, "a")
+		require.NoError(t, err)
+
+		last, err := repo.LastUpdated(ctx)
+		require.NoError(t, err)
+		assert.True(t, last.Equal(section.UpdatedAt), "got %v, want %v", last, section.UpdatedAt)
+		assert.True(t, last.After(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)))
+	})
+}
+
+func TestHomepageRepository_SectionKeyCase(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewHomepageRepository(dbManager)
+
+	created, err := repo.Create(ctx, &models.HomepageSection{SectionKey: " Overview ", Title: "Overview", Content: "About the lab"})
+	require.NoError(t, err)
+	assert.Equal(t, "overview", created.SectionKey, "keys are stored lowercase")
+
+	t.Run("lookup ignores case", func(t *testing.T) {
+		for _, key := range []string{"overview", "OVERVIEW", "Overview"} {
+			section, err := repo.GetByKey(ctx, key)
+			require.NoError(t, err, key)
+			assert.Equal(t, created.ID, section.ID, key)
+		}
+	})
+
+	t.Run("update by key ignores case", func(t *testing.T) {
+		require.NoError(t, repo.UpdateContentByKey(ctx, "OverView", "Overview", "Updated"))
+
+		section, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated", section.Content)
+	})
+
+	t.Run("differently-cased duplicate collides", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.HomepageSection{SectionKey: "OVERVIEW", Title: "Again", Content: "c"})
+		assert.ErrorIs(t, err, ErrDuplicate)
+	})
+}
+
+func TestHomepageRepository_GetVisible(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewHomepageRepository(dbManager)
+
+	for _, section := range []models.HomepageSection{
+		{SectionKey: "contact", Title: "Contact", Content: "c", DisplayOrder: 3},
+		{SectionKey: "overview", Title: "Overview", Content: "c", DisplayOrder: 1},
+		{SectionKey: "research", Title: "Research", Content: "c", DisplayOrder: 2},
+	} {
+		_, err := repo.Create(ctx, &section)
+		require.NoError(t, err)
+	}
+
+	t.Run("caps results in display order", func(t *testing.T) {
+		sections, err := repo.GetVisible(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, sections, 2)
+		assert.Equal(t, "overview", sections[0].SectionKey)
+		assert.Equal(t, "research", sections[1].SectionKey)
+	})
+
+	t.Run("limit above count returns all", func(t *testing.T) {
+		sections, err := repo.GetVisible(ctx, 10)
+		require.NoError(t, err)
+		assert.Len(t, sections, 3)
+	})
+
+	t.Run("non-positive limit is rejected", func(t *testing.T) {
+		for _, limit := range []int{0, -1} {
+			_, err := repo.GetVisible(ctx, limit)
+			assert.ErrorIs(t, err, ErrInvalidInput, "limit %d", limit)
+		}
+	})
+}
+
+func TestHomepageRepository_Create_DisplayOrder(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewHomepageRepository(dbManager)
+
+	first, err := repo.Create(ctx, &models.HomepageSection{SectionKey: "overview", Title: "Overview", Content: "c", DisplayOrder: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 4, first.DisplayOrder)
+
+	t.Run("taken order is rejected", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.HomepageSection{SectionKey: "research", Title: "Research", Content: "c", DisplayOrder: 4})
+		assert.ErrorIs(t, err, ErrDisplayOrderTaken)
+		assert.ErrorIs(t, err, ErrDuplicate)
+
+		_, err = repo.GetByKey(ctx, "research")
+		assert.ErrorIs(t, err, ErrNotFound, "nothing is inserted on collision")
+	})
+
+	t.Run("zero order appends after the last section", func(t *testing.T) {
+		second, err := repo.Create(ctx, &models.HomepageSection{SectionKey: "contact", Title: "Contact", Content: "c"})
+		require.NoError(t, err)
+		assert.Equal(t, 5, second.DisplayOrder)
+
+		stored, err := repo.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, stored.DisplayOrder)
+
+		third, err := repo.Create(ctx, &models.HomepageSection{SectionKey: "news", Title: "News", Content: "c"})
+		require.NoError(t, err)
+		assert.Equal(t, 6, third.DisplayOrder)
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/nekoteoj/lab-cms/test/helpers"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIndexUsage guards against query changes that stop using the indexes from the
+// migrations. It explains the queries the repositories actually run.
+func TestIndexUsage(t *testing.T) {
+	db := setupTestDB(t).GetDB()
+
+	filterQuery := func(filter LabMemberFilter) (string, []any) {
+		query, args, err := labMemberFilterQuery(filter)
+		require.NoError(t, err)
+		return query, args
+	}
+	byRoleQuery, byRoleArgs := filterQuery(LabMemberFilter{Role: models.LabMemberRolePhD})
+
+	tests := []struct {
+		name  string
+		query string
+		index string
+		args  []any
+	}{
+		{
+			name:  "publications by year",
+			query: publicationsByYearQuery,
+			index: "idx_publications_year_created",
+			args:  []any{2024},
+		},
+		{
+			name:  "members filtered by role",
+			query: byRoleQuery,
+			index: "idx_lab_members_role",
+			args:  byRoleArgs,
+		},
+		{
+			// Without ANALYZE statistics SQLite prefers the alumni/order index here,
+			// which also serves the ORDER BY
+			name:  "current members by role",
+			query: labMembersByRoleQuery,
+			index: "idx_lab_members_alumni_order",
+			args:  []any{models.LabMemberRolePhD},
+		},
+		{
+			name:  "projects by status",
+			query: projectsByStatusQuery,
+			index: "idx_projects_status",
+			args:  []any{models.ProjectStatusActive},
+		},
+		{
+			name:  "project by slug",
+			query: projectBySlugQuery,
+			index: "idx_projects_slug",
+			args:  []any{"graph-learning"},
+		},
+		{
+			name:  "user role by email",
+			query: userRoleByEmailQuery,
+			index: "idx_users_email",
+			args:  []any{"root@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.True(t, helpers.ExplainUsesIndex(t, db, tt.query, tt.index, tt.args...),
+				"expected query to use %s", tt.index)
+		})
+	}
+
+	t.Run("full scan is detected", func(t *testing.T) {
+		query := `SELECT id FROM publications WHERE title = $1`
+		require.False(t, helpers.ExplainUsesIndex(t, db, query, "idx_publications_year_created", "Paper"))
+	})
+}
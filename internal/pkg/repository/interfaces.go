@@ -39,6 +39,8 @@ type UserAuthRepository interface {
 
 	// Authentication-specific methods (handles password securely)
 	GetByEmail(ctx context.Context, email string) (*models.UserWithPassword, error)
+	FindByEmail(ctx context.Context, email string) (*models.UserWithPassword, error)
 	Create(ctx context.Context, user *models.UserWithPassword) (*models.UserWithPassword, error)
 	UpdatePassword(ctx context.Context, id int, passwordHash string) error
+	RecordLogin(ctx context.Context, id int) error
 }
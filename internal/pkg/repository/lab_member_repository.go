@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -34,22 +36,7 @@ func (r *LabMemberRepository) GetByID(ctx context.Context, id int) (*models.LabM
 
 	row := r.GetExecer(ctx).QueryRowContext(ctx, query, id)
 
-	var member models.LabMember
-	err := row.Scan(
-		&member.ID,
-		&member.Name,
-		&member.Role,
-		&member.Email,
-		&member.Bio,
-		&member.PhotoURL,
-		&member.PersonalPageContent,
-		&member.ResearchInterests,
-		&member.IsAlumni,
-		&member.DisplayOrder,
-		&member.CreatedAt,
-		&member.UpdatedAt,
-	)
-
+	member, err := scanLabMember(row)
 	if err != nil {
 		return nil, WrapError(err, "get lab member by id")
 	}
@@ -72,80 +59,177 @@ func (r *LabMemberRepository) GetAll(ctx context.Context) ([]models.LabMember, e
 	}
 	defer rows.Close()
 
-	var members []models.LabMember
-	for rows.Next() {
-		var member models.LabMember
-		err := rows.Scan(
-			&member.ID,
-			&member.Name,
-			&member.Role,
-			&member.Email,
-			&member.Bio,
-			&member.PhotoURL,
-			&member.PersonalPageContent,
-			&member.ResearchInterests,
-			&member.IsAlumni,
-			&member.DisplayOrder,
-			&member.CreatedAt,
-			&member.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan lab member")
-		}
-		members = append(members, member)
+	return scanLabMembers(rows, "lab members")
+}
+
+// GetAllPaginated retrieves one page of lab members in the same order as GetAll,
+// along with the total number of members matching the alumni filter. The limit is
+// capped at MaxPageSize.
+func (r *LabMemberRepository) GetAllPaginated(ctx context.Context, limit, offset int, includeAlumni bool) ([]models.LabMember, int, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if offset < 0 {
+		return nil, 0, fmt.Errorf("%w: offset cannot be negative, got %d", ErrInvalidInput, offset)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate lab members")
+	countQuery := `
+		SELECT COUNT(*)
+		FROM lab_members
+		WHERE $1 OR is_alumni = false
+	`
+
+	var total int
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, countQuery, includeAlumni).Scan(&total); err != nil {
+		return nil, 0, WrapError(err, "count lab members")
+	}
+
+	query := `
+		SELECT id, name, role, email, bio, photo_url, personal_page_content,
+		       research_interests, is_alumni, display_order, created_at, updated_at
+		FROM lab_members
+		WHERE $1 OR is_alumni = false
+		ORDER BY is_alumni ASC, display_order ASC, created_at DESC, id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, includeAlumni, limit, offset)
+	if err != nil {
+		return nil, 0, WrapError(err, "get paginated lab members")
 	}
+	defer rows.Close()
 
-	return members, nil
+	members, err := scanLabMembers(rows, "paginated lab members")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return members, total, nil
+}
+
+// LabMemberFilter holds the optional filters for GetFiltered. Zero values match any member.
+type LabMemberFilter struct {
+	Role     models.LabMemberRole
+	IsAlumni *bool
 }
 
+// GetFiltered retrieves lab members matching the filter, in the same order as GetAll.
+func (r *LabMemberRepository) GetFiltered(ctx context.Context, filter LabMemberFilter) ([]models.LabMember, error) {
+	query, args, err := labMemberFilterQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, WrapError(err, "get filtered lab members")
+	}
+	defer rows.Close()
+
+	return scanLabMembers(rows, "filtered lab members")
+}
+
+// labMemberFilterQuery builds the GetFiltered query and its arguments for filter.
+func labMemberFilterQuery(filter LabMemberFilter) (string, []interface{}, error) {
+	qb := newQueryBuilder(`
+		SELECT id, name, role, email, bio, photo_url, personal_page_content,
+		       research_interests, is_alumni, display_order, created_at, updated_at
+		FROM lab_members
+	`, "role", "is_alumni")
+	if filter.Role != "" {
+		qb.where("role", filter.Role)
+	}
+	if filter.IsAlumni != nil {
+		qb.where("is_alumni", *filter.IsAlumni)
+	}
+
+	return qb.build("ORDER BY is_alumni ASC, display_order ASC, created_at DESC")
+}
+
+// labMembersByRoleQuery selects the current members with one role
+const labMembersByRoleQuery = `
+	SELECT id, name, role, email, bio, photo_url, personal_page_content,
+	       research_interests, is_alumni, display_order, created_at, updated_at
+	FROM lab_members
+	WHERE role = $1 AND is_alumni = false
+	ORDER BY display_order ASC, created_at DESC
+`
+
 // GetByRole retrieves lab members filtered by role.
 func (r *LabMemberRepository) GetByRole(ctx context.Context, role models.LabMemberRole) ([]models.LabMember, error) {
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, labMembersByRoleQuery, role)
+	if err != nil {
+		return nil, WrapError(err, "get lab members by role")
+	}
+	defer rows.Close()
+
+	return scanLabMembers(rows, "lab members by role")
+}
+
+// GetGroupedByRole retrieves active members grouped by role for the team page.
+// Members are ordered by display_order within each role. Maps are unordered, so callers
+// should iterate models.LabMemberRoleOrder to render roles in canonical order.
+func (r *LabMemberRepository) GetGroupedByRole(ctx context.Context) (map[models.LabMemberRole][]models.LabMember, error) {
 	query := `
 		SELECT id, name, role, email, bio, photo_url, personal_page_content,
 		       research_interests, is_alumni, display_order, created_at, updated_at
 		FROM lab_members
-		WHERE role = $1 AND is_alumni = false
+		WHERE is_alumni = false
 		ORDER BY display_order ASC, created_at DESC
 	`
 
-	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, role)
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
 	if err != nil {
-		return nil, WrapError(err, "get lab members by role")
+		return nil, WrapError(err, "get lab members grouped by role")
+	}
+	defer rows.Close()
+
+	members, err := scanLabMembers(rows, "lab members grouped by role")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[models.LabMemberRole][]models.LabMember)
+	for _, member := range members {
+		groups[member.Role] = append(groups[member.Role], member)
+	}
+
+	return groups, nil
+}
+
+// CountByRole returns the number of members in each role for the about page chart.
+// Roles without members are absent from the map. Alumni are only counted when
+// includeAlumni is set.
+func (r *LabMemberRepository) CountByRole(ctx context.Context, includeAlumni bool) (map[models.LabMemberRole]int, error) {
+	query := `
+		SELECT role, COUNT(*)
+		FROM lab_members
+		WHERE $1 OR is_alumni = false
+		GROUP BY role
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, includeAlumni)
+	if err != nil {
+		return nil, WrapError(err, "count lab members by role")
 	}
 	defer rows.Close()
 
-	var members []models.LabMember
+	counts := make(map[models.LabMemberRole]int)
 	for rows.Next() {
-		var member models.LabMember
-		err := rows.Scan(
-			&member.ID,
-			&member.Name,
-			&member.Role,
-			&member.Email,
-			&member.Bio,
-			&member.PhotoURL,
-			&member.PersonalPageContent,
-			&member.ResearchInterests,
-			&member.IsAlumni,
-			&member.DisplayOrder,
-			&member.CreatedAt,
-			&member.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan lab member")
+		var role models.LabMemberRole
+		var count int
+		if err := rows.Scan(&role, &count); err != nil {
+			return nil, WrapError(err, "scan lab member role count")
 		}
-		members = append(members, member)
+		counts[role] = count
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate lab members by role")
+		return nil, WrapError(err, "iterate lab member role counts")
 	}
 
-	return members, nil
+	return counts, nil
 }
 
 // GetAlumni retrieves all alumni members.
@@ -164,34 +248,125 @@ func (r *LabMemberRepository) GetAlumni(ctx context.Context) ([]models.LabMember
 	}
 	defer rows.Close()
 
-	var members []models.LabMember
+	return scanLabMembers(rows, "alumni")
+}
+
+// GetWithoutPublications retrieves members who are not linked as an author of any
+// publication, in the same order as GetAll. Alumni are left out unless includeAlumni is set.
+func (r *LabMemberRepository) GetWithoutPublications(ctx context.Context, includeAlumni bool) ([]models.LabMember, error) {
+	query := `
+		SELECT m.id, m.name, m.role, m.email, m.bio, m.photo_url, m.personal_page_content,
+		       m.research_interests, m.is_alumni, m.display_order, m.created_at, m.updated_at
+		FROM lab_members m
+		LEFT JOIN publication_authors pa ON m.id = pa.member_id
+		WHERE pa.member_id IS NULL AND ($1 OR m.is_alumni = false)
+		ORDER BY m.is_alumni ASC, m.display_order ASC, m.created_at DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, includeAlumni)
+	if err != nil {
+		return nil, WrapError(err, "get lab members without publications")
+	}
+	defer rows.Close()
+
+	return scanLabMembers(rows, "lab members without publications")
+}
+
+// SearchByName retrieves members whose name contains query, ignoring case, in the same
+// order as GetAll. LIKE wildcards in query match literally. Alumni are left out unless
+// includeAlumni is set. A blank query returns ErrInvalidInput.
+func (r *LabMemberRepository) SearchByName(ctx context.Context, query string, includeAlumni bool) ([]models.LabMember, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", ErrInvalidInput)
+	}
+
+	sqlQuery := `
+		SELECT id, name, role, email, bio, photo_url, personal_page_content,
+		       research_interests, is_alumni, display_order, created_at, updated_at
+		FROM lab_members
+		WHERE name LIKE $1 ESCAPE '\' AND ($2 OR is_alumni = false)
+		ORDER BY is_alumni ASC, display_order ASC, created_at DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, sqlQuery, "%"+escapeLike(query)+"%", includeAlumni)
+	if err != nil {
+		return nil, WrapError(err, "search lab members by name")
+	}
+	defer rows.Close()
+
+	return scanLabMembers(rows, "lab member search results")
+}
+
+// GetPhotoURLs retrieves the photo URLs of all members, including alumni.
+func (r *LabMemberRepository) GetPhotoURLs(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT photo_url
+		FROM lab_members
+		WHERE photo_url IS NOT NULL AND photo_url != ''
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "get member photo urls")
+	}
+	defer rows.Close()
+
+	var urls []string
 	for rows.Next() {
-		var member models.LabMember
-		err := rows.Scan(
-			&member.ID,
-			&member.Name,
-			&member.Role,
-			&member.Email,
-			&member.Bio,
-			&member.PhotoURL,
-			&member.PersonalPageContent,
-			&member.ResearchInterests,
-			&member.IsAlumni,
-			&member.DisplayOrder,
-			&member.CreatedAt,
-			&member.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan alumni member")
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, WrapError(err, "scan member photo url")
 		}
-		members = append(members, member)
+		urls = append(urls, url)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate alumni")
+		return nil, WrapError(err, "iterate member photo urls")
 	}
 
-	return members, nil
+	return urls, nil
+}
+
+// GetAllInterestTags returns every research interest listed by any member, including alumni,
+// with the number of members listing it. Interests are read from the comma-separated
+// research_interests column, trimmed and lowercased; a member listing a tag twice counts once.
+func (r *LabMemberRepository) GetAllInterestTags(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT research_interests
+		FROM lab_members
+		WHERE research_interests IS NOT NULL AND research_interests != ''
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "get member interest tags")
+	}
+	defer rows.Close()
+
+	tags := make(map[string]int)
+	for rows.Next() {
+		var interests string
+		if err := rows.Scan(&interests); err != nil {
+			return nil, WrapError(err, "scan member interest tags")
+		}
+
+		seen := make(map[string]bool)
+		for _, tag := range strings.Split(interests, ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags[tag]++
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate member interest tags")
+	}
+
+	return tags, nil
 }
 
 // Create inserts a new lab member.
@@ -207,23 +382,28 @@ func (r *LabMemberRepository) Create(ctx context.Context, member *models.LabMemb
 		RETURNING id, created_at, updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		member.Name,
-		member.Role,
-		member.Email,
-		member.Bio,
-		member.PhotoURL,
-		member.PersonalPageContent,
-		member.ResearchInterests,
-		member.IsAlumni,
-		member.DisplayOrder,
-	)
-
-	err := row.Scan(&member.ID, &member.CreatedAt, &member.UpdatedAt)
+	err := r.withAudit(ctx, models.AuditActionCreate, AuditEntityLabMember, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			member.Name,
+			member.Role,
+			member.Email,
+			member.Bio,
+			member.PhotoURL,
+			member.PersonalPageContent,
+			member.ResearchInterests,
+			member.IsAlumni,
+			member.DisplayOrder,
+		)
+
+		if err := row.Scan(&member.ID, &member.CreatedAt, &member.UpdatedAt); err != nil {
+			return 0, WrapError(err, "create lab member")
+		}
+		return member.ID, nil
+	})
 	if err != nil {
-		return nil, WrapError(err, "create lab member")
+		return nil, err
 	}
 
 	return member, nil
@@ -240,27 +420,32 @@ func (r *LabMemberRepository) Update(ctx context.Context, member *models.LabMemb
 		RETURNING updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		member.Name,
-		member.Role,
-		member.Email,
-		member.Bio,
-		member.PhotoURL,
-		member.PersonalPageContent,
-		member.ResearchInterests,
-		member.IsAlumni,
-		member.DisplayOrder,
-		member.ID,
-	)
-
-	err := row.Scan(&member.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+	err := r.withAudit(ctx, models.AuditActionUpdate, AuditEntityLabMember, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			member.Name,
+			member.Role,
+			member.Email,
+			member.Bio,
+			member.PhotoURL,
+			member.PersonalPageContent,
+			member.ResearchInterests,
+			member.IsAlumni,
+			member.DisplayOrder,
+			member.ID,
+		)
+
+		if err := row.Scan(&member.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update lab member")
 		}
-		return nil, WrapError(err, "update lab member")
+		return member.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return member, nil
@@ -270,12 +455,37 @@ func (r *LabMemberRepository) Update(ctx context.Context, member *models.LabMemb
 func (r *LabMemberRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM lab_members WHERE id = $1`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
-	if err != nil {
-		return WrapError(err, "delete lab member")
-	}
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityLabMember, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "delete lab member")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
+}
+
+// PurgeMember removes a member and every link to them, for data removal requests. In one
+// transaction it unlinks the member from publications and projects, then deletes the
+// member row, so a failure leaves everything in place. Names in publications' authors_text
+// are not touched. ErrNotFound is returned if the member does not exist.
+func (r *LabMemberRepository) PurgeMember(ctx context.Context, id int) error {
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityLabMember, func(txCtx context.Context) (int, error) {
+		execer := r.GetExecer(txCtx)
 
-	return CheckRowsAffected(result, 1)
+		if _, err := execer.ExecContext(txCtx, `DELETE FROM publication_authors WHERE member_id = $1`, id); err != nil {
+			return 0, WrapError(err, "unlink purged member from publications")
+		}
+		if _, err := execer.ExecContext(txCtx, `DELETE FROM project_members WHERE member_id = $1`, id); err != nil {
+			return 0, WrapError(err, "unlink purged member from projects")
+		}
+
+		result, err := execer.ExecContext(txCtx, `DELETE FROM lab_members WHERE id = $1`, id)
+		if err != nil {
+			return 0, WrapError(err, "purge lab member")
+		}
+
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
 // MarkAsAlumni updates a member's alumni status.
@@ -286,12 +496,115 @@ func (r *LabMemberRepository) MarkAsAlumni(ctx context.Context, id int, isAlumni
 		WHERE id = $2
 	`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, isAlumni, id)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityLabMember, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, isAlumni, id)
+		if err != nil {
+			return 0, WrapError(err, "mark member as alumni")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
+}
+
+// ReassignPublications moves every authorship of member fromID to member toID, for
+// merging duplicate member records. Publications both members already authored are
+// left linked to toID only, so no duplicate author links are created. An update of each
+// affected publication is recorded in the audit log. It returns the number of authorships
+// moved and ErrNotFound if toID does not exist.
+func (r *LabMemberRepository) ReassignPublications(ctx context.Context, fromID, toID int) (int, error) {
+	if fromID == toID {
+		return 0, fmt.Errorf("%w: cannot reassign publications of member %d to itself", ErrInvalidInput, fromID)
+	}
+
+	var moved int
+	err := r.withAuditEach(ctx, models.AuditActionUpdate, AuditEntityPublication, func(txCtx context.Context) ([]int, error) {
+		if _, err := r.GetByID(txCtx, toID); err != nil {
+			return nil, err
+		}
+
+		// Every publication fromID authored changes author list, moved or not
+		publicationIDs, err := r.authoredPublicationIDs(txCtx, fromID)
+		if err != nil {
+			return nil, err
+		}
+
+		// OR IGNORE skips the publications toID already authors
+		result, err := r.GetExecer(txCtx).ExecContext(txCtx, `
+			UPDATE OR IGNORE publication_authors
+			SET member_id = $1
+			WHERE member_id = $2
+		`, toID, fromID)
+		if err != nil {
+			return nil, WrapError(err, "reassign publications")
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, WrapError(err, "count reassigned publications")
+		}
+		moved = int(affected)
+
+		// Drop the links left behind by the overlapping publications
+		_, err = r.GetExecer(txCtx).ExecContext(txCtx, `DELETE FROM publication_authors WHERE member_id = $1`, fromID)
+		if err != nil {
+			return nil, WrapError(err, "remove overlapping publication links")
+		}
+		return publicationIDs, nil
+	})
 	if err != nil {
-		return WrapError(err, "mark member as alumni")
+		return 0, err
 	}
 
-	return CheckRowsAffected(result, 1)
+	return moved, nil
+}
+
+// authoredPublicationIDs returns the IDs of the publications memberID is linked to as an author
+func (r *LabMemberRepository) authoredPublicationIDs(ctx context.Context, memberID int) ([]int, error) {
+	rows, err := r.GetExecer(ctx).QueryContext(ctx,
+		`SELECT publication_id FROM publication_authors WHERE member_id = $1 ORDER BY publication_id`, memberID)
+	if err != nil {
+		return nil, WrapError(err, "get authored publications")
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, WrapError(err, "scan authored publication")
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate authored publications")
+	}
+
+	return ids, nil
+}
+
+// MarkAsAlumniAndReorder marks a member as alumni and, in the same transaction, moves
+// them to the end of the alumni ordering so the team page stays tidy.
+// Returns ErrNotFound if the member does not exist.
+func (r *LabMemberRepository) MarkAsAlumniAndReorder(ctx context.Context, id int) error {
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityLabMember, func(txCtx context.Context) (int, error) {
+		query := `
+			UPDATE lab_members
+			SET is_alumni = true,
+			    display_order = (
+			        SELECT COALESCE(MAX(display_order), -1) + 1
+			        FROM lab_members
+			        WHERE is_alumni = true AND id != $1
+			    ),
+			    updated_at = datetime('now')
+			WHERE id = $1
+		`
+
+		result, err := r.GetExecer(txCtx).ExecContext(txCtx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "mark member as alumni and reorder")
+		}
+
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
 // UpdatePhotoURL updates a member's photo URL.
@@ -302,10 +615,11 @@ func (r *LabMemberRepository) UpdatePhotoURL(ctx context.Context, id int, photoU
 		WHERE id = $2
 	`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, photoURL, id)
-	if err != nil {
-		return WrapError(err, "update member photo")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityLabMember, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, photoURL, id)
+		if err != nil {
+			return 0, WrapError(err, "update member photo")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
@@ -155,3 +155,468 @@ func TestLabMemberRepository_CRUD(t *testing.T) {
 		assert.Equal(t, ErrNotFound, err)
 	})
 }
+
+func TestLabMemberRepository_GetGroupedByRole(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []models.LabMember{
+		{Name: "PhD Second", Role: models.LabMemberRolePhD, DisplayOrder: 2},
+		{Name: "Principal", Role: models.LabMemberRolePI, DisplayOrder: 1},
+		{Name: "PhD First", Role: models.LabMemberRolePhD, DisplayOrder: 1},
+		{Name: "Postdoc", Role: models.LabMemberRolePostdoc, DisplayOrder: 1},
+		{Name: "Former PhD", Role: models.LabMemberRolePhD, IsAlumni: true, DisplayOrder: 0},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	groups, err := repo.GetGroupedByRole(ctx)
+	require.NoError(t, err)
+
+	assert.Len(t, groups, 3)
+	require.Len(t, groups[models.LabMemberRolePI], 1)
+	assert.Equal(t, "Principal", groups[models.LabMemberRolePI][0].Name)
+	require.Len(t, groups[models.LabMemberRolePostdoc], 1)
+
+	// Alumni are excluded and members are ordered by display_order within a role
+	phds := groups[models.LabMemberRolePhD]
+	require.Len(t, phds, 2)
+	assert.Equal(t, "PhD First", phds[0].Name)
+	assert.Equal(t, "PhD Second", phds[1].Name)
+
+	assert.Empty(t, groups[models.LabMemberRoleMaster])
+}
+
+func TestLabMemberRepository_GetAllPaginated(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []models.LabMember{
+		{Name: "Member 3", Role: models.LabMemberRolePhD, DisplayOrder: 3},
+		{Name: "Member 1", Role: models.LabMemberRolePI, DisplayOrder: 1},
+		{Name: "Alumnus", Role: models.LabMemberRolePhD, IsAlumni: true, DisplayOrder: 0},
+		{Name: "Member 2", Role: models.LabMemberRolePostdoc, DisplayOrder: 2},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	names := func(members []models.LabMember) []string {
+		var out []string
+		for _, m := range members {
+			out = append(out, m.Name)
+		}
+		return out
+	}
+
+	t.Run("pages across members", func(t *testing.T) {
+		page, total, err := repo.GetAllPaginated(ctx, 2, 0, false)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []string{"Member 1", "Member 2"}, names(page))
+
+		page, total, err = repo.GetAllPaginated(ctx, 2, 2, false)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []string{"Member 3"}, names(page))
+
+		page, _, err = repo.GetAllPaginated(ctx, 2, 4, false)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+	})
+
+	t.Run("including alumni affects page and count", func(t *testing.T) {
+		page, total, err := repo.GetAllPaginated(ctx, 10, 0, true)
+		require.NoError(t, err)
+		assert.Equal(t, 4, total)
+		// Alumni sort after current members, matching GetAll
+		assert.Equal(t, []string{"Member 1", "Member 2", "Member 3", "Alumnus"}, names(page))
+	})
+
+	t.Run("invalid paging", func(t *testing.T) {
+		_, _, err := repo.GetAllPaginated(ctx, 0, 0, false)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		_, _, err = repo.GetAllPaginated(ctx, 10, -1, false)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("oversized limit is clamped", func(t *testing.T) {
+		for i := 0; i < MaxPageSize; i++ {
+			_, err := repo.Create(ctx, &models.LabMember{Name: "Extra", Role: models.LabMemberRoleResearcher, DisplayOrder: 10 + i})
+			require.NoError(t, err)
+		}
+
+		page, total, err := repo.GetAllPaginated(ctx, MaxPageSize+1, 0, true)
+		require.NoError(t, err)
+		assert.Equal(t, MaxPageSize+4, total)
+		assert.Len(t, page, MaxPageSize)
+	})
+}
+
+func TestLabMemberRepository_GetPhotoURLs(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []models.LabMember{
+		{Name: "With Photo", Role: models.LabMemberRolePI, PhotoURL: sql.NullString{String: "/uploads/a.png", Valid: true}},
+		{Name: "Alumnus", Role: models.LabMemberRolePhD, IsAlumni: true, PhotoURL: sql.NullString{String: "/uploads/b.jpg", Valid: true}},
+		{Name: "Empty Photo", Role: models.LabMemberRolePhD, PhotoURL: sql.NullString{String: "", Valid: true}},
+		{Name: "No Photo", Role: models.LabMemberRoleMaster},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	urls, err := repo.GetPhotoURLs(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"/uploads/a.png", "/uploads/b.jpg"}, urls)
+}
+
+func TestLabMemberRepository_CountByRole(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []models.LabMember{
+		{Name: "Principal", Role: models.LabMemberRolePI},
+		{Name: "PhD One", Role: models.LabMemberRolePhD},
+		{Name: "PhD Two", Role: models.LabMemberRolePhD},
+		{Name: "Postdoc", Role: models.LabMemberRolePostdoc},
+		{Name: "Former PhD", Role: models.LabMemberRolePhD, IsAlumni: true},
+		{Name: "Former Master", Role: models.LabMemberRoleMaster, IsAlumni: true},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	t.Run("current members only", func(t *testing.T) {
+		counts, err := repo.CountByRole(ctx, false)
+		require.NoError(t, err)
+		assert.Equal(t, map[models.LabMemberRole]int{
+			models.LabMemberRolePI:      1,
+			models.LabMemberRolePhD:     2,
+			models.LabMemberRolePostdoc: 1,
+		}, counts)
+	})
+
+	t.Run("including alumni", func(t *testing.T) {
+		counts, err := repo.CountByRole(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, map[models.LabMemberRole]int{
+			models.LabMemberRolePI:      1,
+			models.LabMemberRolePhD:     3,
+			models.LabMemberRolePostdoc: 1,
+			models.LabMemberRoleMaster:  1,
+		}, counts)
+	})
+
+	t.Run("no members", func(t *testing.T) {
+		counts, err := NewLabMemberRepository(setupTestDB(t)).CountByRole(ctx, true)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+}
+
+func TestLabMemberRepository_GetWithoutPublications(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+
+	author := &models.LabMember{Name: "Published Author", Role: models.LabMemberRolePI}
+	newcomer := &models.LabMember{Name: "Newcomer", Role: models.LabMemberRolePhD}
+	alumnus := &models.LabMember{Name: "Quiet Alumnus", Role: models.LabMemberRoleMaster, IsAlumni: true}
+	for _, m := range []*models.LabMember{author, newcomer, alumnus} {
+		_, err := repo.Create(ctx, m)
+		require.NoError(t, err)
+	}
+
+	pub, err := pubRepo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: "Published Author", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pub.ID, author.ID))
+
+	t.Run("current members only", func(t *testing.T) {
+		members, err := repo.GetWithoutPublications(ctx, false)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, newcomer.ID, members[0].ID)
+	})
+
+	t.Run("including alumni", func(t *testing.T) {
+		members, err := repo.GetWithoutPublications(ctx, true)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, newcomer.ID, members[0].ID)
+		assert.Equal(t, alumnus.ID, members[1].ID)
+	})
+}
+
+func TestLabMemberRepository_SearchByName(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	ada := &models.LabMember{Name: "Ada Lovelace", Role: models.LabMemberRolePI, DisplayOrder: 1}
+	alan := &models.LabMember{Name: "Alan Turing", Role: models.LabMemberRolePhD, DisplayOrder: 2}
+	grace := &models.LabMember{Name: "Grace Hopper", Role: models.LabMemberRoleMaster, IsAlumni: true}
+	percent := &models.LabMember{Name: "Bot 100%", Role: models.LabMemberRoleResearcher, DisplayOrder: 3}
+	underscore := &models.LabMember{Name: "snake_case", Role: models.LabMemberRoleResearcher, DisplayOrder: 4}
+	for _, m := range []*models.LabMember{ada, alan, grace, percent, underscore} {
+		_, err := repo.Create(ctx, m)
+		require.NoError(t, err)
+	}
+
+	names := func(members []models.LabMember) []string {
+		out := make([]string, len(members))
+		for i, m := range members {
+			out[i] = m.Name
+		}
+		return out
+	}
+
+	t.Run("partial match ignores case", func(t *testing.T) {
+		members, err := repo.SearchByName(ctx, "LA", false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ada Lovelace", "Alan Turing"}, names(members))
+	})
+
+	t.Run("alumni only when requested", func(t *testing.T) {
+		members, err := repo.SearchByName(ctx, "hopper", false)
+		require.NoError(t, err)
+		assert.Empty(t, members)
+
+		members, err = repo.SearchByName(ctx, "hopper", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Grace Hopper"}, names(members))
+	})
+
+	t.Run("wildcards match literally", func(t *testing.T) {
+		members, err := repo.SearchByName(ctx, "%", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bot 100%"}, names(members))
+
+		members, err = repo.SearchByName(ctx, "e_c", true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"snake_case"}, names(members))
+	})
+
+	t.Run("blank query is rejected", func(t *testing.T) {
+		_, err := repo.SearchByName(ctx, "  ", true)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestLabMemberRepository_GetFiltered(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []models.LabMember{
+		{Name: "PhD Current", Role: models.LabMemberRolePhD, DisplayOrder: 1},
+		{Name: "PhD Alumnus", Role: models.LabMemberRolePhD, IsAlumni: true},
+		{Name: "Postdoc", Role: models.LabMemberRolePostdoc, DisplayOrder: 2},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	alumni, current := true, false
+	tests := []struct {
+		name   string
+		filter LabMemberFilter
+		want   []string
+	}{
+		{"no filter", LabMemberFilter{}, []string{"PhD Current", "Postdoc", "PhD Alumnus"}},
+		{"role", LabMemberFilter{Role: models.LabMemberRolePhD}, []string{"PhD Current", "PhD Alumnus"}},
+		{"alumni", LabMemberFilter{IsAlumni: &alumni}, []string{"PhD Alumnus"}},
+		{"role and current", LabMemberFilter{Role: models.LabMemberRolePhD, IsAlumni: &current}, []string{"PhD Current"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members, err := repo.GetFiltered(ctx, tt.filter)
+			require.NoError(t, err)
+
+			var names []string
+			for _, m := range members {
+				names = append(names, m.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestLabMemberRepository_ReassignPublications(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+
+	duplicate := &models.LabMember{Name: "J. Doe", Role: models.LabMemberRolePhD}
+	original := &models.LabMember{Name: "Jane Doe", Role: models.LabMemberRolePhD}
+	for _, m := range []*models.LabMember{duplicate, original} {
+		_, err := repo.Create(ctx, m)
+		require.NoError(t, err)
+	}
+
+	var pubIDs []int
+	for _, title := range []string{"Only Duplicate A", "Only Duplicate B", "Shared", "Only Original"} {
+		pub, err := pubRepo.Create(ctx, &models.Publication{Title: title, AuthorsText: "Jane Doe", Year: 2024})
+		require.NoError(t, err)
+		pubIDs = append(pubIDs, pub.ID)
+	}
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pubIDs[0], duplicate.ID))
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pubIDs[1], duplicate.ID))
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pubIDs[2], duplicate.ID))
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pubIDs[2], original.ID))
+	require.NoError(t, pubRepo.LinkAuthor(ctx, pubIDs[3], original.ID))
+
+	moved, err := repo.ReassignPublications(ctx, duplicate.ID, original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved)
+
+	pubs, err := pubRepo.GetByMember(ctx, original.ID)
+	require.NoError(t, err)
+	assert.Len(t, pubs, 4)
+
+	pubs, err = pubRepo.GetByMember(ctx, duplicate.ID)
+	require.NoError(t, err)
+	assert.Empty(t, pubs)
+
+	var links int
+	err = dbManager.GetDB().QueryRow(`SELECT COUNT(*) FROM publication_authors WHERE publication_id = $1`, pubIDs[2]).Scan(&links)
+	require.NoError(t, err)
+	assert.Equal(t, 1, links, "shared publication must not get a duplicate author link")
+
+	t.Run("unknown target", func(t *testing.T) {
+		_, err := repo.ReassignPublications(ctx, original.ID, 99999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("same member", func(t *testing.T) {
+		_, err := repo.ReassignPublications(ctx, original.ID, original.ID)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestLabMemberRepository_MarkAsAlumniAndReorder(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	seed := []*models.LabMember{
+		{Name: "Active First", Role: models.LabMemberRolePhD, DisplayOrder: 1},
+		{Name: "Leaving", Role: models.LabMemberRolePhD, DisplayOrder: 2},
+		{Name: "Old Alumnus", Role: models.LabMemberRolePhD, IsAlumni: true, DisplayOrder: 5},
+		{Name: "Recent Alumnus", Role: models.LabMemberRoleMaster, IsAlumni: true, DisplayOrder: 7},
+	}
+	for _, m := range seed {
+		_, err := repo.Create(ctx, m)
+		require.NoError(t, err)
+	}
+	leaving := seed[1]
+
+	require.NoError(t, repo.MarkAsAlumniAndReorder(ctx, leaving.ID))
+
+	updated, err := repo.GetByID(ctx, leaving.ID)
+	require.NoError(t, err)
+	assert.True(t, updated.IsAlumni)
+	assert.Equal(t, 8, updated.DisplayOrder)
+
+	alumni, err := repo.GetAlumni(ctx)
+	require.NoError(t, err)
+	require.Len(t, alumni, 3)
+	assert.Equal(t, leaving.ID, alumni[2].ID)
+
+	t.Run("already last keeps its place", func(t *testing.T) {
+		require.NoError(t, repo.MarkAsAlumniAndReorder(ctx, leaving.ID))
+
+		again, err := repo.GetByID(ctx, leaving.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 8, again.DisplayOrder)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		assert.ErrorIs(t, repo.MarkAsAlumniAndReorder(ctx, 99999), ErrNotFound)
+	})
+}
+
+func TestLabMemberRepository_GetAllInterestTags(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	for _, interests := range []sql.NullString{
+		{String: "Machine Learning, Graphs", Valid: true},
+		{String: "graphs,  machine learning ,Robotics", Valid: true},
+		{String: "Robotics, robotics,", Valid: true},
+		{String: "", Valid: true},
+		{},
+	} {
+		_, err := repo.Create(ctx, &models.LabMember{
+			Name:              "Member",
+			Role:              models.LabMemberRolePhD,
+			ResearchInterests: interests,
+		})
+		require.NoError(t, err)
+	}
+
+	tags, err := repo.GetAllInterestTags(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"machine learning": 2,
+		"graphs":           2,
+		"robotics":         2,
+	}, tags)
+}
+
+func TestLabMemberRepository_PurgeMember(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+	projRepo := NewProjectRepository(dbManager)
+
+	leaving, err := repo.Create(ctx, &models.LabMember{Name: "Leaving Collaborator", Role: models.LabMemberRolePostdoc})
+	require.NoError(t, err)
+	staying, err := repo.Create(ctx, &models.LabMember{Name: "Staying Member", Role: models.LabMemberRolePI})
+	require.NoError(t, err)
+
+	pub, err := pubRepo.Create(ctx, &models.Publication{Title: "Joint Paper", AuthorsText: "Leaving Collaborator, Staying Member", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, pubRepo.LinkAuthors(ctx, pub.ID, []int{leaving.ID, staying.ID}))
+
+	proj, err := projRepo.Create(ctx, &models.Project{Title: "Joint Project", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+	require.NoError(t, projRepo.LinkMember(ctx, proj.ID, leaving.ID))
+	require.NoError(t, projRepo.LinkMember(ctx, proj.ID, staying.ID))
+
+	require.NoError(t, repo.PurgeMember(ctx, leaving.ID))
+
+	t.Run("member is gone", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, leaving.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("links are gone", func(t *testing.T) {
+		var links int
+		require.NoError(t, dbManager.GetDB().QueryRow(
+			`SELECT (SELECT COUNT(*) FROM publication_authors WHERE member_id = $1)
+			      + (SELECT COUNT(*) FROM project_members WHERE member_id = $1)`, leaving.ID).Scan(&links))
+		assert.Zero(t, links)
+	})
+
+	t.Run("other members keep their links", func(t *testing.T) {
+		authors, err := pubRepo.GetAuthors(ctx, pub.ID)
+		require.NoError(t, err)
+		require.Len(t, authors, 1)
+		assert.Equal(t, staying.ID, authors[0].ID)
+
+		members, err := projRepo.CountMembers(ctx, proj.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, members)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		assert.ErrorIs(t, repo.PurgeMember(ctx, leaving.ID), ErrNotFound)
+	})
+}
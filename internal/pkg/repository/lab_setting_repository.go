@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/db"
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// LabSettingRepository provides data access for the lab_settings key-value store.
+type LabSettingRepository struct {
+	*BaseRepository
+}
+
+// NewLabSettingRepository creates a new lab setting repository.
+func NewLabSettingRepository(dbManager *db.DBManager) *LabSettingRepository {
+	return &LabSettingRepository{
+		BaseRepository: NewBaseRepository(dbManager, "lab_settings"),
+	}
+}
+
+// Get retrieves the default-locale value of a setting.
+// Returns ErrNotFound if the setting does not exist.
+func (r *LabSettingRepository) Get(ctx context.Context, key string) (string, error) {
+	query := `SELECT setting_value FROM lab_settings WHERE setting_key = $1 AND locale = $2`
+
+	var value string
+	err := r.GetExecer(ctx).QueryRowContext(ctx, query, key, models.DefaultSettingLocale).Scan(&value)
+	if err != nil {
+		return "", WrapError(err, "get lab setting")
+	}
+
+	return value, nil
+}
+
+// GetLocalized retrieves a setting in the given locale (e.g. "fr" or "pt-BR", as found in
+// Accept-Language). When there is no value for that locale it falls back to the base
+// language ("pt" for "pt-BR") and then to the default locale. Returns ErrNotFound if the
+// setting does not exist in any of them.
+func (r *LabSettingRepository) GetLocalized(ctx context.Context, key, locale string) (*models.LabSetting, error) {
+	locale = normalizeLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	query := `
+		SELECT id, setting_key, setting_value, locale, created_at, updated_at
+		FROM lab_settings
+		WHERE setting_key = $1 AND locale IN ($2, $3, $4)
+		ORDER BY CASE locale WHEN $2 THEN 0 WHEN $3 THEN 1 ELSE 2 END
+		LIMIT 1
+	`
+
+	var s models.LabSetting
+	err := r.GetExecer(ctx).QueryRowContext(ctx, query, key, locale, language, models.DefaultSettingLocale).
+		Scan(&s.ID, &s.SettingKey, &s.SettingValue, &s.Locale, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, WrapError(err, "get localized lab setting")
+	}
+
+	return &s, nil
+}
+
+// GetAll retrieves all settings ordered by key, default locale first.
+func (r *LabSettingRepository) GetAll(ctx context.Context) ([]models.LabSetting, error) {
+	query := `
+		SELECT id, setting_key, setting_value, locale, created_at, updated_at
+		FROM lab_settings
+		ORDER BY setting_key ASC, locale ASC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "get all lab settings")
+	}
+	defer rows.Close()
+
+	var settings []models.LabSetting
+	for rows.Next() {
+		var s models.LabSetting
+		if err := rows.Scan(&s.ID, &s.SettingKey, &s.SettingValue, &s.Locale, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, WrapError(err, "scan lab setting")
+		}
+		settings = append(settings, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate lab settings")
+	}
+
+	return settings, nil
+}
+
+// Set creates or replaces the default-locale value of a setting.
+func (r *LabSettingRepository) Set(ctx context.Context, key, value string) error {
+	return r.SetLocalized(ctx, key, models.DefaultSettingLocale, value)
+}
+
+// SetLocalized creates or replaces the value of a setting for one locale.
+// Locales are matched case-insensitively, so "pt-BR" and "pt-br" are the same.
+func (r *LabSettingRepository) SetLocalized(ctx context.Context, key, locale, value string) error {
+	if key == "" {
+		return ErrInvalidInput
+	}
+
+	query := `
+		INSERT INTO lab_settings (setting_key, setting_value, locale, created_at, updated_at)
+		VALUES ($1, $2, $3, datetime('now'), datetime('now'))
+		ON CONFLICT (setting_key, locale) DO UPDATE
+		SET setting_value = excluded.setting_value, updated_at = datetime('now')
+	`
+
+	_, err := r.GetExecer(ctx).ExecContext(ctx, query, key, value, normalizeLocale(locale))
+	if err != nil {
+		return WrapError(err, "set lab setting")
+	}
+
+	return nil
+}
+
+// LabName returns the lab name used in page titles and feed channel names,
+// or models.DefaultLabName when it is not set or blank.
+func (r *LabSettingRepository) LabName(ctx context.Context) (string, error) {
+	name, err := r.Get(ctx, models.LabSettingName)
+	if errors.Is(err, ErrNotFound) {
+		return models.DefaultLabName, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		return models.DefaultLabName, nil
+	}
+	return name, nil
+}
+
+// normalizeLocale trims and lowercases a locale tag and uses "-" as the separator,
+// which is the form locales are stored in
+func normalizeLocale(locale string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabSettingRepository_GetSet(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabSettingRepository(dbManager)
+
+	t.Run("missing setting", func(t *testing.T) {
+		_, err := repo.Get(ctx, "unknown_key")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("set creates and replaces", func(t *testing.T) {
+		require.NoError(t, repo.Set(ctx, "contact_email", "lab@example.com"))
+		require.NoError(t, repo.Set(ctx, "contact_email", "office@example.com"))
+
+		value, err := repo.Get(ctx, "contact_email")
+		require.NoError(t, err)
+		assert.Equal(t, "office@example.com", value)
+	})
+
+	t.Run("empty key is rejected", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Set(ctx, "", "value"), ErrInvalidInput)
+	})
+}
+
+func TestLabSettingRepository_LabName(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabSettingRepository(dbManager)
+
+	t.Run("configured value", func(t *testing.T) {
+		require.NoError(t, repo.Set(ctx, models.LabSettingName, "Vision Systems Lab"))
+
+		name, err := repo.LabName(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Vision Systems Lab", name)
+	})
+
+	t.Run("blank value falls back to default", func(t *testing.T) {
+		require.NoError(t, repo.Set(ctx, models.LabSettingName, "  "))
+
+		name, err := repo.LabName(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultLabName, name)
+	})
+
+	t.Run("unset falls back to default", func(t *testing.T) {
+		_, err := dbManager.GetDB().Exec(`DELETE FROM lab_settings WHERE setting_key = $1`, models.LabSettingName)
+		require.NoError(t, err)
+
+		name, err := repo.LabName(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultLabName, name)
+	})
+}
+
+func TestLabSettingRepository_GetLocalized(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabSettingRepository(dbManager)
+
+	require.NoError(t, repo.Set(ctx, models.LabSettingName, "Vision Lab"))
+	require.NoError(t, repo.SetLocalized(ctx, models.LabSettingName, "fr", "Laboratoire Vision"))
+	require.NoError(t, repo.SetLocalized(ctx, models.LabSettingName, "pt-BR", "Laboratório de Visão"))
+
+	t.Run("exact locale", func(t *testing.T) {
+		setting, err := repo.GetLocalized(ctx, models.LabSettingName, "pt-br")
+		require.NoError(t, err)
+		assert.Equal(t, "Laboratório de Visão", setting.SettingValue)
+		assert.Equal(t, "pt-br", setting.Locale)
+	})
+
+	t.Run("falls back to the base language", func(t *testing.T) {
+		setting, err := repo.GetLocalized(ctx, models.LabSettingName, "fr-CA")
+		require.NoError(t, err)
+		assert.Equal(t, "Laboratoire Vision", setting.SettingValue)
+	})
+
+	t.Run("falls back to the default locale", func(t *testing.T) {
+		setting, err := repo.GetLocalized(ctx, models.LabSettingName, "de")
+		require.NoError(t, err)
+		assert.Equal(t, "Vision Lab", setting.SettingValue)
+		assert.Equal(t, models.DefaultSettingLocale, setting.Locale)
+	})
+
+	t.Run("translations leave the default untouched", func(t *testing.T) {
+		value, err := repo.Get(ctx, models.LabSettingName)
+		require.NoError(t, err)
+		assert.Equal(t, "Vision Lab", value)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := repo.GetLocalized(ctx, "unknown_key", "fr")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -3,6 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -90,8 +94,15 @@ func (r *NewsRepository) GetAll(ctx context.Context) ([]models.News, error) {
 	return news, nil
 }
 
-// GetPublished retrieves all published news items that should be visible to the public.
+// GetPublished retrieves the most recent published news items that should be visible to the public.
+// Callers normally pass config.NewsPageLimit; a non-positive limit returns ErrInvalidInput and
+// a limit above MaxPageSize is clamped.
 func (r *NewsRepository) GetPublished(ctx context.Context, limit int) ([]models.News, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT id, title, content, published_at, is_published, created_at, updated_at
 		FROM news
@@ -133,6 +144,57 @@ func (r *NewsRepository) GetPublished(ctx context.Context, limit int) ([]models.
 	return news, nil
 }
 
+// GetByMonth retrieves published news whose effective date (published_at, or created_at
+// when unset) falls within the given month, newest first. Used by the news archive.
+func (r *NewsRepository) GetByMonth(ctx context.Context, year, month int) ([]models.News, error) {
+	if month < 1 || month > 12 {
+		return nil, ErrInvalidInput
+	}
+
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	query := `
+		SELECT id, title, content, published_at, is_published, created_at, updated_at
+		FROM news
+		WHERE is_published = true
+		  AND COALESCE(published_at, created_at) >= $1
+		  AND COALESCE(published_at, created_at) < $2
+		  AND (published_at IS NULL OR published_at <= datetime('now'))
+		ORDER BY COALESCE(published_at, created_at) DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, start.Format(time.DateTime), end.Format(time.DateTime))
+	if err != nil {
+		return nil, WrapError(err, "get news by month")
+	}
+	defer rows.Close()
+
+	var news []models.News
+	for rows.Next() {
+		var n models.News
+		err := rows.Scan(
+			&n.ID,
+			&n.Title,
+			&n.Content,
+			&n.PublishedAt,
+			&n.IsPublished,
+			&n.CreatedAt,
+			&n.UpdatedAt,
+		)
+		if err != nil {
+			return nil, WrapError(err, "scan news")
+		}
+		news = append(news, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate news by month")
+	}
+
+	return news, nil
+}
+
 // GetDrafts retrieves all unpublished news items.
 func (r *NewsRepository) GetDrafts(ctx context.Context) ([]models.News, error) {
 	query := `
@@ -173,10 +235,128 @@ func (r *NewsRepository) GetDrafts(ctx context.Context) ([]models.News, error) {
 	return news, nil
 }
 
-// Create inserts a new news item.
+// GetStaleDrafts retrieves unpublished news items that have not been updated for longer
+// than olderThan, least recently updated first. Used to remind editors of forgotten drafts.
+func (r *NewsRepository) GetStaleDrafts(ctx context.Context, olderThan time.Duration) ([]models.News, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("%w: stale draft threshold must be positive, got %s", ErrInvalidInput, olderThan)
+	}
+
+	cutoff := time.Now().UTC().Add(-olderThan).Format(sqliteDateTime)
+
+	query := `
+		SELECT id, title, content, published_at, is_published, created_at, updated_at
+		FROM news
+		WHERE is_published = false
+		  AND updated_at < $1
+		ORDER BY updated_at ASC, id ASC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, cutoff)
+	if err != nil {
+		return nil, WrapError(err, "get stale draft news")
+	}
+	defer rows.Close()
+
+	var news []models.News
+	for rows.Next() {
+		var n models.News
+		err := rows.Scan(
+			&n.ID,
+			&n.Title,
+			&n.Content,
+			&n.PublishedAt,
+			&n.IsPublished,
+			&n.CreatedAt,
+			&n.UpdatedAt,
+		)
+		if err != nil {
+			return nil, WrapError(err, "scan news")
+		}
+		news = append(news, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate stale draft news")
+	}
+
+	return news, nil
+}
+
+// CountByState returns how many news items GetPublished and GetDrafts would consider,
+// without a limit. Items published with a future date are counted in neither.
+func (r *NewsRepository) CountByState(ctx context.Context) (published int, drafts int, err error) {
+	publishedQuery := `
+		SELECT COUNT(*)
+		FROM news
+		WHERE is_published = true
+		  AND (published_at IS NULL OR published_at <= datetime('now'))
+	`
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, publishedQuery).Scan(&published); err != nil {
+		return 0, 0, WrapError(err, "count published news")
+	}
+
+	draftsQuery := `SELECT COUNT(*) FROM news WHERE is_published = false`
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, draftsQuery).Scan(&drafts); err != nil {
+		return 0, 0, WrapError(err, "count draft news")
+	}
+
+	return published, drafts, nil
+}
+
+// GetAdjacent returns the neighbours of a published news item for previous/next links,
+// using the same ordering as GetPublished. prev is the item published just before id and
+// next the one published just after it; either is nil at the ends of the list.
+// ErrNotFound is returned when id is not a published news item.
+func (r *NewsRepository) GetAdjacent(ctx context.Context, id int) (prev, next *models.News, err error) {
+	query := `
+		SELECT prev_id, next_id
+		FROM (
+			SELECT id,
+			       LEAD(id) OVER timeline AS prev_id,
+			       LAG(id) OVER timeline AS next_id
+			FROM news
+			WHERE is_published = true
+			  AND (published_at IS NULL OR published_at <= datetime('now'))
+			WINDOW timeline AS (
+				ORDER BY
+					CASE WHEN published_at IS NOT NULL THEN published_at ELSE created_at END DESC,
+					id DESC
+			)
+		)
+		WHERE id = $1
+	`
+
+	var prevID, nextID sql.NullInt64
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, query, id).Scan(&prevID, &nextID); err != nil {
+		return nil, nil, WrapError(err, "get adjacent news")
+	}
+
+	if prevID.Valid {
+		if prev, err = r.GetByID(ctx, int(prevID.Int64)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if nextID.Valid {
+		if next, err = r.GetByID(ctx, int(nextID.Int64)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return prev, next, nil
+}
+
+// Create inserts a new news item. A published item must pass the same checks as Publish;
+// drafts may be saved incomplete.
 func (r *NewsRepository) Create(ctx context.Context, news *models.News) (*models.News, error) {
+	if news.IsPublished {
+		if err := checkPublishable(news); err != nil {
+			return nil, err
+		}
+	}
+
 	var query string
-	var row *sql.Row
+	var args []interface{}
 
 	if news.PublishedAt.Valid {
 		// News with specific publish date
@@ -185,14 +365,7 @@ func (r *NewsRepository) Create(ctx context.Context, news *models.News) (*models
 			VALUES ($1, $2, $3, $4, datetime('now'), datetime('now'))
 			RETURNING id, created_at, updated_at
 		`
-		row = r.GetExecer(ctx).QueryRowContext(
-			ctx,
-			query,
-			news.Title,
-			news.Content,
-			news.PublishedAt,
-			news.IsPublished,
-		)
+		args = []interface{}{news.Title, news.Content, news.PublishedAt, news.IsPublished}
 	} else {
 		// News without specific publish date
 		query = `
@@ -200,27 +373,33 @@ func (r *NewsRepository) Create(ctx context.Context, news *models.News) (*models
 			VALUES ($1, $2, NULL, $3, datetime('now'), datetime('now'))
 			RETURNING id, created_at, updated_at
 		`
-		row = r.GetExecer(ctx).QueryRowContext(
-			ctx,
-			query,
-			news.Title,
-			news.Content,
-			news.IsPublished,
-		)
+		args = []interface{}{news.Title, news.Content, news.IsPublished}
 	}
 
-	err := row.Scan(&news.ID, &news.CreatedAt, &news.UpdatedAt)
+	err := r.withAudit(ctx, models.AuditActionCreate, AuditEntityNews, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(ctx, query, args...)
+		if err := row.Scan(&news.ID, &news.CreatedAt, &news.UpdatedAt); err != nil {
+			return 0, WrapError(err, "create news")
+		}
+		return news.ID, nil
+	})
 	if err != nil {
-		return nil, WrapError(err, "create news")
+		return nil, err
 	}
 
 	return news, nil
 }
 
-// Update modifies an existing news item.
+// Update modifies an existing news item. A published item must pass the same checks as Publish.
 func (r *NewsRepository) Update(ctx context.Context, news *models.News) (*models.News, error) {
+	if news.IsPublished {
+		if err := checkPublishable(news); err != nil {
+			return nil, err
+		}
+	}
+
 	var query string
-	var row *sql.Row
+	var args []interface{}
 
 	if news.PublishedAt.Valid {
 		query = `
@@ -230,15 +409,7 @@ func (r *NewsRepository) Update(ctx context.Context, news *models.News) (*models
 			WHERE id = $5
 			RETURNING updated_at
 		`
-		row = r.GetExecer(ctx).QueryRowContext(
-			ctx,
-			query,
-			news.Title,
-			news.Content,
-			news.PublishedAt,
-			news.IsPublished,
-			news.ID,
-		)
+		args = []interface{}{news.Title, news.Content, news.PublishedAt, news.IsPublished, news.ID}
 	} else {
 		query = `
 			UPDATE news
@@ -247,22 +418,21 @@ func (r *NewsRepository) Update(ctx context.Context, news *models.News) (*models
 			WHERE id = $4
 			RETURNING updated_at
 		`
-		row = r.GetExecer(ctx).QueryRowContext(
-			ctx,
-			query,
-			news.Title,
-			news.Content,
-			news.IsPublished,
-			news.ID,
-		)
+		args = []interface{}{news.Title, news.Content, news.IsPublished, news.ID}
 	}
 
-	err := row.Scan(&news.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+	err := r.withAudit(ctx, models.AuditActionUpdate, AuditEntityNews, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(ctx, query, args...)
+		if err := row.Scan(&news.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update news")
 		}
-		return nil, WrapError(err, "update news")
+		return news.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return news, nil
@@ -272,28 +442,52 @@ func (r *NewsRepository) Update(ctx context.Context, news *models.News) (*models
 func (r *NewsRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM news WHERE id = $1`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
-	if err != nil {
-		return WrapError(err, "delete news")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityNews, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "delete news")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
-// Publish marks a news item as published.
+// Publish marks a news item as published. Items without a title or with content shorter
+// than models.NewsMinContentLength are rejected with ErrInvalidInput; drafts may stay short.
 func (r *NewsRepository) Publish(ctx context.Context, id int) error {
-	query := `
-		UPDATE news
-		SET is_published = true, published_at = datetime('now'), updated_at = datetime('now')
-		WHERE id = $1
-	`
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityNews, func(txCtx context.Context) (int, error) {
+		news, err := r.GetByID(txCtx, id)
+		if err != nil {
+			return 0, err
+		}
+		if err := checkPublishable(news); err != nil {
+			return 0, err
+		}
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
-	if err != nil {
-		return WrapError(err, "publish news")
-	}
+		query := `
+			UPDATE news
+			SET is_published = true, published_at = datetime('now'), updated_at = datetime('now')
+			WHERE id = $1
+		`
 
-	return CheckRowsAffected(result, 1)
+		result, err := r.GetExecer(txCtx).ExecContext(txCtx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "publish news")
+		}
+
+		return id, CheckRowsAffected(result, 1)
+	})
+}
+
+// checkPublishable rejects news that is not ready to be shown publicly: a blank title or
+// content shorter than models.NewsMinContentLength, ignoring surrounding whitespace.
+func checkPublishable(news *models.News) error {
+	if strings.TrimSpace(news.Title) == "" {
+		return fmt.Errorf("%w: news needs a title before it can be published", ErrInvalidInput)
+	}
+	if min := models.NewsMinContentLength(); utf8.RuneCountInString(strings.TrimSpace(news.Content)) < min {
+		return fmt.Errorf("%w: news content must be at least %d characters to be published", ErrInvalidInput, min)
+	}
+	return nil
 }
 
 // Unpublish marks a news item as unpublished.
@@ -304,10 +498,11 @@ func (r *NewsRepository) Unpublish(ctx context.Context, id int) error {
 		WHERE id = $1
 	`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
-	if err != nil {
-		return WrapError(err, "unpublish news")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityNews, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "unpublish news")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
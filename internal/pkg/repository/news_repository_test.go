@@ -5,11 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// publishableContent is long enough to pass the content check for published news
+const publishableContent = "Content long enough to be published"
+
 func TestNewsRepository_CRUD(t *testing.T) {
 	dbManager := setupTestDB(t)
 	repo := NewNewsRepository(dbManager)
@@ -31,7 +35,7 @@ func TestNewsRepository_CRUD(t *testing.T) {
 	t.Run("get news by id", func(t *testing.T) {
 		news := &models.News{
 			Title:       "Another News",
-			Content:     "More content",
+			Content:     publishableContent,
 			IsPublished: true,
 			PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
 		}
@@ -50,7 +54,7 @@ func TestNewsRepository_CRUD(t *testing.T) {
 		for i := 0; i < 3; i++ {
 			news := &models.News{
 				Title:       "News Item " + string(rune('A'+i)),
-				Content:     "Content",
+				Content:     publishableContent,
 				IsPublished: i%2 == 0,
 			}
 			_, err := repo.Create(ctx, news)
@@ -66,7 +70,7 @@ func TestNewsRepository_CRUD(t *testing.T) {
 		// Create published news
 		news := &models.News{
 			Title:       "Published News",
-			Content:     "Published content",
+			Content:     publishableContent,
 			IsPublished: true,
 			PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
 		}
@@ -115,7 +119,7 @@ func TestNewsRepository_CRUD(t *testing.T) {
 	t.Run("publish news", func(t *testing.T) {
 		news := &models.News{
 			Title:       "To Publish",
-			Content:     "Content",
+			Content:     "Content long enough to be published",
 			IsPublished: false,
 		}
 
@@ -133,7 +137,7 @@ func TestNewsRepository_CRUD(t *testing.T) {
 	t.Run("unpublish news", func(t *testing.T) {
 		news := &models.News{
 			Title:       "To Unpublish",
-			Content:     "Content",
+			Content:     publishableContent,
 			IsPublished: true,
 			PublishedAt: sql.NullTime{Time: time.Now(), Valid: true},
 		}
@@ -166,3 +170,304 @@ func TestNewsRepository_CRUD(t *testing.T) {
 		assert.Equal(t, ErrNotFound, err)
 	})
 }
+
+func TestNewsRepository_GetByMonth(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	at := func(month time.Month, day, hour int) sql.NullTime {
+		return sql.NullTime{Time: time.Date(2024, month, day, hour, 0, 0, 0, time.UTC), Valid: true}
+	}
+
+	seed := []models.News{
+		{Title: "March early", Content: publishableContent, IsPublished: true, PublishedAt: at(time.March, 2, 9)},
+		{Title: "March late", Content: publishableContent, IsPublished: true, PublishedAt: at(time.March, 31, 23)},
+		{Title: "March draft", Content: "c", IsPublished: false, PublishedAt: at(time.March, 10, 9)},
+		{Title: "April first", Content: publishableContent, IsPublished: true, PublishedAt: at(time.April, 1, 0)},
+	}
+	for i := range seed {
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	t.Run("returns only the requested month", func(t *testing.T) {
+		march, err := repo.GetByMonth(ctx, 2024, 3)
+		require.NoError(t, err)
+		require.Len(t, march, 2)
+		assert.Equal(t, "March late", march[0].Title)
+		assert.Equal(t, "March early", march[1].Title)
+
+		april, err := repo.GetByMonth(ctx, 2024, 4)
+		require.NoError(t, err)
+		require.Len(t, april, 1)
+		assert.Equal(t, "April first", april[0].Title)
+
+		may, err := repo.GetByMonth(ctx, 2024, 5)
+		require.NoError(t, err)
+		assert.Empty(t, may)
+	})
+
+	t.Run("invalid month", func(t *testing.T) {
+		for _, month := range []int{0, 13} {
+			_, err := repo.GetByMonth(ctx, 2024, month)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		}
+	})
+}
+
+func TestNewsRepository_GetPublished_Limit(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	for i := 0; i < MaxPageSize+5; i++ {
+		_, err := repo.Create(ctx, &models.News{
+			Title:       "News",
+			Content:     publishableContent,
+			IsPublished: true,
+			PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Duration(i+1) * time.Minute), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("non-positive limit is rejected", func(t *testing.T) {
+		for _, limit := range []int{0, -1} {
+			_, err := repo.GetPublished(ctx, limit)
+			assert.ErrorIs(t, err, ErrInvalidInput, limit)
+		}
+	})
+
+	t.Run("limit is applied", func(t *testing.T) {
+		news, err := repo.GetPublished(ctx, 3)
+		require.NoError(t, err)
+		assert.Len(t, news, 3)
+	})
+
+	t.Run("large limit is clamped", func(t *testing.T) {
+		news, err := repo.GetPublished(ctx, 10000)
+		require.NoError(t, err)
+		assert.Len(t, news, MaxPageSize)
+	})
+}
+
+func TestNewsRepository_GetPublished_DefaultPageLimit(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	for i := 0; i < config.DefaultNewsPageLimit+1; i++ {
+		_, err := repo.Create(ctx, &models.News{
+			Title:       "News",
+			Content:     publishableContent,
+			IsPublished: true,
+			PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Duration(i+1) * time.Minute), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	news, err := repo.GetPublished(ctx, config.DefaultNewsPageLimit)
+	require.NoError(t, err)
+	assert.Len(t, news, config.DefaultNewsPageLimit)
+}
+
+func TestNewsRepository_CountByState(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	items := []*models.News{
+		{Title: "Published", Content: publishableContent, IsPublished: true, PublishedAt: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}},
+		{Title: "Published without date", Content: publishableContent, IsPublished: true},
+		{Title: "Scheduled", Content: publishableContent, IsPublished: true, PublishedAt: sql.NullTime{Time: time.Now().Add(24 * time.Hour), Valid: true}},
+		{Title: "Draft 1", Content: "c"},
+		{Title: "Draft 2", Content: "c"},
+		{Title: "Draft 3", Content: "c"},
+	}
+	for _, n := range items {
+		_, err := repo.Create(ctx, n)
+		require.NoError(t, err)
+	}
+
+	published, drafts, err := repo.CountByState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, 3, drafts)
+
+	// The counts agree with the listing methods
+	publishedNews, err := repo.GetPublished(ctx, MaxPageSize)
+	require.NoError(t, err)
+	assert.Len(t, publishedNews, published)
+	draftNews, err := repo.GetDrafts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, draftNews, drafts)
+}
+
+func TestNewsRepository_GetAdjacent(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	create := func(title string, publishedAgo time.Duration, published bool) int {
+		n, err := repo.Create(ctx, &models.News{
+			Title:       title,
+			Content:     publishableContent,
+			IsPublished: published,
+			PublishedAt: sql.NullTime{Time: time.Now().Add(-publishedAgo), Valid: true},
+		})
+		require.NoError(t, err)
+		return n.ID
+	}
+
+	// Created out of publication order so that IDs do not decide the result
+	middle := create("Middle", 2*time.Hour, true)
+	last := create("Newest", time.Hour, true)
+	first := create("Oldest", 3*time.Hour, true)
+	draft := create("Draft", 90*time.Minute, false)
+
+	t.Run("middle has both neighbours", func(t *testing.T) {
+		prev, next, err := repo.GetAdjacent(ctx, middle)
+		require.NoError(t, err)
+		require.NotNil(t, prev)
+		require.NotNil(t, next)
+		assert.Equal(t, first, prev.ID)
+		assert.Equal(t, last, next.ID)
+	})
+
+	t.Run("oldest has no previous", func(t *testing.T) {
+		prev, next, err := repo.GetAdjacent(ctx, first)
+		require.NoError(t, err)
+		assert.Nil(t, prev)
+		require.NotNil(t, next)
+		assert.Equal(t, middle, next.ID)
+	})
+
+	t.Run("newest has no next", func(t *testing.T) {
+		prev, next, err := repo.GetAdjacent(ctx, last)
+		require.NoError(t, err)
+		require.NotNil(t, prev)
+		assert.Equal(t, middle, prev.ID)
+		assert.Nil(t, next)
+	})
+
+	t.Run("draft is not found", func(t *testing.T) {
+		_, _, err := repo.GetAdjacent(ctx, draft)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestNewsRepository_Publish_ContentCheck(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	t.Cleanup(func() { models.SetNewsMinContentLength(models.DefaultNewsMinContentLength) })
+	models.SetNewsMinContentLength(30)
+
+	create := func(title, content string) int {
+		n, err := repo.Create(ctx, &models.News{Title: title, Content: content})
+		require.NoError(t, err, "drafts may be short")
+		return n.ID
+	}
+
+	t.Run("long enough content is published", func(t *testing.T) {
+		id := create("Grant awarded", "The lab received a three-year research grant.")
+		require.NoError(t, repo.Publish(ctx, id))
+
+		news, err := repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.True(t, news.IsPublished)
+	})
+
+	t.Run("short content is rejected", func(t *testing.T) {
+		id := create("Soon", "  More soon.  ")
+		assert.ErrorIs(t, repo.Publish(ctx, id), ErrInvalidInput)
+
+		news, err := repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.False(t, news.IsPublished, "rejected item stays a draft")
+	})
+
+	t.Run("blank title is rejected", func(t *testing.T) {
+		id := create("   ", "The lab received a three-year research grant.")
+		assert.ErrorIs(t, repo.Publish(ctx, id), ErrInvalidInput)
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Publish(ctx, 99999), ErrNotFound)
+	})
+}
+
+func TestNewsRepository_CreateUpdate_ContentCheck(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	t.Run("create published with short content is rejected", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.News{Title: "Soon", Content: "More soon.", IsPublished: true})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		published, drafts, err := repo.CountByState(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, published+drafts)
+	})
+
+	t.Run("create published with blank title is rejected", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.News{Title: "  ", Content: publishableContent, IsPublished: true})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("update publishing short content is rejected", func(t *testing.T) {
+		draft, err := repo.Create(ctx, &models.News{Title: "Soon", Content: "More soon."})
+		require.NoError(t, err, "drafts may be short")
+
+		draft.IsPublished = true
+		_, err = repo.Update(ctx, draft)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		stored, err := repo.GetByID(ctx, draft.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsPublished, "rejected item stays a draft")
+	})
+
+	t.Run("update publishing long enough content succeeds", func(t *testing.T) {
+		draft, err := repo.Create(ctx, &models.News{Title: "Grant awarded", Content: "More soon."})
+		require.NoError(t, err)
+
+		draft.Content = publishableContent
+		draft.IsPublished = true
+		_, err = repo.Update(ctx, draft)
+		require.NoError(t, err)
+	})
+}
+
+func TestNewsRepository_GetStaleDrafts(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewNewsRepository(dbManager)
+
+	fresh, err := repo.Create(ctx, &models.News{Title: "Fresh draft", Content: "c"})
+	require.NoError(t, err)
+	stale, err := repo.Create(ctx, &models.News{Title: "Stale draft", Content: "c"})
+	require.NoError(t, err)
+	published, err := repo.Create(ctx, &models.News{Title: "Old news", Content: publishableContent, IsPublished: true})
+	require.NoError(t, err)
+
+	// Backdate everything but the fresh draft
+	monthAgo := time.Now().UTC().AddDate(0, -1, 0).Format("2006-01-02 15:04:05")
+	for _, id := range []int{stale.ID, published.ID} {
+		_, err := dbManager.GetDB().Exec(`UPDATE news SET updated_at = $1 WHERE id = $2`, monthAgo, id)
+		require.NoError(t, err)
+	}
+
+	t.Run("returns only drafts past the threshold", func(t *testing.T) {
+		drafts, err := repo.GetStaleDrafts(ctx, 7*24*time.Hour)
+		require.NoError(t, err)
+		require.Len(t, drafts, 1)
+		assert.Equal(t, stale.ID, drafts[0].ID)
+		assert.NotEqual(t, fresh.ID, drafts[0].ID)
+	})
+
+	t.Run("threshold beyond every draft", func(t *testing.T) {
+		drafts, err := repo.GetStaleDrafts(ctx, 365*24*time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, drafts)
+	})
+
+	t.Run("non-positive threshold", func(t *testing.T) {
+		_, err := repo.GetStaleDrafts(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -26,7 +28,7 @@ func NewProjectRepository(dbManager *db.DBManager) *ProjectRepository {
 // GetByID retrieves a project by ID.
 func (r *ProjectRepository) GetByID(ctx context.Context, id int) (*models.Project, error) {
 	query := `
-		SELECT id, title, description, status, created_at, updated_at
+		SELECT id, title, COALESCE(slug, ''), description, status, created_at, updated_at
 		FROM projects
 		WHERE id = $1
 	`
@@ -37,6 +39,7 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id int) (*models.Projec
 	err := row.Scan(
 		&proj.ID,
 		&proj.Title,
+		&proj.Slug,
 		&proj.Description,
 		&proj.Status,
 		&proj.CreatedAt,
@@ -50,10 +53,49 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id int) (*models.Projec
 	return &proj, nil
 }
 
+// GetByIDOrNil retrieves a project by ID, returning (nil, nil) when it does not exist.
+// GetByID keeps returning ErrNotFound for callers that treat a missing row as an error.
+func (r *ProjectRepository) GetByIDOrNil(ctx context.Context, id int) (*models.Project, error) {
+	return orNil(r.GetByID(ctx, id))
+}
+
+// projectBySlugQuery selects a project by slug; it is served by idx_projects_slug
+const projectBySlugQuery = `
+	SELECT id, title, COALESCE(slug, ''), description, status, created_at, updated_at
+	FROM projects
+	WHERE slug = $1
+`
+
+// GetBySlug retrieves a project by its slug. Projects without a slug cannot be found this way.
+func (r *ProjectRepository) GetBySlug(ctx context.Context, slug string) (*models.Project, error) {
+	if slug == "" {
+		return nil, ErrNotFound
+	}
+
+	row := r.GetExecer(ctx).QueryRowContext(ctx, projectBySlugQuery, slug)
+
+	var proj models.Project
+	err := row.Scan(
+		&proj.ID,
+		&proj.Title,
+		&proj.Slug,
+		&proj.Description,
+		&proj.Status,
+		&proj.CreatedAt,
+		&proj.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, WrapError(err, "get project by slug")
+	}
+
+	return &proj, nil
+}
+
 // GetAll retrieves all projects ordered by status and creation date.
 func (r *ProjectRepository) GetAll(ctx context.Context) ([]models.Project, error) {
 	query := `
-		SELECT id, title, description, status, created_at, updated_at
+		SELECT id, title, COALESCE(slug, ''), description, status, created_at, updated_at
 		FROM projects
 		ORDER BY 
 			CASE status WHEN 'active' THEN 0 ELSE 1 END,
@@ -72,6 +114,7 @@ func (r *ProjectRepository) GetAll(ctx context.Context) ([]models.Project, error
 		err := rows.Scan(
 			&proj.ID,
 			&proj.Title,
+			&proj.Slug,
 			&proj.Description,
 			&proj.Status,
 			&proj.CreatedAt,
@@ -90,18 +133,117 @@ func (r *ProjectRepository) GetAll(ctx context.Context) ([]models.Project, error
 	return projects, nil
 }
 
+// ProjectFilter holds the optional filters for GetFiltered. Zero values match any project.
+type ProjectFilter struct {
+	Status models.ProjectStatus
+}
+
+// GetFiltered retrieves projects matching the filter, in the same order as GetAll.
+func (r *ProjectRepository) GetFiltered(ctx context.Context, filter ProjectFilter) ([]models.Project, error) {
+	qb := newQueryBuilder(`
+		SELECT id, title, COALESCE(slug, ''), description, status, created_at, updated_at
+		FROM projects
+	`, "status")
+	if filter.Status != "" {
+		qb.where("status", filter.Status)
+	}
+
+	query, args, err := qb.build(`
+		ORDER BY
+			CASE status WHEN 'active' THEN 0 ELSE 1 END,
+			created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, WrapError(err, "get filtered projects")
+	}
+	defer rows.Close()
+
+	var projects []models.Project
+	for rows.Next() {
+		var proj models.Project
+		err := rows.Scan(
+			&proj.ID,
+			&proj.Title,
+			&proj.Slug,
+			&proj.Description,
+			&proj.Status,
+			&proj.CreatedAt,
+			&proj.UpdatedAt,
+		)
+		if err != nil {
+			return nil, WrapError(err, "scan project")
+		}
+		projects = append(projects, proj)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate filtered projects")
+	}
+
+	return projects, nil
+}
+
+// projectsByStatusQuery selects the projects with one status; it is served by idx_projects_status
+const projectsByStatusQuery = `
+	SELECT id, title, COALESCE(slug, ''), description, status, created_at, updated_at
+	FROM projects
+	WHERE status = $1
+	ORDER BY created_at DESC
+`
+
 // GetByStatus retrieves projects filtered by status.
 func (r *ProjectRepository) GetByStatus(ctx context.Context, status models.ProjectStatus) ([]models.Project, error) {
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, projectsByStatusQuery, status)
+	if err != nil {
+		return nil, WrapError(err, "get projects by status")
+	}
+	defer rows.Close()
+
+	var projects []models.Project
+	for rows.Next() {
+		var proj models.Project
+		err := rows.Scan(
+			&proj.ID,
+			&proj.Title,
+			&proj.Slug,
+			&proj.Description,
+			&proj.Status,
+			&proj.CreatedAt,
+			&proj.UpdatedAt,
+		)
+		if err != nil {
+			return nil, WrapError(err, "scan project")
+		}
+		projects = append(projects, proj)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate projects by status")
+	}
+
+	return projects, nil
+}
+
+// GetByMember retrieves the projects a lab member participates in, in the same order as GetAll.
+func (r *ProjectRepository) GetByMember(ctx context.Context, memberID int) ([]models.Project, error) {
 	query := `
-		SELECT id, title, description, status, created_at, updated_at
-		FROM projects
-		WHERE status = $1
-		ORDER BY created_at DESC
+		SELECT p.id, p.title, COALESCE(p.slug, ''), p.description, p.status, p.created_at, p.updated_at
+		FROM projects p
+		INNER JOIN project_members pm ON p.id = pm.project_id
+		WHERE pm.member_id = $1
+		ORDER BY
+			CASE p.status WHEN 'active' THEN 0 ELSE 1 END,
+			p.created_at DESC
 	`
 
-	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, status)
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, memberID)
 	if err != nil {
-		return nil, WrapError(err, "get projects by status")
+		return nil, WrapError(err, "get projects by member")
 	}
 	defer rows.Close()
 
@@ -111,6 +253,7 @@ func (r *ProjectRepository) GetByStatus(ctx context.Context, status models.Proje
 		err := rows.Scan(
 			&proj.ID,
 			&proj.Title,
+			&proj.Slug,
 			&proj.Description,
 			&proj.Status,
 			&proj.CreatedAt,
@@ -123,7 +266,7 @@ func (r *ProjectRepository) GetByStatus(ctx context.Context, status models.Proje
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate projects by status")
+		return nil, WrapError(err, "iterate projects by member")
 	}
 
 	return projects, nil
@@ -132,22 +275,28 @@ func (r *ProjectRepository) GetByStatus(ctx context.Context, status models.Proje
 // Create inserts a new project.
 func (r *ProjectRepository) Create(ctx context.Context, proj *models.Project) (*models.Project, error) {
 	query := `
-		INSERT INTO projects (title, description, status, created_at, updated_at)
-		VALUES ($1, $2, $3, datetime('now'), datetime('now'))
+		INSERT INTO projects (title, slug, description, status, created_at, updated_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, datetime('now'), datetime('now'))
 		RETURNING id, created_at, updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		proj.Title,
-		proj.Description,
-		proj.Status,
-	)
+	err := r.withAudit(ctx, models.AuditActionCreate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			proj.Title,
+			proj.Slug,
+			proj.Description,
+			proj.Status,
+		)
 
-	err := row.Scan(&proj.ID, &proj.CreatedAt, &proj.UpdatedAt)
+		if err := row.Scan(&proj.ID, &proj.CreatedAt, &proj.UpdatedAt); err != nil {
+			return 0, WrapError(err, "create project")
+		}
+		return proj.ID, nil
+	})
 	if err != nil {
-		return nil, WrapError(err, "create project")
+		return nil, err
 	}
 
 	return proj, nil
@@ -157,41 +306,108 @@ func (r *ProjectRepository) Create(ctx context.Context, proj *models.Project) (*
 func (r *ProjectRepository) Update(ctx context.Context, proj *models.Project) (*models.Project, error) {
 	query := `
 		UPDATE projects
-		SET title = $1, description = $2, status = $3, updated_at = datetime('now')
-		WHERE id = $4
+		SET title = $1, slug = NULLIF($2, ''), description = $3, status = $4, updated_at = datetime('now')
+		WHERE id = $5
 		RETURNING updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		proj.Title,
-		proj.Description,
-		proj.Status,
-		proj.ID,
-	)
+	err := r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			proj.Title,
+			proj.Slug,
+			proj.Description,
+			proj.Status,
+			proj.ID,
+		)
 
-	err := row.Scan(&proj.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+		if err := row.Scan(&proj.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update project")
 		}
-		return nil, WrapError(err, "update project")
+		return proj.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return proj, nil
 }
 
-// Delete removes a project.
-func (r *ProjectRepository) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM projects WHERE id = $1`
+// projectTransitions lists the statuses each project status may move to.
+var projectTransitions = map[models.ProjectStatus][]models.ProjectStatus{
+	models.ProjectStatusActive:    {models.ProjectStatusCompleted},
+	models.ProjectStatusCompleted: {models.ProjectStatusActive},
+}
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+// Transition moves a project to a new status. It returns ErrNotFound for a missing
+// project and ErrInvalidInput when the move is not allowed, including a transition
+// to the status the project already has. ErrConflict is returned if the status was
+// changed by someone else while the transition was being made.
+func (r *ProjectRepository) Transition(ctx context.Context, id int, to models.ProjectStatus) error {
+	proj, err := r.GetByID(ctx, id)
 	if err != nil {
-		return WrapError(err, "delete project")
+		return err
+	}
+
+	allowed := false
+	for _, status := range projectTransitions[proj.Status] {
+		if status == to {
+			allowed = true
+			break
+		}
 	}
+	if !allowed {
+		return fmt.Errorf("%w: cannot transition project from %q to %q", ErrInvalidInput, proj.Status, to)
+	}
+
+	return r.updateStatus(ctx, id, proj.Status, to)
+}
+
+// updateStatus sets a project's status to to, provided it is still from. When no row
+// matches it reports ErrNotFound if the project is gone and ErrConflict otherwise.
+func (r *ProjectRepository) updateStatus(ctx context.Context, id int, from, to models.ProjectStatus) error {
+	query := `
+		UPDATE projects
+		SET status = $1, updated_at = datetime('now')
+		WHERE id = $2 AND status = $3
+	`
+
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, to, id, from)
+		if err != nil {
+			return 0, WrapError(err, "transition project")
+		}
+		if err := CheckRowsAffected(result, 1); !errors.Is(err, ErrNotFound) {
+			return id, err
+		}
+
+		var exists bool
+		err = r.GetExecer(ctx).QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1)`, id).Scan(&exists)
+		if err != nil {
+			return 0, WrapError(err, "check project exists")
+		}
+		if !exists {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("%w: project status is no longer %q", ErrConflict, from)
+	})
+}
+
+// Delete removes a project.
+func (r *ProjectRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM projects WHERE id = $1`
 
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityProject, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "delete project")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
 }
 
 // LinkMember associates a lab member with a project.
@@ -202,24 +418,26 @@ func (r *ProjectRepository) LinkMember(ctx context.Context, projectID, memberID
 		ON CONFLICT (project_id, member_id) DO NOTHING
 	`
 
-	_, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, memberID)
-	if err != nil {
-		return WrapError(err, "link member to project")
-	}
-
-	return nil
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		_, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, memberID)
+		if err != nil {
+			return 0, WrapError(err, "link member to project")
+		}
+		return projectID, nil
+	})
 }
 
 // UnlinkMember removes the association between a lab member and a project.
 func (r *ProjectRepository) UnlinkMember(ctx context.Context, projectID, memberID int) error {
 	query := `DELETE FROM project_members WHERE project_id = $1 AND member_id = $2`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, memberID)
-	if err != nil {
-		return WrapError(err, "unlink member from project")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, memberID)
+		if err != nil {
+			return 0, WrapError(err, "unlink member from project")
+		}
+		return projectID, CheckRowsAffected(result, 1)
+	})
 }
 
 // LinkPublication associates a publication with a project.
@@ -230,9 +448,50 @@ func (r *ProjectRepository) LinkPublication(ctx context.Context, projectID, publ
 		ON CONFLICT (project_id, publication_id) DO NOTHING
 	`
 
-	_, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, publicationID)
-	if err != nil {
-		return WrapError(err, "link publication to project")
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		_, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, publicationID)
+		if err != nil {
+			return 0, WrapError(err, "link publication to project")
+		}
+		return projectID, nil
+	})
+}
+
+// LinkPublications associates several existing publications with a project in one
+// transaction, so either all of them are linked or none is. Publications that are already
+// linked, or listed twice, are skipped.
+func (r *ProjectRepository) LinkPublications(ctx context.Context, projectID int, publicationIDs []int) error {
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(txCtx context.Context) (int, error) {
+		return projectID, r.insertPublicationLinks(txCtx, projectID, publicationIDs)
+	})
+}
+
+// SetPublications replaces the publications linked to a project with publicationIDs.
+// It runs in a transaction, so on error the previous set is kept. An empty list unlinks
+// every publication.
+func (r *ProjectRepository) SetPublications(ctx context.Context, projectID int, publicationIDs []int) error {
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(txCtx context.Context) (int, error) {
+		_, err := r.GetExecer(txCtx).ExecContext(txCtx,
+			`DELETE FROM project_publications WHERE project_id = $1`, projectID)
+		if err != nil {
+			return 0, WrapError(err, "clear project publications")
+		}
+
+		return projectID, r.insertPublicationLinks(txCtx, projectID, publicationIDs)
+	})
+}
+
+// insertPublicationLinks links each publication to the project; callers run it inside a transaction
+func (r *ProjectRepository) insertPublicationLinks(ctx context.Context, projectID int, publicationIDs []int) error {
+	query := `
+		INSERT INTO project_publications (project_id, publication_id)
+		VALUES ($1, $2)
+		ON CONFLICT (project_id, publication_id) DO NOTHING
+	`
+	for _, publicationID := range publicationIDs {
+		if _, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, publicationID); err != nil {
+			return WrapError(err, "link publications to project")
+		}
 	}
 
 	return nil
@@ -242,12 +501,13 @@ func (r *ProjectRepository) LinkPublication(ctx context.Context, projectID, publ
 func (r *ProjectRepository) UnlinkPublication(ctx context.Context, projectID, publicationID int) error {
 	query := `DELETE FROM project_publications WHERE project_id = $1 AND publication_id = $2`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, publicationID)
-	if err != nil {
-		return WrapError(err, "unlink publication from project")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityProject, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, projectID, publicationID)
+		if err != nil {
+			return 0, WrapError(err, "unlink publication from project")
+		}
+		return projectID, CheckRowsAffected(result, 1)
+	})
 }
 
 // GetMembers retrieves all members associated with a project.
@@ -268,34 +528,7 @@ func (r *ProjectRepository) GetMembers(ctx context.Context, projectID int) ([]mo
 	}
 	defer rows.Close()
 
-	var members []models.LabMember
-	for rows.Next() {
-		var m models.LabMember
-		err := rows.Scan(
-			&m.ID,
-			&m.Name,
-			&m.Role,
-			&m.Email,
-			&m.Bio,
-			&m.PhotoURL,
-			&m.PersonalPageContent,
-			&m.ResearchInterests,
-			&m.IsAlumni,
-			&m.DisplayOrder,
-			&m.CreatedAt,
-			&m.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan project member")
-		}
-		members = append(members, m)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate project members")
-	}
-
-	return members, nil
+	return scanLabMembers(rows, "project members")
 }
 
 // GetPublications retrieves all publications associated with a project.
@@ -314,30 +547,33 @@ func (r *ProjectRepository) GetPublications(ctx context.Context, projectID int)
 	}
 	defer rows.Close()
 
-	var pubs []models.Publication
-	for rows.Next() {
-		var p models.Publication
-		err := rows.Scan(
-			&p.ID,
-			&p.Title,
-			&p.AuthorsText,
-			&p.Venue,
-			&p.Year,
-			&p.URL,
-			&p.CreatedAt,
-			&p.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan project publication")
-		}
-		pubs = append(pubs, p)
+	return scanPublications(rows, "project publications")
+}
+
+// CountMembers returns how many members are linked to a project without loading them,
+// for project cards. A project without members, or one that does not exist, counts 0.
+func (r *ProjectRepository) CountMembers(ctx context.Context, projectID int) (int, error) {
+	query := `SELECT COUNT(*) FROM project_members WHERE project_id = $1`
+
+	var count int
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, query, projectID).Scan(&count); err != nil {
+		return 0, WrapError(err, "count project members")
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate project publications")
+	return count, nil
+}
+
+// CountPublications returns how many publications are linked to a project without
+// loading them. A project without publications, or one that does not exist, counts 0.
+func (r *ProjectRepository) CountPublications(ctx context.Context, projectID int) (int, error) {
+	query := `SELECT COUNT(*) FROM project_publications WHERE project_id = $1`
+
+	var count int
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, query, projectID).Scan(&count); err != nil {
+		return 0, WrapError(err, "count project publications")
 	}
 
-	return pubs, nil
+	return count, nil
 }
 
 // GetWithRelations retrieves a project with its members and publications.
@@ -363,3 +599,13 @@ func (r *ProjectRepository) GetWithRelations(ctx context.Context, id int) (*mode
 		Publications: publications,
 	}, nil
 }
+
+// GetBySlugWithRelations retrieves a project by slug with its members and publications.
+func (r *ProjectRepository) GetBySlugWithRelations(ctx context.Context, slug string) (*models.ProjectWithRelations, error) {
+	proj, err := r.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetWithRelations(ctx, proj.ID)
+}
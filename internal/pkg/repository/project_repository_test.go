@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -241,3 +242,289 @@ func TestProjectRepository_Links(t *testing.T) {
 		assert.Len(t, projWithRels.Publications, 1)
 	})
 }
+
+func TestProjectRepository_GetByIDOrNil(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewProjectRepository(dbManager)
+
+	created, err := repo.Create(ctx, &models.Project{
+		Title:       "Existing Project",
+		Description: "Description",
+		Status:      models.ProjectStatusActive,
+	})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		proj, err := repo.GetByIDOrNil(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, proj)
+		assert.Equal(t, "Existing Project", proj.Title)
+	})
+
+	t.Run("missing returns nil without error", func(t *testing.T) {
+		proj, err := repo.GetByIDOrNil(ctx, 99999)
+		require.NoError(t, err)
+		assert.Nil(t, proj)
+	})
+
+	t.Run("GetByID still reports ErrNotFound", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, 99999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestProjectRepository_Transition(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewProjectRepository(dbManager)
+
+	created, err := repo.Create(ctx, &models.Project{
+		Title:       "Transition Project",
+		Description: "Description",
+		Status:      models.ProjectStatusActive,
+	})
+	require.NoError(t, err)
+
+	t.Run("active to completed and back", func(t *testing.T) {
+		require.NoError(t, repo.Transition(ctx, created.ID, models.ProjectStatusCompleted))
+
+		proj, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProjectStatusCompleted, proj.Status)
+
+		require.NoError(t, repo.Transition(ctx, created.ID, models.ProjectStatusActive))
+
+		proj, err = repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProjectStatusActive, proj.Status)
+	})
+
+	t.Run("same status is rejected", func(t *testing.T) {
+		err := repo.Transition(ctx, created.ID, models.ProjectStatusActive)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("unknown status is rejected", func(t *testing.T) {
+		err := repo.Transition(ctx, created.ID, models.ProjectStatus("archived"))
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("missing project", func(t *testing.T) {
+		err := repo.Transition(ctx, 99999, models.ProjectStatusCompleted)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("status changed concurrently", func(t *testing.T) {
+		current, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		stale := models.ProjectStatusActive
+		if current.Status == stale {
+			stale = models.ProjectStatusCompleted
+		}
+
+		// The status read by Transition no longer matches the stored one
+		err = repo.updateStatus(ctx, created.ID, stale, current.Status)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.NotErrorIs(t, err, ErrNotFound)
+
+		unchanged, err := repo.GetByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, current.Status, unchanged.Status)
+	})
+
+	t.Run("project deleted concurrently", func(t *testing.T) {
+		err := repo.updateStatus(ctx, 99999, models.ProjectStatusActive, models.ProjectStatusCompleted)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestProjectRepository_GetBySlugWithRelations(t *testing.T) {
+	dbManager := setupTestDB(t)
+	projRepo := NewProjectRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+
+	proj, err := projRepo.Create(ctx, &models.Project{
+		Title:       "Graph Learning",
+		Slug:        "graph-learning",
+		Description: "Learning on graphs",
+		Status:      models.ProjectStatusActive,
+	})
+	require.NoError(t, err)
+
+	member, err := memberRepo.Create(ctx, &models.LabMember{Name: "Alice", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+	require.NoError(t, projRepo.LinkMember(ctx, proj.ID, member.ID))
+
+	pub, err := pubRepo.Create(ctx, &models.Publication{Title: "Graphs", AuthorsText: "Alice", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, projRepo.LinkPublication(ctx, proj.ID, pub.ID))
+
+	t.Run("found with relations", func(t *testing.T) {
+		got, err := projRepo.GetBySlugWithRelations(ctx, "graph-learning")
+		require.NoError(t, err)
+		assert.Equal(t, proj.ID, got.ID)
+		assert.Equal(t, "graph-learning", got.Slug)
+		require.Len(t, got.Members, 1)
+		assert.Equal(t, member.ID, got.Members[0].ID)
+		require.Len(t, got.Publications, 1)
+		assert.Equal(t, pub.ID, got.Publications[0].ID)
+	})
+
+	t.Run("unknown slug", func(t *testing.T) {
+		_, err := projRepo.GetBySlugWithRelations(ctx, "no-such-project")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("duplicate slug is rejected", func(t *testing.T) {
+		_, err := projRepo.Create(ctx, &models.Project{
+			Title:       "Other",
+			Slug:        "graph-learning",
+			Description: "Same slug",
+			Status:      models.ProjectStatusActive,
+		})
+		assert.ErrorIs(t, err, ErrDuplicate)
+	})
+}
+
+func TestProjectRepository_GetByMember(t *testing.T) {
+	dbManager := setupTestDB(t)
+	projRepo := NewProjectRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+
+	member, err := memberRepo.Create(ctx, &models.LabMember{Name: "Alice", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+	idle, err := memberRepo.Create(ctx, &models.LabMember{Name: "Bob", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+
+	completed, err := projRepo.Create(ctx, &models.Project{Title: "Done", Description: "d", Status: models.ProjectStatusCompleted})
+	require.NoError(t, err)
+	active, err := projRepo.Create(ctx, &models.Project{Title: "Ongoing", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+	_, err = projRepo.Create(ctx, &models.Project{Title: "Unrelated", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+
+	require.NoError(t, projRepo.LinkMember(ctx, completed.ID, member.ID))
+	require.NoError(t, projRepo.LinkMember(ctx, active.ID, member.ID))
+
+	t.Run("member with projects", func(t *testing.T) {
+		projects, err := projRepo.GetByMember(ctx, member.ID)
+		require.NoError(t, err)
+		require.Len(t, projects, 2)
+		assert.Equal(t, active.ID, projects[0].ID, "active projects come first")
+		assert.Equal(t, completed.ID, projects[1].ID)
+	})
+
+	t.Run("member without projects", func(t *testing.T) {
+		projects, err := projRepo.GetByMember(ctx, idle.ID)
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+}
+
+func TestProjectRepository_CountLinks(t *testing.T) {
+	dbManager := setupTestDB(t)
+	projRepo := NewProjectRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+
+	busy, err := projRepo.Create(ctx, &models.Project{Title: "Busy", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+	empty, err := projRepo.Create(ctx, &models.Project{Title: "Empty", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		member, err := memberRepo.Create(ctx, &models.LabMember{Name: name, Role: models.LabMemberRolePhD})
+		require.NoError(t, err)
+		require.NoError(t, projRepo.LinkMember(ctx, busy.ID, member.ID))
+	}
+	for _, title := range []string{"Paper A", "Paper B"} {
+		pub, err := pubRepo.Create(ctx, &models.Publication{Title: title, AuthorsText: "Alice", Year: 2024})
+		require.NoError(t, err)
+		require.NoError(t, projRepo.LinkPublication(ctx, busy.ID, pub.ID))
+	}
+
+	t.Run("linked project", func(t *testing.T) {
+		members, err := projRepo.CountMembers(ctx, busy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, members)
+
+		pubs, err := projRepo.CountPublications(ctx, busy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, pubs)
+	})
+
+	t.Run("project without links", func(t *testing.T) {
+		members, err := projRepo.CountMembers(ctx, empty.ID)
+		require.NoError(t, err)
+		assert.Zero(t, members)
+
+		pubs, err := projRepo.CountPublications(ctx, empty.ID)
+		require.NoError(t, err)
+		assert.Zero(t, pubs)
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		members, err := projRepo.CountMembers(ctx, 99999)
+		require.NoError(t, err)
+		assert.Zero(t, members)
+
+		pubs, err := projRepo.CountPublications(ctx, 99999)
+		require.NoError(t, err)
+		assert.Zero(t, pubs)
+	})
+}
+
+func TestProjectRepository_SetPublications(t *testing.T) {
+	dbManager := setupTestDB(t)
+	projRepo := NewProjectRepository(dbManager)
+	pubRepo := NewPublicationRepository(dbManager)
+
+	proj, err := projRepo.Create(ctx, &models.Project{Title: "Survey", Description: "d", Status: models.ProjectStatusActive})
+	require.NoError(t, err)
+
+	ids := make([]int, 4)
+	for i := range ids {
+		pub, err := pubRepo.Create(ctx, &models.Publication{Title: fmt.Sprintf("Paper %d", i), AuthorsText: "Alice", Year: 2020 + i})
+		require.NoError(t, err)
+		ids[i] = pub.ID
+	}
+
+	linked := func() []int {
+		pubs, err := projRepo.GetPublications(ctx, proj.ID)
+		require.NoError(t, err)
+		got := make([]int, len(pubs))
+		for i, p := range pubs {
+			got[i] = p.ID
+		}
+		return got
+	}
+
+	t.Run("set links the list", func(t *testing.T) {
+		require.NoError(t, projRepo.SetPublications(ctx, proj.ID, []int{ids[0], ids[1]}))
+		assert.ElementsMatch(t, []int{ids[0], ids[1]}, linked())
+	})
+
+	t.Run("set replaces the previous list", func(t *testing.T) {
+		require.NoError(t, projRepo.SetPublications(ctx, proj.ID, []int{ids[1], ids[2], ids[3]}))
+		assert.ElementsMatch(t, []int{ids[1], ids[2], ids[3]}, linked())
+	})
+
+	t.Run("link adds to the existing list", func(t *testing.T) {
+		require.NoError(t, projRepo.SetPublications(ctx, proj.ID, []int{ids[0]}))
+		require.NoError(t, projRepo.LinkPublications(ctx, proj.ID, []int{ids[0], ids[2], ids[2]}))
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, linked())
+	})
+
+	t.Run("unknown publication rolls back", func(t *testing.T) {
+		assert.Error(t, projRepo.SetPublications(ctx, proj.ID, []int{ids[3], 99999}))
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, linked())
+
+		assert.Error(t, projRepo.LinkPublications(ctx, proj.ID, []int{ids[3], 99999}))
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, linked())
+	})
+
+	t.Run("empty set unlinks everything", func(t *testing.T) {
+		require.NoError(t, projRepo.SetPublications(ctx, proj.ID, nil))
+		assert.Empty(t, linked())
+	})
+}
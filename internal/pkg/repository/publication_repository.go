@@ -3,7 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/nekoteoj/lab-cms/internal/pkg/bibtex"
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 )
@@ -33,18 +38,7 @@ func (r *PublicationRepository) GetByID(ctx context.Context, id int) (*models.Pu
 
 	row := r.GetExecer(ctx).QueryRowContext(ctx, query, id)
 
-	var pub models.Publication
-	err := row.Scan(
-		&pub.ID,
-		&pub.Title,
-		&pub.AuthorsText,
-		&pub.Venue,
-		&pub.Year,
-		&pub.URL,
-		&pub.CreatedAt,
-		&pub.UpdatedAt,
-	)
-
+	pub, err := scanPublication(row)
 	if err != nil {
 		return nil, WrapError(err, "get publication by id")
 	}
@@ -52,6 +46,12 @@ func (r *PublicationRepository) GetByID(ctx context.Context, id int) (*models.Pu
 	return &pub, nil
 }
 
+// GetByIDOrNil retrieves a publication by ID, returning (nil, nil) when it does not exist.
+// GetByID keeps returning ErrNotFound for callers that treat a missing row as an error.
+func (r *PublicationRepository) GetByIDOrNil(ctx context.Context, id int) (*models.Publication, error) {
+	return orNil(r.GetByID(ctx, id))
+}
+
 // GetAll retrieves all publications ordered by year (newest first).
 func (r *PublicationRepository) GetAll(ctx context.Context) ([]models.Publication, error) {
 	query := `
@@ -66,71 +66,185 @@ func (r *PublicationRepository) GetAll(ctx context.Context) ([]models.Publicatio
 	}
 	defer rows.Close()
 
-	var pubs []models.Publication
-	for rows.Next() {
-		var pub models.Publication
-		err := rows.Scan(
-			&pub.ID,
-			&pub.Title,
-			&pub.AuthorsText,
-			&pub.Venue,
-			&pub.Year,
-			&pub.URL,
-			&pub.CreatedAt,
-			&pub.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan publication")
-		}
-		pubs = append(pubs, pub)
+	return scanPublications(rows, "publications")
+}
+
+// PublicationFilter holds the optional filters for GetFiltered. Zero values match any publication.
+type PublicationFilter struct {
+	Year int
+	// SortBy selects the ordering; see publicationSortOrders. Empty means newest first.
+	SortBy string
+}
+
+// publicationSortOrders whitelists the sort keys accepted by GetFiltered so that
+// user input never ends up in the ORDER BY clause.
+var publicationSortOrders = map[string]string{
+	"":       "ORDER BY year DESC, created_at DESC",
+	"year":   "ORDER BY year DESC, created_at DESC",
+	"author": "ORDER BY authors_text COLLATE NOCASE ASC, year DESC, id ASC",
+}
+
+// GetFiltered retrieves publications matching the filter, newest first unless
+// filter.SortBy asks otherwise. An unknown sort key returns ErrInvalidInput.
+func (r *PublicationRepository) GetFiltered(ctx context.Context, filter PublicationFilter) ([]models.Publication, error) {
+	orderBy, ok := publicationSortOrders[filter.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort publications by %q", ErrInvalidInput, filter.SortBy)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate publications")
+	qb := newQueryBuilder(`
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+	`, "year")
+	if filter.Year != 0 {
+		qb.where("year", filter.Year)
+	}
+
+	query, args, err := qb.build(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, WrapError(err, "get filtered publications")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "filtered publications")
+}
+
+// GetRecent retrieves the most recently added publications regardless of publication year,
+// so that older papers entered today still surface on the homepage. A non-positive limit
+// returns ErrInvalidInput and a limit above MaxPageSize is clamped.
+func (r *PublicationRepository) GetRecent(ctx context.Context, limit int) ([]models.Publication, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, WrapError(err, "get recent publications")
 	}
+	defer rows.Close()
 
-	return pubs, nil
+	return scanPublications(rows, "recent publications")
 }
 
+// publicationsByYearQuery selects the publications of one year; it is served by
+// idx_publications_year_created
+const publicationsByYearQuery = `
+	SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+	FROM publications
+	WHERE year = $1
+	ORDER BY created_at DESC
+`
+
 // GetByYear retrieves publications for a specific year.
 func (r *PublicationRepository) GetByYear(ctx context.Context, year int) ([]models.Publication, error) {
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, publicationsByYearQuery, year)
+	if err != nil {
+		return nil, WrapError(err, "get publications by year")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "publications by year")
+}
+
+// YearGroup is one heading of the publications timeline: a year and the
+// publications published in it.
+type YearGroup struct {
+	Year         int                  `json:"year"`
+	Publications []models.Publication `json:"publications"`
+}
+
+// GetGroupedByYear retrieves all publications grouped by year for the timeline page.
+// Groups are ordered newest year first; within a year publications are ordered by
+// created_at descending, matching GetByYear.
+func (r *PublicationRepository) GetGroupedByYear(ctx context.Context) ([]YearGroup, error) {
 	query := `
 		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
 		FROM publications
-		WHERE year = $1
-		ORDER BY created_at DESC
+		ORDER BY year DESC, created_at DESC, id DESC
 	`
 
-	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, year)
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
 	if err != nil {
-		return nil, WrapError(err, "get publications by year")
+		return nil, WrapError(err, "get publications grouped by year")
 	}
 	defer rows.Close()
 
-	var pubs []models.Publication
-	for rows.Next() {
-		var pub models.Publication
-		err := rows.Scan(
-			&pub.ID,
-			&pub.Title,
-			&pub.AuthorsText,
-			&pub.Venue,
-			&pub.Year,
-			&pub.URL,
-			&pub.CreatedAt,
-			&pub.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan publication")
+	pubs, err := scanPublications(rows, "publications grouped by year")
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []YearGroup{}
+	for _, pub := range pubs {
+		if n := len(groups); n == 0 || groups[n-1].Year != pub.Year {
+			groups = append(groups, YearGroup{Year: pub.Year})
 		}
-		pubs = append(pubs, pub)
+		last := &groups[len(groups)-1]
+		last.Publications = append(last.Publications, pub)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate publications by year")
+	return groups, nil
+}
+
+// GetAdjacentByYear retrieves the publications immediately before and after the given one
+// in the year DESC, created_at DESC ordering used by the timeline, for previous/next
+// navigation. Ties are broken by id so the order is stable. prev or next is nil at either
+// end of the list; ErrNotFound is returned if the publication does not exist.
+func (r *PublicationRepository) GetAdjacentByYear(ctx context.Context, id int) (prev, next *models.Publication, err error) {
+	query := `
+		WITH ordered AS (
+			SELECT id, title, authors_text, venue, year, url, created_at, updated_at,
+				ROW_NUMBER() OVER (ORDER BY year DESC, created_at DESC, id DESC) AS pos
+			FROM publications
+		),
+		target AS (
+			SELECT pos FROM ordered WHERE id = $1
+		)
+		SELECT o.id, o.title, o.authors_text, o.venue, o.year, o.url, o.created_at, o.updated_at
+		FROM ordered o, target t
+		WHERE o.pos BETWEEN t.pos - 1 AND t.pos + 1
+		ORDER BY o.pos
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, nil, WrapError(err, "get adjacent publications")
+	}
+	defer rows.Close()
+
+	pubs, err := scanPublications(rows, "adjacent publications")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// pubs holds the target with its neighbours, in timeline order
+	for i := range pubs {
+		if pubs[i].ID != id {
+			continue
+		}
+		if i > 0 {
+			prev = &pubs[i-1]
+		}
+		if i+1 < len(pubs) {
+			next = &pubs[i+1]
+		}
+		return prev, next, nil
 	}
 
-	return pubs, nil
+	return nil, nil, ErrNotFound
 }
 
 // GetByMember retrieves publications associated with a lab member.
@@ -149,60 +263,311 @@ func (r *PublicationRepository) GetByMember(ctx context.Context, memberID int) (
 	}
 	defer rows.Close()
 
-	var pubs []models.Publication
-	for rows.Next() {
-		var pub models.Publication
-		err := rows.Scan(
-			&pub.ID,
-			&pub.Title,
-			&pub.AuthorsText,
-			&pub.Venue,
-			&pub.Year,
-			&pub.URL,
-			&pub.CreatedAt,
-			&pub.UpdatedAt,
+	return scanPublications(rows, "publications by member")
+}
+
+// GetByMembers retrieves the publications authored by any of the given members, each
+// listed once even when several of the members co-authored it, newest year first.
+// An empty memberIDs returns no publications.
+func (r *PublicationRepository) GetByMembers(ctx context.Context, memberIDs []int) ([]models.Publication, error) {
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(memberIDs))
+	args := make([]interface{}, len(memberIDs))
+	for i, id := range memberIDs {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id
+	}
+
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		WHERE id IN (
+			SELECT publication_id
+			FROM publication_authors
+			WHERE member_id IN (` + strings.Join(placeholders, ", ") + `)
 		)
-		if err != nil {
-			return nil, WrapError(err, "scan publication")
+		ORDER BY year DESC, created_at DESC, id DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, WrapError(err, "get publications by members")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "publications by members")
+}
+
+// GetCreatedBetween retrieves publications added to the CMS within [from, to), oldest
+// first, e.g. for "publications added in 2024" in an annual report. It filters on
+// created_at rather than the publication year. Returns ErrInvalidInput unless to is
+// after from.
+func (r *PublicationRepository) GetCreatedBetween(ctx context.Context, from, to time.Time) ([]models.Publication, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: window end %s is not after its start %s", ErrInvalidInput, to, from)
+	}
+
+	// created_at holds UTC text from datetime('now'); datetime() also normalizes any row
+	// written in another layout before the string comparison
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		WHERE datetime(created_at) >= $1 AND datetime(created_at) < $2
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query,
+		from.UTC().Format(sqliteDateTime), to.UTC().Format(sqliteDateTime))
+	if err != nil {
+		return nil, WrapError(err, "get publications created between")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "publications created between")
+}
+
+// CountByVenue returns the number of publications per venue.
+// Publications without a venue are not counted.
+func (r *PublicationRepository) CountByVenue(ctx context.Context) (map[string]int, error) {
+	query := `
+		SELECT venue, COUNT(*)
+		FROM publications
+		WHERE venue IS NOT NULL
+		GROUP BY venue
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "count publications by venue")
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var venue string
+		var count int
+		if err := rows.Scan(&venue, &count); err != nil {
+			return nil, WrapError(err, "scan publication venue count")
 		}
-		pubs = append(pubs, pub)
+		counts[venue] = count
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate publications by member")
+		return nil, WrapError(err, "iterate publication venue counts")
 	}
 
-	return pubs, nil
+	return counts, nil
+}
+
+// YearBounds returns the earliest and latest publication years, e.g. for a
+// "publications since YEAR" banner. ErrNotFound is returned when there are no publications.
+func (r *PublicationRepository) YearBounds(ctx context.Context) (min, max int, err error) {
+	query := `SELECT MIN(year), MAX(year) FROM publications`
+
+	var minYear, maxYear sql.NullInt64
+	if err := r.GetExecer(ctx).QueryRowContext(ctx, query).Scan(&minYear, &maxYear); err != nil {
+		return 0, 0, WrapError(err, "get publication year bounds")
+	}
+	if !minYear.Valid || !maxYear.Valid {
+		return 0, 0, ErrNotFound
+	}
+
+	return int(minYear.Int64), int(maxYear.Int64), nil
+}
+
+// Search retrieves publications whose title, authors or venue contain the term,
+// ignoring case. Results are ranked by where the term matched: a title starting
+// with the term first, then other title matches, then author and venue matches.
+// Publications with the same rank are ordered newest first. At most limit results are
+// returned; limit must be positive and is capped at MaxPageSize.
+func (r *PublicationRepository) Search(ctx context.Context, term string, limit int) ([]models.Publication, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return nil, nil
+	}
+
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		WHERE title LIKE $1 ESCAPE '\' OR authors_text LIKE $1 ESCAPE '\' OR venue LIKE $1 ESCAPE '\'
+		ORDER BY
+			CASE
+				WHEN title LIKE $2 ESCAPE '\' THEN 0
+				WHEN title LIKE $1 ESCAPE '\' THEN 1
+				WHEN authors_text LIKE $1 ESCAPE '\' THEN 2
+				ELSE 3
+			END,
+			year DESC, id DESC
+		LIMIT $3
+	`
+
+	escaped := escapeLike(term)
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, "%"+escaped+"%", escaped+"%", limit)
+	if err != nil {
+		return nil, WrapError(err, "search publications")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "publication search results")
+}
+
+// GetWithoutLinkedAuthors retrieves publications that have no lab member linked as an
+// author, newest first. These typically come from imports that only set authors_text.
+func (r *PublicationRepository) GetWithoutLinkedAuthors(ctx context.Context) ([]models.Publication, error) {
+	query := `
+		SELECT p.id, p.title, p.authors_text, p.venue, p.year, p.url, p.created_at, p.updated_at
+		FROM publications p
+		LEFT JOIN publication_authors pa ON p.id = pa.publication_id
+		WHERE pa.publication_id IS NULL
+		ORDER BY p.year DESC, p.created_at DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "get publications without linked authors")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "publications without linked authors")
+}
+
+// GetIncomplete retrieves publications missing a URL or a venue, newest first, for
+// data-quality cleanup. Blank values count as missing.
+func (r *PublicationRepository) GetIncomplete(ctx context.Context) ([]models.Publication, error) {
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		WHERE url IS NULL OR trim(url) = ''
+		   OR venue IS NULL OR trim(venue) = ''
+		ORDER BY year DESC, created_at DESC, id DESC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query)
+	if err != nil {
+		return nil, WrapError(err, "get incomplete publications")
+	}
+	defer rows.Close()
+
+	return scanPublications(rows, "incomplete publications")
+}
+
+// FindPossibleDuplicates retrieves publications from the same year whose title matches
+// the given title after normalization (case, punctuation and whitespace are ignored).
+// Importers use it to warn before creating a publication that already exists.
+func (r *PublicationRepository) FindPossibleDuplicates(ctx context.Context, title string, year int) ([]models.Publication, error) {
+	normalized := normalizeTitle(title)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	candidates, err := r.GetByYear(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []models.Publication
+	for _, pub := range candidates {
+		if normalizeTitle(pub.Title) == normalized {
+			duplicates = append(duplicates, pub)
+		}
+	}
+
+	return duplicates, nil
 }
 
 // Create inserts a new publication.
 func (r *PublicationRepository) Create(ctx context.Context, pub *models.Publication) (*models.Publication, error) {
+	if err := checkAuthorsText(pub.AuthorsText); err != nil {
+		return nil, err
+	}
+
 	query := `
 		INSERT INTO publications (title, authors_text, venue, year, url, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, datetime('now'), datetime('now'))
 		RETURNING id, created_at, updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		pub.Title,
-		pub.AuthorsText,
-		pub.Venue,
-		pub.Year,
-		pub.URL,
-	)
-
-	err := row.Scan(&pub.ID, &pub.CreatedAt, &pub.UpdatedAt)
+	err := r.withAudit(ctx, models.AuditActionCreate, AuditEntityPublication, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			pub.Title,
+			pub.AuthorsText,
+			pub.Venue,
+			pub.Year,
+			pub.URL,
+		)
+
+		if err := row.Scan(&pub.ID, &pub.CreatedAt, &pub.UpdatedAt); err != nil {
+			return 0, WrapError(err, "create publication")
+		}
+		return pub.ID, nil
+	})
 	if err != nil {
-		return nil, WrapError(err, "create publication")
+		return nil, err
 	}
 
 	return pub, nil
 }
 
+// BatchResult summarizes a bulk import: the IDs of the publications that were created
+// and the entries that were rejected.
+type BatchResult struct {
+	ImportedIDs []int        `json:"imported_ids"`
+	Errors      []BatchError `json:"errors"`
+}
+
+// BatchError describes why a single entry of a bulk import was rejected.
+type BatchError struct {
+	Index   int    `json:"index"`         // Position of the entry in the file, starting at 1
+	Key     string `json:"key,omitempty"` // Citation key, if it could be read
+	Message string `json:"message"`
+}
+
+// ImportBibTeXBatch creates a publication for every valid entry in raw BibTeX.
+// Each entry is committed on its own, so malformed or invalid entries are reported
+// in the result without preventing the others from being imported.
+// An error is returned only when the database fails; the result then holds the
+// entries processed so far.
+func (r *PublicationRepository) ImportBibTeXBatch(ctx context.Context, raw string) (BatchResult, error) {
+	result := BatchResult{ImportedIDs: []int{}, Errors: []BatchError{}}
+
+	for _, parsed := range bibtex.Parse(raw) {
+		if parsed.Err != nil {
+			result.Errors = append(result.Errors, BatchError{Index: parsed.Index, Key: parsed.Key, Message: parsed.Err.Error()})
+			continue
+		}
+
+		pub, err := parsed.Entry.Publication()
+		if err != nil {
+			result.Errors = append(result.Errors, BatchError{Index: parsed.Index, Key: parsed.Key, Message: err.Error()})
+			continue
+		}
+
+		created, err := r.Create(ctx, pub)
+		if err != nil {
+			return result, fmt.Errorf("import entry %d: %w", parsed.Index, err)
+		}
+		result.ImportedIDs = append(result.ImportedIDs, created.ID)
+	}
+
+	return result, nil
+}
+
 // Update modifies an existing publication.
 func (r *PublicationRepository) Update(ctx context.Context, pub *models.Publication) (*models.Publication, error) {
+	if err := checkAuthorsText(pub.AuthorsText); err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE publications
 		SET title = $1, authors_text = $2, venue = $3, year = $4, url = $5,
@@ -211,23 +576,28 @@ func (r *PublicationRepository) Update(ctx context.Context, pub *models.Publicat
 		RETURNING updated_at
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(
-		ctx,
-		query,
-		pub.Title,
-		pub.AuthorsText,
-		pub.Venue,
-		pub.Year,
-		pub.URL,
-		pub.ID,
-	)
-
-	err := row.Scan(&pub.UpdatedAt)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+	err := r.withAudit(ctx, models.AuditActionUpdate, AuditEntityPublication, func(ctx context.Context) (int, error) {
+		row := r.GetExecer(ctx).QueryRowContext(
+			ctx,
+			query,
+			pub.Title,
+			pub.AuthorsText,
+			pub.Venue,
+			pub.Year,
+			pub.URL,
+			pub.ID,
+		)
+
+		if err := row.Scan(&pub.UpdatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return 0, ErrNotFound
+			}
+			return 0, WrapError(err, "update publication")
 		}
-		return nil, WrapError(err, "update publication")
+		return pub.ID, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return pub, nil
@@ -237,43 +607,122 @@ func (r *PublicationRepository) Update(ctx context.Context, pub *models.Publicat
 func (r *PublicationRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM publications WHERE id = $1`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+	return r.withAudit(ctx, models.AuditActionDelete, AuditEntityPublication, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+		if err != nil {
+			return 0, WrapError(err, "delete publication")
+		}
+		return id, CheckRowsAffected(result, 1)
+	})
+}
+
+// DeleteBulk removes the publications with the given IDs in a single transaction and
+// returns how many were deleted. IDs that do not exist are skipped; if none of them
+// exist, ErrNotFound is returned. An empty list deletes nothing and returns 0.
+func (r *PublicationRepository) DeleteBulk(ctx context.Context, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var deleted []int
+	err := r.withAuditEach(ctx, models.AuditActionDelete, AuditEntityPublication, func(txCtx context.Context) ([]int, error) {
+		query := `DELETE FROM publications WHERE id = $1`
+
+		for _, id := range ids {
+			result, err := r.GetExecer(txCtx).ExecContext(txCtx, query, id)
+			if err != nil {
+				return nil, WrapError(err, "bulk delete publications")
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return nil, WrapError(err, "bulk delete publications")
+			}
+			if rows > 0 {
+				deleted = append(deleted, id)
+			}
+		}
+
+		if len(deleted) == 0 {
+			return nil, ErrNotFound
+		}
+		return deleted, nil
+	})
 	if err != nil {
-		return WrapError(err, "delete publication")
+		return 0, err
 	}
 
-	return CheckRowsAffected(result, 1)
+	return len(deleted), nil
 }
 
-// LinkAuthor associates a lab member with a publication.
+// LinkAuthor associates a lab member with a publication, as its last author.
+// Linking a member who is already an author keeps their position.
 func (r *PublicationRepository) LinkAuthor(ctx context.Context, publicationID, memberID int) error {
 	query := `
-		INSERT INTO publication_authors (publication_id, member_id)
-		VALUES ($1, $2)
+		INSERT INTO publication_authors (publication_id, member_id, author_order)
+		VALUES ($1, $2, (
+			SELECT COALESCE(MAX(author_order) + 1, 0)
+			FROM publication_authors
+			WHERE publication_id = $1
+		))
 		ON CONFLICT (publication_id, member_id) DO NOTHING
 	`
 
-	_, err := r.GetExecer(ctx).ExecContext(ctx, query, publicationID, memberID)
-	if err != nil {
-		return WrapError(err, "link author to publication")
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityPublication, func(ctx context.Context) (int, error) {
+		_, err := r.GetExecer(ctx).ExecContext(ctx, query, publicationID, memberID)
+		if err != nil {
+			return 0, WrapError(err, "link author to publication")
+		}
+		return publicationID, nil
+	})
+}
+
+// LinkAuthors replaces the linked authors of a publication with memberIDs, in authorship
+// order (first author first). It runs in a transaction, so on error the previous author
+// list is kept. A member listed twice returns ErrInvalidInput.
+func (r *PublicationRepository) LinkAuthors(ctx context.Context, publicationID int, memberIDs []int) error {
+	seen := make(map[int]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: member %d is listed more than once", ErrInvalidInput, id)
+		}
+		seen[id] = true
 	}
 
-	return nil
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityPublication, func(txCtx context.Context) (int, error) {
+		_, err := r.GetExecer(txCtx).ExecContext(txCtx,
+			`DELETE FROM publication_authors WHERE publication_id = $1`, publicationID)
+		if err != nil {
+			return 0, WrapError(err, "clear publication authors")
+		}
+
+		query := `
+			INSERT INTO publication_authors (publication_id, member_id, author_order)
+			VALUES ($1, $2, $3)
+		`
+		for i, memberID := range memberIDs {
+			if _, err := r.GetExecer(txCtx).ExecContext(txCtx, query, publicationID, memberID, i); err != nil {
+				return 0, WrapError(err, "link authors to publication")
+			}
+		}
+
+		return publicationID, nil
+	})
 }
 
 // UnlinkAuthor removes the association between a lab member and a publication.
 func (r *PublicationRepository) UnlinkAuthor(ctx context.Context, publicationID, memberID int) error {
 	query := `DELETE FROM publication_authors WHERE publication_id = $1 AND member_id = $2`
 
-	result, err := r.GetExecer(ctx).ExecContext(ctx, query, publicationID, memberID)
-	if err != nil {
-		return WrapError(err, "unlink author from publication")
-	}
-
-	return CheckRowsAffected(result, 1)
+	return r.withAudit(ctx, models.AuditActionUpdate, AuditEntityPublication, func(ctx context.Context) (int, error) {
+		result, err := r.GetExecer(ctx).ExecContext(ctx, query, publicationID, memberID)
+		if err != nil {
+			return 0, WrapError(err, "unlink author from publication")
+		}
+		return publicationID, CheckRowsAffected(result, 1)
+	})
 }
 
-// GetAuthors retrieves all authors for a publication.
+// GetAuthors retrieves all authors for a publication in authorship order.
 func (r *PublicationRepository) GetAuthors(ctx context.Context, publicationID int) ([]models.LabMember, error) {
 	query := `
 		SELECT m.id, m.name, m.role, m.email, m.bio, m.photo_url,
@@ -282,7 +731,7 @@ func (r *PublicationRepository) GetAuthors(ctx context.Context, publicationID in
 		FROM lab_members m
 		INNER JOIN publication_authors pa ON m.id = pa.member_id
 		WHERE pa.publication_id = $1
-		ORDER BY m.display_order ASC
+		ORDER BY pa.author_order ASC, m.display_order ASC
 	`
 
 	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, publicationID)
@@ -291,34 +740,7 @@ func (r *PublicationRepository) GetAuthors(ctx context.Context, publicationID in
 	}
 	defer rows.Close()
 
-	var members []models.LabMember
-	for rows.Next() {
-		var m models.LabMember
-		err := rows.Scan(
-			&m.ID,
-			&m.Name,
-			&m.Role,
-			&m.Email,
-			&m.Bio,
-			&m.PhotoURL,
-			&m.PersonalPageContent,
-			&m.ResearchInterests,
-			&m.IsAlumni,
-			&m.DisplayOrder,
-			&m.CreatedAt,
-			&m.UpdatedAt,
-		)
-		if err != nil {
-			return nil, WrapError(err, "scan author")
-		}
-		members = append(members, m)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, WrapError(err, "iterate authors")
-	}
-
-	return members, nil
+	return scanLabMembers(rows, "authors")
 }
 
 // GetWithAuthors retrieves a publication with its authors.
@@ -338,3 +760,32 @@ func (r *PublicationRepository) GetWithAuthors(ctx context.Context, id int) (*mo
 		Authors:     authors,
 	}, nil
 }
+
+// checkAuthorsText rejects an authors_text longer than models.AuthorsTextMaxLength, so that
+// entries which skipped model validation (e.g. direct imports) cannot break page layouts.
+func checkAuthorsText(authors string) error {
+	if models.AuthorsTextTooLong(authors) {
+		return fmt.Errorf("%w: authors_text is longer than %d characters", ErrInvalidInput, models.AuthorsTextMaxLength())
+	}
+	return nil
+}
+
+// normalizeTitle lowercases a title, strips punctuation and collapses whitespace
+// so that trivially different spellings of the same title compare equal.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
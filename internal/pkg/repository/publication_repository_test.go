@@ -2,7 +2,10 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -223,3 +226,691 @@ func TestPublicationRepository_Links(t *testing.T) {
 		assert.Len(t, pubWithAuthors.Authors, 1)
 	})
 }
+
+func TestPublicationRepository_FindPossibleDuplicates(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	existing, err := repo.Create(ctx, &models.Publication{
+		Title:       "Deep Learning: A Survey",
+		AuthorsText: "John Doe",
+		Year:        2024,
+	})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, &models.Publication{
+		Title:       "Graph Neural Networks in Practice",
+		AuthorsText: "Jane Smith",
+		Year:        2024,
+	})
+	require.NoError(t, err)
+
+	t.Run("near-identical title is flagged", func(t *testing.T) {
+		dups, err := repo.FindPossibleDuplicates(ctx, "deep learning -- a  survey.", 2024)
+		require.NoError(t, err)
+		require.Len(t, dups, 1)
+		assert.Equal(t, existing.ID, dups[0].ID)
+	})
+
+	t.Run("different title is not flagged", func(t *testing.T) {
+		dups, err := repo.FindPossibleDuplicates(ctx, "Reinforcement Learning: A Survey", 2024)
+		require.NoError(t, err)
+		assert.Empty(t, dups)
+	})
+
+	t.Run("same title in another year is not flagged", func(t *testing.T) {
+		dups, err := repo.FindPossibleDuplicates(ctx, "Deep Learning: A Survey", 2023)
+		require.NoError(t, err)
+		assert.Empty(t, dups)
+	})
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Deep Learning: A Survey", "deep learning a survey"},
+		{"  DEEP learning -- a survey!  ", "deep learning a survey"},
+		{"Über-Netze (2nd ed.)", "übernetze 2nd ed"},
+		{"...", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, normalizeTitle(tt.input))
+		})
+	}
+}
+
+func TestPublicationRepository_GetByIDOrNil(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	created, err := repo.Create(ctx, &models.Publication{
+		Title:       "Existing Paper",
+		AuthorsText: "A. Author",
+		Year:        2024,
+	})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		pub, err := repo.GetByIDOrNil(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, pub)
+		assert.Equal(t, "Existing Paper", pub.Title)
+	})
+
+	t.Run("missing returns nil without error", func(t *testing.T) {
+		pub, err := repo.GetByIDOrNil(ctx, 99999)
+		require.NoError(t, err)
+		assert.Nil(t, pub)
+	})
+
+	t.Run("GetByID still reports ErrNotFound", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, 99999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestPublicationRepository_ImportBibTeXBatch(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	raw := `
+@article{good2024,
+  author = {Doe, Jane},
+  title  = {First Imported Paper},
+  year   = 2024
+}
+
+@article{unclosed,
+  title = {Never closed,
+  year  = 2023
+@article{noyear, author = {Doe, Jane}, title = {Missing Year}}
+
+@inproceedings{good2023,
+  author    = {Ann Lee and Bo Chen},
+  title     = {Second Imported Paper},
+  booktitle = {Workshop},
+  year      = {2023}
+}
+`
+
+	result, err := repo.ImportBibTeXBatch(ctx, raw)
+	require.NoError(t, err)
+	require.Len(t, result.ImportedIDs, 2)
+	require.Len(t, result.Errors, 2)
+
+	assert.Equal(t, 2, result.Errors[0].Index)
+	assert.Equal(t, "unclosed", result.Errors[0].Key)
+	assert.Contains(t, result.Errors[0].Message, "unbalanced braces")
+	assert.Equal(t, 3, result.Errors[1].Index)
+	assert.Equal(t, "noyear", result.Errors[1].Key)
+	assert.Contains(t, result.Errors[1].Message, "invalid year")
+
+	// Valid entries are committed despite the rejected ones
+	second, err := repo.GetByID(ctx, result.ImportedIDs[1])
+	require.NoError(t, err)
+	assert.Equal(t, "Second Imported Paper", second.Title)
+	assert.Equal(t, "Ann Lee, Bo Chen", second.AuthorsText)
+	assert.Equal(t, "Workshop", second.Venue.String)
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestPublicationRepository_Search(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	create := func(title, authors, venue string, year int) int {
+		pub := &models.Publication{Title: title, AuthorsText: authors, Year: year}
+		if venue != "" {
+			pub.Venue = sql.NullString{String: venue, Valid: true}
+		}
+		created, err := repo.Create(ctx, pub)
+		require.NoError(t, err)
+		return created.ID
+	}
+
+	venueOnly := create("Sparse Attention", "Jane Doe", "Robotics Letters", 2024)
+	titleInner := create("Learning for Robotics", "Jane Doe", "NeurIPS", 2020)
+	titlePrefix := create("Robotics at Scale", "Jane Doe", "", 2019)
+	authorOnly := create("Unrelated Work", "Robotics Team", "", 2023)
+	create("Nothing Matches", "Jane Doe", "ICML", 2024)
+
+	t.Run("title match outranks venue-only match", func(t *testing.T) {
+		results, err := repo.Search(ctx, "robotics", 10)
+		require.NoError(t, err)
+
+		ids := make([]int, len(results))
+		for i, pub := range results {
+			ids[i] = pub.ID
+		}
+		assert.Equal(t, []int{titlePrefix, titleInner, authorOnly, venueOnly}, ids)
+	})
+
+	t.Run("same rank ordered by year", func(t *testing.T) {
+		newer := create("Robotics Revisited", "Jane Doe", "", 2025)
+
+		results, err := repo.Search(ctx, "Robotics", 10)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(results), 2)
+		assert.Equal(t, newer, results[0].ID)
+		assert.Equal(t, titlePrefix, results[1].ID)
+	})
+
+	t.Run("wildcards match literally", func(t *testing.T) {
+		create("100% Recall", "Jane Doe", "", 2022)
+
+		results, err := repo.Search(ctx, "0%", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "100% Recall", results[0].Title)
+	})
+
+	t.Run("empty term returns nothing", func(t *testing.T) {
+		results, err := repo.Search(ctx, "  ", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("limit caps results", func(t *testing.T) {
+		all, err := repo.Search(ctx, "robotics", 10)
+		require.NoError(t, err)
+		require.Greater(t, len(all), 2)
+
+		results, err := repo.Search(ctx, "robotics", 2)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, all[0].ID, results[0].ID)
+		assert.Equal(t, all[1].ID, results[1].ID)
+	})
+
+	t.Run("non-positive limit rejected", func(t *testing.T) {
+		_, err := repo.Search(ctx, "robotics", 0)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestPublicationRepository_CountByVenue(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	venues := []string{"Nature", "Nature", "ICML", ""}
+	for i, venue := range venues {
+		pub := &models.Publication{Title: "Paper " + string(rune('A'+i)), AuthorsText: "Author", Year: 2024}
+		if venue != "" {
+			pub.Venue = sql.NullString{String: venue, Valid: true}
+		}
+		_, err := repo.Create(ctx, pub)
+		require.NoError(t, err)
+	}
+
+	counts, err := repo.CountByVenue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"Nature": 2, "ICML": 1}, counts)
+}
+
+func TestPublicationRepository_GetWithoutLinkedAuthors(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+
+	member, err := memberRepo.Create(ctx, &models.LabMember{Name: "Jane Doe", Role: models.LabMemberRolePI})
+	require.NoError(t, err)
+
+	linked, err := repo.Create(ctx, &models.Publication{Title: "Linked Paper", AuthorsText: "Jane Doe", Year: 2024})
+	require.NoError(t, err)
+	require.NoError(t, repo.LinkAuthor(ctx, linked.ID, member.ID))
+
+	unlinked, err := repo.Create(ctx, &models.Publication{Title: "Imported Paper", AuthorsText: "Jane Doe", Year: 2023})
+	require.NoError(t, err)
+
+	pubs, err := repo.GetWithoutLinkedAuthors(ctx)
+	require.NoError(t, err)
+	require.Len(t, pubs, 1)
+	assert.Equal(t, unlinked.ID, pubs[0].ID)
+}
+
+func TestPublicationRepository_GetRecent(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	// Added in this order; the last one is the oldest paper but the newest entry
+	var ids []int
+	for _, year := range []int{2024, 2022, 2025, 2010} {
+		pub, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: "Author", Year: year})
+		require.NoError(t, err)
+		ids = append(ids, pub.ID)
+	}
+
+	t.Run("ordered by insertion recency", func(t *testing.T) {
+		pubs, err := repo.GetRecent(ctx, 3)
+		require.NoError(t, err)
+		require.Len(t, pubs, 3)
+		assert.Equal(t, ids[3], pubs[0].ID)
+		assert.Equal(t, 2010, pubs[0].Year)
+		assert.Equal(t, ids[2], pubs[1].ID)
+		assert.Equal(t, ids[1], pubs[2].ID)
+	})
+
+	t.Run("non-positive limit is rejected", func(t *testing.T) {
+		_, err := repo.GetRecent(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestPublicationRepository_GetFiltered_SortBy(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	for _, authors := range []string{"charlie, C.", "Alice, A. and Bob, B.", "bob, B."} {
+		_, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: authors, Year: 2024})
+		require.NoError(t, err)
+	}
+
+	t.Run("author sorts alphabetically ignoring case", func(t *testing.T) {
+		pubs, err := repo.GetFiltered(ctx, PublicationFilter{SortBy: "author"})
+		require.NoError(t, err)
+		require.Len(t, pubs, 3)
+		assert.Equal(t, "Alice, A. and Bob, B.", pubs[0].AuthorsText)
+		assert.Equal(t, "bob, B.", pubs[1].AuthorsText)
+		assert.Equal(t, "charlie, C.", pubs[2].AuthorsText)
+	})
+
+	t.Run("unknown sort key is rejected", func(t *testing.T) {
+		_, err := repo.GetFiltered(ctx, PublicationFilter{SortBy: "title; DROP TABLE publications"})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestPublicationRepository_GetGroupedByYear(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	t.Run("empty table yields no groups", func(t *testing.T) {
+		groups, err := repo.GetGroupedByYear(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+	})
+
+	ids := make(map[string]int)
+	for _, seed := range []struct {
+		title string
+		year  int
+	}{
+		{"Old A", 2019}, {"New A", 2024}, {"Mid", 2021}, {"New B", 2024}, {"Old B", 2019},
+	} {
+		pub, err := repo.Create(ctx, &models.Publication{Title: seed.title, AuthorsText: "Author", Year: seed.year})
+		require.NoError(t, err)
+		ids[seed.title] = pub.ID
+	}
+
+	groups, err := repo.GetGroupedByYear(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+
+	t.Run("years are descending", func(t *testing.T) {
+		assert.Equal(t, 2024, groups[0].Year)
+		assert.Equal(t, 2021, groups[1].Year)
+		assert.Equal(t, 2019, groups[2].Year)
+	})
+
+	t.Run("publications are grouped under their year", func(t *testing.T) {
+		require.Len(t, groups[0].Publications, 2)
+		require.Len(t, groups[1].Publications, 1)
+		require.Len(t, groups[2].Publications, 2)
+		for _, group := range groups {
+			for _, pub := range group.Publications {
+				assert.Equal(t, group.Year, pub.Year)
+			}
+		}
+	})
+
+	t.Run("newest entry first within a year", func(t *testing.T) {
+		assert.Equal(t, ids["New B"], groups[0].Publications[0].ID)
+		assert.Equal(t, ids["New A"], groups[0].Publications[1].ID)
+		assert.Equal(t, ids["Old B"], groups[2].Publications[0].ID)
+		assert.Equal(t, ids["Old A"], groups[2].Publications[1].ID)
+	})
+}
+
+func TestPublicationRepository_AuthorsTextLength(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	t.Cleanup(func() { models.SetAuthorsTextMaxLength(models.DefaultAuthorsTextMaxLength) })
+	models.SetAuthorsTextMaxLength(30)
+
+	pub, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: strings.Repeat("a", 30), Year: 2024})
+	require.NoError(t, err, "authors_text at the limit is accepted")
+
+	t.Run("create rejects over-length authors", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: strings.Repeat("a", 31), Year: 2024})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+
+	t.Run("update rejects over-length authors", func(t *testing.T) {
+		pub.AuthorsText = strings.Repeat("a", 31)
+		_, err := repo.Update(ctx, pub)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		stored, err := repo.GetByID(ctx, pub.ID)
+		require.NoError(t, err)
+		assert.Len(t, stored.AuthorsText, 30, "rejected update leaves the row unchanged")
+	})
+}
+
+func TestPublicationRepository_GetByMembers(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+
+	var members []int
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		m, err := memberRepo.Create(ctx, &models.LabMember{Name: name, Role: models.LabMemberRolePhD})
+		require.NoError(t, err)
+		members = append(members, m.ID)
+	}
+	alice, bob, carol := members[0], members[1], members[2]
+
+	create := func(title string, year int, authors ...int) int {
+		pub, err := repo.Create(ctx, &models.Publication{Title: title, AuthorsText: "Authors", Year: year})
+		require.NoError(t, err)
+		for _, id := range authors {
+			require.NoError(t, repo.LinkAuthor(ctx, pub.ID, id))
+		}
+		return pub.ID
+	}
+
+	joint := create("Joint", 2023, alice, bob)
+	aliceOnly := create("Alice Only", 2024, alice)
+	bobOnly := create("Bob Only", 2021, bob)
+	create("Carol Only", 2025, carol)
+
+	t.Run("union without duplicates, newest first", func(t *testing.T) {
+		pubs, err := repo.GetByMembers(ctx, []int{alice, bob})
+		require.NoError(t, err)
+
+		ids := make([]int, len(pubs))
+		for i, pub := range pubs {
+			ids[i] = pub.ID
+		}
+		assert.Equal(t, []int{aliceOnly, joint, bobOnly}, ids)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		pubs, err := repo.GetByMembers(ctx, nil)
+		require.NoError(t, err)
+		assert.Empty(t, pubs)
+	})
+}
+
+func TestPublicationRepository_DeleteBulk(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	create := func() int {
+		pub, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: "Author", Year: 2024})
+		require.NoError(t, err)
+		return pub.ID
+	}
+
+	t.Run("deletes multiple", func(t *testing.T) {
+		a, b, keep := create(), create(), create()
+
+		n, err := repo.DeleteBulk(ctx, []int{a, b})
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		_, err = repo.GetByID(ctx, a)
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = repo.GetByID(ctx, keep)
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips missing ids", func(t *testing.T) {
+		a := create()
+
+		n, err := repo.DeleteBulk(ctx, []int{a, 99999})
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("none matched", func(t *testing.T) {
+		n, err := repo.DeleteBulk(ctx, []int{99998, 99999})
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, 0, n)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		n, err := repo.DeleteBulk(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+	})
+}
+
+func TestPublicationRepository_AuthorOrder(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+	memberRepo := NewLabMemberRepository(dbManager)
+
+	// Display order is the reverse of the authorship order used below
+	var ids []int
+	for i, name := range []string{"Alice", "Bob", "Carol"} {
+		m, err := memberRepo.Create(ctx, &models.LabMember{Name: name, Role: models.LabMemberRolePhD, DisplayOrder: 10 - i})
+		require.NoError(t, err)
+		ids = append(ids, m.ID)
+	}
+
+	authorNames := func(pubID int) []string {
+		authors, err := repo.GetAuthors(ctx, pubID)
+		require.NoError(t, err)
+		var names []string
+		for _, a := range authors {
+			names = append(names, a.Name)
+		}
+		return names
+	}
+
+	pub, err := repo.Create(ctx, &models.Publication{Title: "Paper", AuthorsText: "Bob, Alice, Carol", Year: 2024})
+	require.NoError(t, err)
+
+	t.Run("LinkAuthors sets the order", func(t *testing.T) {
+		require.NoError(t, repo.LinkAuthors(ctx, pub.ID, []int{ids[1], ids[0], ids[2]}))
+		assert.Equal(t, []string{"Bob", "Alice", "Carol"}, authorNames(pub.ID))
+	})
+
+	t.Run("LinkAuthors replaces the previous list", func(t *testing.T) {
+		require.NoError(t, repo.LinkAuthors(ctx, pub.ID, []int{ids[2], ids[0]}))
+		assert.Equal(t, []string{"Carol", "Alice"}, authorNames(pub.ID))
+	})
+
+	t.Run("LinkAuthor appends", func(t *testing.T) {
+		require.NoError(t, repo.LinkAuthor(ctx, pub.ID, ids[1]))
+		assert.Equal(t, []string{"Carol", "Alice", "Bob"}, authorNames(pub.ID))
+
+		// Relinking keeps the existing position
+		require.NoError(t, repo.LinkAuthor(ctx, pub.ID, ids[2]))
+		assert.Equal(t, []string{"Carol", "Alice", "Bob"}, authorNames(pub.ID))
+	})
+
+	t.Run("duplicate member is rejected", func(t *testing.T) {
+		err := repo.LinkAuthors(ctx, pub.ID, []int{ids[0], ids[0]})
+		assert.ErrorIs(t, err, ErrInvalidInput)
+		assert.Equal(t, []string{"Carol", "Alice", "Bob"}, authorNames(pub.ID))
+	})
+
+	t.Run("unknown member rolls back", func(t *testing.T) {
+		err := repo.LinkAuthors(ctx, pub.ID, []int{ids[0], 99999})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"Carol", "Alice", "Bob"}, authorNames(pub.ID))
+	})
+}
+
+func TestPublicationRepository_GetCreatedBetween(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	// The publication year deliberately differs from when each entry was added
+	created := map[string]string{
+		"Before":        "2023-12-31 23:59:59",
+		"Start of year": "2024-01-01 00:00:00",
+		"Midyear":       "2024-06-15T10:30:00Z",
+		"End of year":   "2024-12-31 23:59:59",
+		"After":         "2025-01-01 00:00:00",
+	}
+	ids := map[string]int{}
+	for _, title := range []string{"Before", "Start of year", "Midyear", "End of year", "After"} {
+		pub, err := repo.Create(ctx, &models.Publication{Title: title, AuthorsText: "Author", Year: 2019})
+		require.NoError(t, err)
+		_, err = dbManager.GetDB().Exec(`UPDATE publications SET created_at = $1 WHERE id = $2`, created[title], pub.ID)
+		require.NoError(t, err)
+		ids[title] = pub.ID
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	t.Run("returns entries added within the window", func(t *testing.T) {
+		pubs, err := repo.GetCreatedBetween(ctx, from, to)
+		require.NoError(t, err)
+		require.Len(t, pubs, 3)
+		assert.Equal(t, ids["Start of year"], pubs[0].ID)
+		assert.Equal(t, ids["Midyear"], pubs[1].ID)
+		assert.Equal(t, ids["End of year"], pubs[2].ID)
+	})
+
+	t.Run("window bounds are converted to UTC", func(t *testing.T) {
+		// 2024-01-01 02:00 in UTC+2 is midnight UTC
+		plusTwo := time.FixedZone("UTC+2", 2*60*60)
+		pubs, err := repo.GetCreatedBetween(ctx, time.Date(2024, 1, 1, 2, 0, 0, 0, plusTwo), to)
+		require.NoError(t, err)
+		require.NotEmpty(t, pubs)
+		assert.Equal(t, ids["Start of year"], pubs[0].ID)
+	})
+
+	t.Run("empty or inverted window", func(t *testing.T) {
+		_, err := repo.GetCreatedBetween(ctx, from, from)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		_, err = repo.GetCreatedBetween(ctx, to, from)
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
+
+func TestPublicationRepository_GetIncomplete(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	str := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+
+	seed := []models.Publication{
+		{Title: "Complete", Venue: str("ICML"), URL: str("https://example.org/a")},
+		{Title: "No URL", Venue: str("NeurIPS")},
+		{Title: "No venue", URL: str("https://example.org/b")},
+		{Title: "Blank venue", Venue: str("  "), URL: str("https://example.org/c")},
+		{Title: "Nothing"},
+	}
+	for i := range seed {
+		seed[i].AuthorsText = "Author"
+		seed[i].Year = 2020 + i
+		_, err := repo.Create(ctx, &seed[i])
+		require.NoError(t, err)
+	}
+
+	pubs, err := repo.GetIncomplete(ctx)
+	require.NoError(t, err)
+
+	var titles []string
+	for _, p := range pubs {
+		titles = append(titles, p.Title)
+	}
+	assert.Equal(t, []string{"Nothing", "Blank venue", "No venue", "No URL"}, titles)
+}
+
+func TestPublicationRepository_GetAdjacentByYear(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	create := func(title string, year int) int {
+		created, err := repo.Create(ctx, &models.Publication{Title: title, AuthorsText: "Author", Year: year})
+		require.NoError(t, err)
+		return created.ID
+	}
+
+	// Timeline order: newest, sameYearLater, sameYearEarlier, oldest
+	oldest := create("Oldest", 2018)
+	sameYearEarlier := create("Same Year Earlier", 2022)
+	newest := create("Newest", 2024)
+	sameYearLater := create("Same Year Later", 2022)
+
+	idOf := func(p *models.Publication) int {
+		if p == nil {
+			return 0
+		}
+		return p.ID
+	}
+
+	tests := []struct {
+		name       string
+		id         int
+		prev, next int
+	}{
+		{"first has no previous", newest, 0, sameYearLater},
+		{"middle of same year", sameYearLater, newest, sameYearEarlier},
+		{"crosses to older year", sameYearEarlier, sameYearLater, oldest},
+		{"last has no next", oldest, sameYearEarlier, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, next, err := repo.GetAdjacentByYear(ctx, tt.id)
+			require.NoError(t, err)
+			assert.Equal(t, tt.prev, idOf(prev))
+			assert.Equal(t, tt.next, idOf(next))
+		})
+	}
+
+	t.Run("unknown publication", func(t *testing.T) {
+		_, _, err := repo.GetAdjacentByYear(ctx, 99999)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("only publication has no neighbors", func(t *testing.T) {
+		single := NewPublicationRepository(setupTestDB(t))
+		created, err := single.Create(ctx, &models.Publication{Title: "Alone", AuthorsText: "Author", Year: 2020})
+		require.NoError(t, err)
+
+		prev, next, err := single.GetAdjacentByYear(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Nil(t, prev)
+		assert.Nil(t, next)
+	})
+}
+
+func TestPublicationRepository_YearBounds(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	t.Run("empty table", func(t *testing.T) {
+		min, max, err := repo.YearBounds(ctx)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Zero(t, min)
+		assert.Zero(t, max)
+	})
+
+	t.Run("populated table", func(t *testing.T) {
+		for _, year := range []int{2015, 2023, 2009, 2019} {
+			_, err := repo.Create(ctx, &models.Publication{Title: fmt.Sprintf("Paper %d", year), AuthorsText: "Author", Year: year})
+			require.NoError(t, err)
+		}
+
+		min, max, err := repo.YearBounds(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2009, min)
+		assert.Equal(t, 2023, max)
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// queryBuilder appends optional filter conditions to a base SELECT.
+// Only whitelisted columns can be filtered on and values are always bound as
+// parameters, so user input never ends up in the SQL text.
+type queryBuilder struct {
+	base       string
+	allowed    map[string]bool
+	conditions []string
+	args       []any
+	err        error
+}
+
+// newQueryBuilder creates a builder for the base query (SELECT ... FROM ...)
+// that accepts conditions on the given columns only.
+func newQueryBuilder(base string, allowedColumns ...string) *queryBuilder {
+	allowed := make(map[string]bool, len(allowedColumns))
+	for _, column := range allowedColumns {
+		allowed[column] = true
+	}
+	return &queryBuilder{base: base, allowed: allowed}
+}
+
+// where adds a "column = value" condition.
+func (b *queryBuilder) where(column string, value any) *queryBuilder {
+	if b.err != nil {
+		return b
+	}
+	if !b.allowed[column] {
+		b.err = fmt.Errorf("%w: cannot filter on column %q", ErrInvalidInput, column)
+		return b
+	}
+
+	b.args = append(b.args, value)
+	b.conditions = append(b.conditions, column+" = $"+strconv.Itoa(len(b.args)))
+	return b
+}
+
+// build returns the SQL with a WHERE clause for the added conditions followed by
+// the given suffix (ORDER BY, LIMIT ...), and the bound arguments.
+func (b *queryBuilder) build(suffix string) (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSpace(b.base))
+	if len(b.conditions) > 0 {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(b.conditions, " AND "))
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		sb.WriteString(" ")
+		sb.WriteString(suffix)
+	}
+
+	return sb.String(), b.args, nil
+}
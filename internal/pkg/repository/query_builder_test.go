@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	const base = `
+		SELECT id, name FROM lab_members
+	`
+
+	tests := []struct {
+		name      string
+		filters   [][2]any
+		suffix    string
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "no filters",
+			suffix:    "ORDER BY id",
+			wantQuery: "SELECT id, name FROM lab_members ORDER BY id",
+			wantArgs:  nil,
+		},
+		{
+			name:      "single filter",
+			filters:   [][2]any{{"role", "PhD"}},
+			suffix:    "ORDER BY id",
+			wantQuery: "SELECT id, name FROM lab_members WHERE role = $1 ORDER BY id",
+			wantArgs:  []any{"PhD"},
+		},
+		{
+			name:      "multiple filters",
+			filters:   [][2]any{{"role", "PhD"}, {"is_alumni", false}},
+			suffix:    "ORDER BY id",
+			wantQuery: "SELECT id, name FROM lab_members WHERE role = $1 AND is_alumni = $2 ORDER BY id",
+			wantArgs:  []any{"PhD", false},
+		},
+		{
+			name:      "no suffix",
+			filters:   [][2]any{{"is_alumni", true}},
+			wantQuery: "SELECT id, name FROM lab_members WHERE is_alumni = $1",
+			wantArgs:  []any{true},
+		},
+		{
+			name:      "values are never interpolated",
+			filters:   [][2]any{{"role", "x' OR '1'='1"}},
+			wantQuery: "SELECT id, name FROM lab_members WHERE role = $1",
+			wantArgs:  []any{"x' OR '1'='1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qb := newQueryBuilder(base, "role", "is_alumni")
+			for _, f := range tt.filters {
+				qb.where(f[0].(string), f[1])
+			}
+
+			query, args, err := qb.build(tt.suffix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestQueryBuilder_RejectsUnknownColumn(t *testing.T) {
+	qb := newQueryBuilder("SELECT id FROM lab_members", "role")
+	qb.where("role", "PhD").where("name; DROP TABLE lab_members", "x")
+
+	_, _, err := qb.build("")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
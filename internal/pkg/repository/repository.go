@@ -3,11 +3,19 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 )
 
+// MaxPageSize is the largest number of rows a paginated query returns; larger limits are clamped.
+const MaxPageSize = 100
+
+// sqliteDateTime is the layout of timestamps written with datetime('now'). Times compared
+// against such columns must be formatted with it in UTC to sort correctly as strings.
+const sqliteDateTime = "2006-01-02 15:04:05"
+
 // BaseRepository provides common functionality for all repositories.
 type BaseRepository struct {
 	dbManager *db.DBManager
@@ -27,11 +35,41 @@ func (r *BaseRepository) GetExecer(ctx context.Context) db.Execer {
 	return r.dbManager.GetExecer(ctx)
 }
 
-// WithTransaction executes a function within a transaction.
+// WithTransaction executes a function within a transaction, joining one already in ctx.
 func (r *BaseRepository) WithTransaction(ctx context.Context, fn db.TransactionFunc) error {
 	return r.dbManager.WithTransaction(ctx, fn)
 }
 
+// withAudit runs write and records action on entity in the audit log within the same
+// transaction, so a change is never committed without its audit entry. write returns
+// the ID of the changed row.
+func (r *BaseRepository) withAudit(ctx context.Context, action, entity string, write func(ctx context.Context) (int, error)) error {
+	return r.withAuditEach(ctx, action, entity, func(txCtx context.Context) ([]int, error) {
+		id, err := write(txCtx)
+		if err != nil {
+			return nil, err
+		}
+		return []int{id}, nil
+	})
+}
+
+// withAuditEach is withAudit for writes that change several rows: write returns the IDs
+// of the changed rows and one audit entry is recorded for each of them.
+func (r *BaseRepository) withAuditEach(ctx context.Context, action, entity string, write func(ctx context.Context) ([]int, error)) error {
+	return r.WithTransaction(ctx, func(txCtx context.Context) error {
+		ids, err := write(txCtx)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := recordAudit(txCtx, r.GetExecer(txCtx), action, entity, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // CheckRowsAffected verifies that exactly one row was affected.
 // Returns ErrNotFound if no rows were affected.
 func CheckRowsAffected(result sql.Result, expected int64) error {
@@ -47,3 +85,27 @@ func CheckRowsAffected(result sql.Result, expected int64) error {
 	}
 	return nil
 }
+
+// pageLimit validates a page size, rejecting non-positive limits with ErrInvalidInput
+// and clamping limits above MaxPageSize.
+func pageLimit(limit int) (int, error) {
+	if limit <= 0 {
+		return 0, fmt.Errorf("%w: limit must be positive, got %d", ErrInvalidInput, limit)
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize, nil
+	}
+	return limit, nil
+}
+
+// orNil converts an ErrNotFound result into (nil, nil) for lookups where a missing
+// row is an expected outcome. Other errors are returned unchanged.
+func orNil[T any](entity *T, err error) (*T, error) {
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
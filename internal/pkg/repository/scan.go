@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// labMemberColumns lists the columns scanLabMember expects, in scan order.
+var labMemberColumns = []string{
+	"id", "name", "role", "email", "bio", "photo_url", "personal_page_content",
+	"research_interests", "is_alumni", "display_order", "created_at", "updated_at",
+}
+
+// publicationColumns lists the columns scanPublication expects, in scan order.
+var publicationColumns = []string{
+	"id", "title", "authors_text", "venue", "year", "url", "created_at", "updated_at",
+}
+
+// scanLabMember scans a single lab member row selected in labMemberColumns order.
+func scanLabMember(s rowScanner) (models.LabMember, error) {
+	var m models.LabMember
+	err := s.Scan(
+		&m.ID,
+		&m.Name,
+		&m.Role,
+		&m.Email,
+		&m.Bio,
+		&m.PhotoURL,
+		&m.PersonalPageContent,
+		&m.ResearchInterests,
+		&m.IsAlumni,
+		&m.DisplayOrder,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	return m, err
+}
+
+// scanPublication scans a single publication row selected in publicationColumns order.
+func scanPublication(s rowScanner) (models.Publication, error) {
+	var p models.Publication
+	err := s.Scan(
+		&p.ID,
+		&p.Title,
+		&p.AuthorsText,
+		&p.Venue,
+		&p.Year,
+		&p.URL,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+// scanLabMembers reads all remaining rows as lab members.
+// The operation name is used to wrap scan and iteration errors.
+func scanLabMembers(rows *sql.Rows, operation string) ([]models.LabMember, error) {
+	if err := checkColumns(rows, labMemberColumns); err != nil {
+		return nil, WrapError(err, "scan "+operation)
+	}
+
+	var members []models.LabMember
+	for rows.Next() {
+		m, err := scanLabMember(rows)
+		if err != nil {
+			return nil, WrapError(err, "scan "+operation)
+		}
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate "+operation)
+	}
+
+	return members, nil
+}
+
+// scanPublications reads all remaining rows as publications.
+// The operation name is used to wrap scan and iteration errors.
+func scanPublications(rows *sql.Rows, operation string) ([]models.Publication, error) {
+	if err := checkColumns(rows, publicationColumns); err != nil {
+		return nil, WrapError(err, "scan "+operation)
+	}
+
+	var pubs []models.Publication
+	for rows.Next() {
+		p, err := scanPublication(rows)
+		if err != nil {
+			return nil, WrapError(err, "scan "+operation)
+		}
+		pubs = append(pubs, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, WrapError(err, "iterate "+operation)
+	}
+
+	return pubs, nil
+}
+
+// checkColumns guards against a SELECT list drifting from the scan order.
+// Table aliases (m.id) are reported without the prefix, so names compare directly.
+func checkColumns(rows *sql.Rows, expected []string) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	if len(columns) != len(expected) {
+		return fmt.Errorf("%w: expected %d columns, got %d", ErrDatabase, len(expected), len(columns))
+	}
+	for i, name := range columns {
+		if name != expected[i] {
+			return fmt.Errorf("%w: column %d is %q, expected %q", ErrDatabase, i, name, expected[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanLabMembers_MatchesInlineScan(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewLabMemberRepository(dbManager)
+
+	// One member with every nullable column set and one with none
+	_, err := repo.Create(ctx, &models.LabMember{
+		Name:                "Full Member",
+		Role:                models.LabMemberRolePI,
+		Email:               sql.NullString{String: "full@example.com", Valid: true},
+		Bio:                 sql.NullString{String: "Bio", Valid: true},
+		PhotoURL:            sql.NullString{String: "/uploads/full.jpg", Valid: true},
+		PersonalPageContent: sql.NullString{String: "Page", Valid: true},
+		ResearchInterests:   sql.NullString{String: "Interests", Valid: true},
+		IsAlumni:            true,
+		DisplayOrder:        3,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &models.LabMember{Name: "Sparse Member", Role: models.LabMemberRolePhD})
+	require.NoError(t, err)
+
+	query := `
+		SELECT id, name, role, email, bio, photo_url, personal_page_content,
+		       research_interests, is_alumni, display_order, created_at, updated_at
+		FROM lab_members
+		ORDER BY id
+	`
+
+	rows, err := dbManager.GetDB().QueryContext(ctx, query)
+	require.NoError(t, err)
+	var inline []models.LabMember
+	for rows.Next() {
+		var m models.LabMember
+		require.NoError(t, rows.Scan(
+			&m.ID, &m.Name, &m.Role, &m.Email, &m.Bio, &m.PhotoURL, &m.PersonalPageContent,
+			&m.ResearchInterests, &m.IsAlumni, &m.DisplayOrder, &m.CreatedAt, &m.UpdatedAt,
+		))
+		inline = append(inline, m)
+	}
+	require.NoError(t, rows.Close())
+
+	rows, err = dbManager.GetDB().QueryContext(ctx, query)
+	require.NoError(t, err)
+	defer rows.Close()
+	shared, err := scanLabMembers(rows, "lab members")
+	require.NoError(t, err)
+
+	require.Len(t, inline, 2)
+	assert.Equal(t, inline, shared)
+}
+
+func TestScanPublications_MatchesInlineScan(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewPublicationRepository(dbManager)
+
+	_, err := repo.Create(ctx, &models.Publication{
+		Title:       "Full Paper",
+		AuthorsText: "A. Author",
+		Venue:       sql.NullString{String: "Nature", Valid: true},
+		Year:        2024,
+		URL:         sql.NullString{String: "https://example.com/paper", Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &models.Publication{Title: "Sparse Paper", AuthorsText: "B. Author", Year: 2023})
+	require.NoError(t, err)
+
+	query := `
+		SELECT id, title, authors_text, venue, year, url, created_at, updated_at
+		FROM publications
+		ORDER BY id
+	`
+
+	rows, err := dbManager.GetDB().QueryContext(ctx, query)
+	require.NoError(t, err)
+	var inline []models.Publication
+	for rows.Next() {
+		var p models.Publication
+		require.NoError(t, rows.Scan(&p.ID, &p.Title, &p.AuthorsText, &p.Venue, &p.Year, &p.URL, &p.CreatedAt, &p.UpdatedAt))
+		inline = append(inline, p)
+	}
+	require.NoError(t, rows.Close())
+
+	rows, err = dbManager.GetDB().QueryContext(ctx, query)
+	require.NoError(t, err)
+	defer rows.Close()
+	shared, err := scanPublications(rows, "publications")
+	require.NoError(t, err)
+
+	require.Len(t, inline, 2)
+	assert.Equal(t, inline, shared)
+}
+
+func TestScanHelpers_ColumnGuard(t *testing.T) {
+	dbManager := setupTestDB(t)
+
+	_, err := NewPublicationRepository(dbManager).Create(ctx, &models.Publication{
+		Title: "Paper", AuthorsText: "A. Author", Year: 2024,
+	})
+	require.NoError(t, err)
+
+	t.Run("misordered columns", func(t *testing.T) {
+		rows, err := dbManager.GetDB().QueryContext(ctx, `
+			SELECT id, authors_text, title, venue, year, url, created_at, updated_at FROM publications
+		`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		_, err = scanPublications(rows, "publications")
+		assert.ErrorIs(t, err, ErrDatabase)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		rows, err := dbManager.GetDB().QueryContext(ctx, `
+			SELECT id, name, role FROM lab_members
+		`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		_, err = scanLabMembers(rows, "lab members")
+		assert.ErrorIs(t, err, ErrDatabase)
+	})
+
+	t.Run("aliased columns pass", func(t *testing.T) {
+		rows, err := dbManager.GetDB().QueryContext(ctx, `
+			SELECT p.id, p.title, p.authors_text, p.venue, p.year, p.url, p.created_at, p.updated_at
+			FROM publications p
+		`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		pubs, err := scanPublications(rows, "publications")
+		require.NoError(t, err)
+		assert.Len(t, pubs, 1)
+	})
+}
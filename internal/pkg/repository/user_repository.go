@@ -3,6 +3,9 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/db"
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
@@ -26,7 +29,7 @@ func NewUserRepository(dbManager *db.DBManager) *UserRepository {
 // GetByID retrieves a user by ID.
 func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
 	query := `
-		SELECT id, email, role, created_at, updated_at
+		SELECT id, email, role, last_login_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -38,6 +41,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, err
 		&user.ID,
 		&user.Email,
 		&user.Role,
+		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -49,15 +53,15 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, err
 	return &user, nil
 }
 
-// GetByEmail retrieves a user by email.
+// GetByEmail retrieves a user by email. The email is trimmed and compared case-insensitively.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.UserWithPassword, error) {
 	query := `
-		SELECT id, email, role, password_hash, created_at, updated_at
+		SELECT id, email, role, password_hash, last_login_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
-	row := r.GetExecer(ctx).QueryRowContext(ctx, query, email)
+	row := r.GetExecer(ctx).QueryRowContext(ctx, query, normalizeEmail(email))
 
 	var user models.UserWithPassword
 	err := row.Scan(
@@ -65,6 +69,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 		&user.Email,
 		&user.Role,
 		&user.PasswordHash,
+		&user.LastLoginAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -76,10 +81,46 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.
 	return &user, nil
 }
 
+// FindByEmail retrieves a user by email, returning (nil, nil) when no user exists.
+// Unlike GetByEmail, a missing user is not an error, which keeps login code paths uniform.
+// Login handlers must still run a bcrypt comparison against a dummy hash when the user
+// is nil, so response timing does not reveal whether an email is registered.
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.UserWithPassword, error) {
+	user, err := r.GetByEmail(ctx, email)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// userRoleByEmailQuery looks up a role by normalized email, using idx_users_email
+const userRoleByEmailQuery = `
+	SELECT role
+	FROM users
+	WHERE email = $1
+`
+
+// GetRoleByEmail retrieves only the role of the user with the given email, for
+// authorization checks that do not need the rest of the row. The email is trimmed and
+// compared case-insensitively. ErrNotFound is returned for unknown emails.
+func (r *UserRepository) GetRoleByEmail(ctx context.Context, email string) (models.UserRole, error) {
+	var role models.UserRole
+	err := r.GetExecer(ctx).QueryRowContext(ctx, userRoleByEmailQuery, normalizeEmail(email)).Scan(&role)
+	if err != nil {
+		return "", WrapError(err, "get user role by email")
+	}
+
+	return role, nil
+}
+
 // GetAll retrieves all users.
 func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
 	query := `
-		SELECT id, email, role, created_at, updated_at
+		SELECT id, email, role, last_login_at, created_at, updated_at
 		FROM users
 		ORDER BY created_at DESC
 	`
@@ -90,6 +131,30 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
 	}
 	defer rows.Close()
 
+	return scanUsers(rows)
+}
+
+// GetInactive retrieves users who have not logged in since the given time, including
+// those who never logged in, least recently active first. Used for security reviews.
+func (r *UserRepository) GetInactive(ctx context.Context, since time.Time) ([]models.User, error) {
+	query := `
+		SELECT id, email, role, last_login_at, created_at, updated_at
+		FROM users
+		WHERE last_login_at IS NULL OR datetime(last_login_at) < $1
+		ORDER BY last_login_at ASC, id ASC
+	`
+
+	rows, err := r.GetExecer(ctx).QueryContext(ctx, query, since.UTC().Format(sqliteDateTime))
+	if err != nil {
+		return nil, WrapError(err, "get inactive users")
+	}
+	defer rows.Close()
+
+	return scanUsers(rows)
+}
+
+// scanUsers reads all rows of a user query
+func scanUsers(rows *sql.Rows) ([]models.User, error) {
 	var users []models.User
 	for rows.Next() {
 		var user models.User
@@ -97,6 +162,7 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
 			&user.ID,
 			&user.Email,
 			&user.Role,
+			&user.LastLoginAt,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 		)
@@ -114,7 +180,11 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]models.User, error) {
 }
 
 // Create inserts a new user.
+// The email is stored trimmed and lowercase; an email that differs from an existing one
+// only in case returns ErrDuplicate.
 func (r *UserRepository) Create(ctx context.Context, user *models.UserWithPassword) (*models.UserWithPassword, error) {
+	user.Email = normalizeEmail(user.Email)
+
 	query := `
 		INSERT INTO users (email, role, password_hash, created_at, updated_at)
 		VALUES ($1, $2, $3, datetime('now'), datetime('now'))
@@ -140,8 +210,10 @@ func (r *UserRepository) Create(ctx context.Context, user *models.UserWithPasswo
 	return user, nil
 }
 
-// Update modifies an existing user.
+// Update modifies an existing user. The email is normalized as in Create.
 func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	user.Email = normalizeEmail(user.Email)
+
 	query := `
 		UPDATE users
 		SET email = $1, role = $2, updated_at = datetime('now')
@@ -181,6 +253,19 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, id int, passwordHas
 	return CheckRowsAffected(result, 1)
 }
 
+// RecordLogin sets a user's last login time to now. It does not touch updated_at,
+// which tracks changes to the account itself.
+func (r *UserRepository) RecordLogin(ctx context.Context, id int) error {
+	query := `UPDATE users SET last_login_at = datetime('now') WHERE id = $1`
+
+	result, err := r.GetExecer(ctx).ExecContext(ctx, query, id)
+	if err != nil {
+		return WrapError(err, "record user login")
+	}
+
+	return CheckRowsAffected(result, 1)
+}
+
 // Delete removes a user.
 func (r *UserRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -192,3 +277,9 @@ func (r *UserRepository) Delete(ctx context.Context, id int) error {
 
 	return CheckRowsAffected(result, 1)
 }
+
+// normalizeEmail trims surrounding whitespace and lowercases an email address; emails are
+// stored in this form so that lookups are case-insensitive and can use idx_users_email.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
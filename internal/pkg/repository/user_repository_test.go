@@ -2,6 +2,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -166,3 +167,142 @@ func TestUserRepository_BasicOperations(t *testing.T) {
 		assert.Equal(t, ErrNotFound, err)
 	})
 }
+
+func TestUserRepository_FindByEmail(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewUserRepository(dbManager)
+
+	created, err := repo.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: "found@example.com", Role: models.UserRoleNormal},
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		user, err := repo.FindByEmail(ctx, "found@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, created.ID, user.ID)
+		assert.Equal(t, "hash", user.PasswordHash)
+	})
+
+	t.Run("not found returns nil without error", func(t *testing.T) {
+		user, err := repo.FindByEmail(ctx, "missing@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("GetByEmail still reports ErrNotFound", func(t *testing.T) {
+		_, err := repo.GetByEmail(ctx, "missing@example.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUserRepository_GetRoleByEmail(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewUserRepository(dbManager)
+
+	_, err := repo.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: "Root@Example.com", Role: models.UserRoleRoot},
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		role, err := repo.GetRoleByEmail(ctx, "root@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleRoot, role)
+	})
+
+	t.Run("email is normalized", func(t *testing.T) {
+		role, err := repo.GetRoleByEmail(ctx, "  ROOT@example.COM ")
+		require.NoError(t, err)
+		assert.Equal(t, models.UserRoleRoot, role)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, err := repo.GetRoleByEmail(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestUserRepository_EmailCase(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewUserRepository(dbManager)
+
+	user, err := repo.Create(ctx, &models.UserWithPassword{
+		User:         models.User{Email: " Admin@Example.com", Role: models.UserRoleNormal},
+		PasswordHash: "hash",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", user.Email, "emails are stored normalized")
+
+	t.Run("differently-cased duplicate", func(t *testing.T) {
+		_, err := repo.Create(ctx, &models.UserWithPassword{
+			User:         models.User{Email: "ADMIN@example.com", Role: models.UserRoleNormal},
+			PasswordHash: "hash",
+		})
+		assert.ErrorIs(t, err, ErrDuplicate)
+	})
+
+	t.Run("lookup ignores case", func(t *testing.T) {
+		found, err := repo.GetByEmail(ctx, "admin@EXAMPLE.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("update normalizes", func(t *testing.T) {
+		user.Email = "Renamed@Example.com"
+		updated, err := repo.Update(ctx, &user.User)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed@example.com", updated.Email)
+	})
+}
+
+func TestUserRepository_GetInactive(t *testing.T) {
+	dbManager := setupTestDB(t)
+	repo := NewUserRepository(dbManager)
+
+	var ids []int
+	for _, email := range []string{"active@example.com", "idle@example.com", "never@example.com"} {
+		user, err := repo.Create(ctx, &models.UserWithPassword{
+			User:         models.User{Email: email, Role: models.UserRoleNormal},
+			PasswordHash: "hash",
+		})
+		require.NoError(t, err)
+		ids = append(ids, user.ID)
+	}
+	active, idle, never := ids[0], ids[1], ids[2]
+
+	require.NoError(t, repo.RecordLogin(ctx, active))
+	require.NoError(t, repo.RecordLogin(ctx, idle))
+	_, err := dbManager.GetDB().Exec(`UPDATE users SET last_login_at = '2024-01-15 09:00:00' WHERE id = $1`, idle)
+	require.NoError(t, err)
+
+	t.Run("recorded login is stored", func(t *testing.T) {
+		user, err := repo.GetByID(ctx, active)
+		require.NoError(t, err)
+		require.True(t, user.LastLoginAt.Valid)
+		assert.WithinDuration(t, time.Now(), user.LastLoginAt.Time, time.Minute)
+	})
+
+	t.Run("reports users without a login since", func(t *testing.T) {
+		users, err := repo.GetInactive(ctx, time.Now().AddDate(0, -3, 0))
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, never, users[0].ID, "never logged in comes first")
+		assert.False(t, users[0].LastLoginAt.Valid)
+		assert.Equal(t, idle, users[1].ID)
+	})
+
+	t.Run("older cutoff only reports users who never logged in", func(t *testing.T) {
+		users, err := repo.GetInactive(ctx, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, never, users[0].ID)
+	})
+
+	t.Run("record login for missing user", func(t *testing.T) {
+		assert.ErrorIs(t, repo.RecordLogin(ctx, 9999), ErrNotFound)
+	})
+}
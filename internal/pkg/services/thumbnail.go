@@ -0,0 +1,113 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// thumbnailJPEGQuality is the JPEG quality used when encoding thumbnails
+const thumbnailJPEGQuality = 85
+
+// saveThumbnail stores a thumbnail of the image in data next to the original file
+// and returns its filename. Images that already fit within the thumbnail size are
+// not upscaled; the original filename is returned instead.
+func (s *UploadService) saveThumbnail(name string, data []byte) (string, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode image for thumbnail: %w", err)
+	}
+
+	width, height := fitWithin(src.Bounds().Dx(), src.Bounds().Dy(), s.thumbnailWidth, s.thumbnailHeight)
+	if width == src.Bounds().Dx() && height == src.Bounds().Dy() {
+		return name, nil
+	}
+
+	thumb := resizeBox(src, width, height)
+
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailJPEGQuality})
+	case "gif":
+		err = gif.Encode(&buf, thumb, nil)
+	default:
+		err = png.Encode(&buf, thumb)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	thumbName := thumbnailName(name)
+	if err := os.WriteFile(filepath.Join(s.uploadPath, thumbName), buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("failed to store thumbnail: %w", err)
+	}
+
+	return thumbName, nil
+}
+
+// thumbnailName returns the thumbnail filename for a stored file: photo-1a2b.png -> photo-1a2b.thumb.png
+func thumbnailName(name string) string {
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + thumbnailInfix + ext
+}
+
+// fitWithin scales width x height down to fit within maxWidth x maxHeight, keeping the
+// aspect ratio. Dimensions that already fit are returned unchanged.
+func fitWithin(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	// Compare maxWidth/width with maxHeight/height without floating point
+	if maxWidth*height <= maxHeight*width {
+		return maxWidth, max(1, height*maxWidth/width)
+	}
+	return max(1, width*maxHeight/height), maxHeight
+}
+
+// resizeBox downscales src to width x height by averaging the source pixels covered
+// by each destination pixel. It is only meant for shrinking.
+func resizeBox(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*srcH/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*srcW/width)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,313 @@
+// Package services implements business logic on top of the repositories.
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	_ "image/png"  // register PNG decoder
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+)
+
+const (
+	// maxSlugLength caps the slug part of stored filenames
+	maxSlugLength = 50
+
+	// suffixBytes is the number of random bytes appended to stored filenames (hex encoded)
+	suffixBytes = 4
+
+	// DefaultMaxImageDimension is the largest accepted image width or height in pixels
+	DefaultMaxImageDimension = 4096
+
+	// UploadURLPrefix is the URL path under which files in UploadPath are served
+	UploadURLPrefix = "/uploads/"
+
+	// thumbnailInfix is inserted before the extension of thumbnail filenames
+	thumbnailInfix = ".thumb"
+)
+
+// imageFormats maps image file extensions to the format name reported by image.DecodeConfig
+var imageFormats = map[string]string{
+	".jpg":  "jpeg",
+	".jpeg": "jpeg",
+	".png":  "png",
+	".gif":  "gif",
+}
+
+// UploadService manages files uploaded through the admin interface.
+type UploadService struct {
+	uploadPath        string
+	maxUploadSize     int64
+	allowedExtensions map[string]bool
+	maxImageDimension int
+	thumbnailWidth    int
+	thumbnailHeight   int
+	sniffLimit        int
+}
+
+// UploadResult describes a stored upload.
+type UploadResult struct {
+	Filename     string // Name of the stored file within the upload directory
+	URL          string // Public URL of the original file
+	ThumbnailURL string // Public URL of the thumbnail, empty when none was generated
+}
+
+// NewUploadService creates an upload service using the upload settings from cfg.
+func NewUploadService(cfg *config.Config) *UploadService {
+	allowed := make(map[string]bool)
+	for _, ext := range cfg.AllowedUploadExtensions() {
+		allowed[ext] = true
+	}
+
+	return &UploadService{
+		uploadPath:        cfg.UploadPath,
+		maxUploadSize:     cfg.MaxUploadSize,
+		allowedExtensions: allowed,
+		maxImageDimension: DefaultMaxImageDimension,
+		thumbnailWidth:    cfg.UploadThumbnailWidth,
+		thumbnailHeight:   cfg.UploadThumbnailHeight,
+		sniffLimit:        cfg.UploadSniffLimit(),
+	}
+}
+
+// Save stores an uploaded file under a safe name in the upload directory.
+// Images are validated and, when thumbnails are enabled, a resized variant is stored
+// alongside the original. Other allowed file types are stored as-is without a thumbnail.
+// The upload is streamed to disk; only the image header, at most the configured sniff
+// limit, is buffered in memory for validation.
+func (s *UploadService) Save(filename string, r io.Reader) (*UploadResult, error) {
+	if s.maxUploadSize <= 0 {
+		return nil, fmt.Errorf("%w: uploads are disabled", repository.ErrInvalidInput)
+	}
+
+	name, err := s.SafeFilename(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bytes read while sniffing are kept so they can be written ahead of the rest
+	var sniffed bytes.Buffer
+	_, isImage := imageFormats[filepath.Ext(name)]
+	if isImage {
+		head := io.TeeReader(io.LimitReader(r, int64(s.sniffLimit)), &sniffed)
+		if err := s.ValidateImage(name, head); err != nil {
+			if sniffed.Len() >= s.sniffLimit {
+				return nil, fmt.Errorf("%w: image header does not fit in the first %d bytes", repository.ErrInvalidInput, s.sniffLimit)
+			}
+			return nil, err
+		}
+	}
+
+	path := filepath.Join(s.uploadPath, name)
+	if err := s.writeUpload(path, io.MultiReader(&sniffed, r)); err != nil {
+		return nil, err
+	}
+
+	result := &UploadResult{
+		Filename: name,
+		URL:      UploadURLPrefix + name,
+	}
+
+	if isImage && s.thumbnailWidth > 0 && s.thumbnailHeight > 0 {
+		thumbName, err := s.thumbnailFor(path, name)
+		if err != nil {
+			// Nothing references the original yet, so do not leave it behind
+			_ = os.Remove(path)
+			return nil, err
+		}
+		result.ThumbnailURL = UploadURLPrefix + thumbName
+	}
+
+	return result, nil
+}
+
+// thumbnailFor generates the thumbnail of the stored upload at path, named after name
+func (s *UploadService) thumbnailFor(path, name string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read upload for thumbnail: %w", err)
+	}
+	return s.saveThumbnail(name, data)
+}
+
+// writeUpload copies r to path, failing with repository.ErrInvalidInput once more than
+// the maximum upload size has been read. The data goes to a hidden temporary file that
+// is only renamed to path when complete, so a rejected upload leaves nothing behind.
+func (s *UploadService) writeUpload(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(r, s.maxUploadSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	if n > s.maxUploadSize {
+		return fmt.Errorf("%w: file exceeds maximum upload size of %d bytes", repository.ErrInvalidInput, s.maxUploadSize)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store upload: %w", err)
+	}
+	return nil
+}
+
+// SafeFilename turns a browser-supplied filename into the name the file is stored under:
+// a lowercase ASCII slug of the base name, a short random suffix, and the original
+// extension. Names containing path separators and extensions outside the allowlist
+// are rejected with repository.ErrInvalidInput.
+func (s *UploadService) SafeFilename(original string) (string, error) {
+	if strings.ContainsAny(original, `/\`) || strings.ContainsRune(original, 0) {
+		return "", fmt.Errorf("%w: filename must not contain path separators", repository.ErrInvalidInput)
+	}
+
+	ext := strings.ToLower(filepath.Ext(original))
+	if !s.allowedExtensions[ext] {
+		return "", fmt.Errorf("%w: file extension %q is not allowed", repository.ErrInvalidInput, ext)
+	}
+
+	base := slugify(strings.TrimSuffix(original, filepath.Ext(original)))
+	if base == "" {
+		base = "file"
+	}
+
+	suffix := make([]byte, suffixBytes)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to generate filename suffix: %w", err)
+	}
+
+	return base + "-" + hex.EncodeToString(suffix) + ext, nil
+}
+
+// GC deletes image files in the upload directory that are not referenced by any of the
+// given paths and returns how many files were (or, in dry-run mode, would be) removed.
+// References may be upload URLs (/uploads/photo.png) or paths relative to the upload
+// directory; a referenced original also keeps its thumbnail. Only images, the kind of
+// file member photos and their thumbnails are, are collected: documents and other
+// uploads are never removed. Hidden files are ignored.
+func (s *UploadService) GC(ctx context.Context, referencedPaths []string, dryRun bool) (int, error) {
+	referenced := make(map[string]bool, len(referencedPaths)*2)
+	for _, ref := range referencedPaths {
+		rel := strings.TrimPrefix(strings.TrimPrefix(ref, UploadURLPrefix), "/")
+		if rel == "" || strings.Contains(rel, "://") {
+			continue
+		}
+		referenced[rel] = true
+		referenced[thumbnailName(rel)] = true
+	}
+
+	removed := 0
+	err := filepath.WalkDir(s.uploadPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") && path != s.uploadPath {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if _, isImage := imageFormats[strings.ToLower(filepath.Ext(path))]; !isImage {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.uploadPath, path)
+		if err != nil {
+			return err
+		}
+		if referenced[filepath.ToSlash(rel)] {
+			return nil
+		}
+
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+		removed++
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("failed to clean up uploads: %w", err)
+	}
+
+	return removed, nil
+}
+
+// ValidateImage checks that the content of an uploaded image matches the type declared by
+// its filename extension and that its dimensions do not exceed the configured maximum.
+// Only the image header is read. Mismatches are rejected with repository.ErrInvalidInput.
+func (s *UploadService) ValidateImage(filename string, r io.Reader) error {
+	ext := strings.ToLower(filepath.Ext(filename))
+	declared, ok := imageFormats[ext]
+	if !ok {
+		return fmt.Errorf("%w: %q is not a supported image type", repository.ErrInvalidInput, ext)
+	}
+
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return fmt.Errorf("%w: file is not a valid image", repository.ErrInvalidInput)
+	}
+
+	if format != declared {
+		return fmt.Errorf("%w: file content is %s but extension is %s", repository.ErrInvalidInput, format, ext)
+	}
+
+	if cfg.Width > s.maxImageDimension || cfg.Height > s.maxImageDimension {
+		return fmt.Errorf("%w: image is %dx%d, maximum is %dx%d", repository.ErrInvalidInput,
+			cfg.Width, cfg.Height, s.maxImageDimension, s.maxImageDimension)
+	}
+
+	return nil
+}
+
+// slugify lowercases s and replaces every run of characters other than ASCII letters
+// and digits with a single hyphen, trimming hyphens from both ends.
+func slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			if b.Len() >= maxSlugLength {
+				break
+			}
+			continue
+		}
+		pendingHyphen = true
+	}
+
+	return b.String()
+}
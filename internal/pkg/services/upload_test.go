@@ -0,0 +1,395 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nekoteoj/lab-cms/internal/pkg/config"
+	"github.com/nekoteoj/lab-cms/internal/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUploadService() *UploadService {
+	return NewUploadService(&config.Config{UploadAllowedExtensions: config.DefaultUploadAllowedExtensions})
+}
+
+func TestUploadService_SafeFilename(t *testing.T) {
+	svc := newTestUploadService()
+
+	t.Run("spaces and unicode are slugified", func(t *testing.T) {
+		name, err := svc.SafeFilename("Café Portrait (final) 2024.JPG")
+		require.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^caf-portrait-final-2024-[0-9a-f]{8}\.jpg$`), name)
+	})
+
+	t.Run("extension is preserved", func(t *testing.T) {
+		for original, ext := range map[string]string{
+			"photo.png":     ".png",
+			"photo.tar.gif": ".gif",
+			"PHOTO.JPEG":    ".jpeg",
+		} {
+			name, err := svc.SafeFilename(original)
+			require.NoError(t, err, original)
+			assert.Regexp(t, regexp.MustCompile(`-[0-9a-f]{8}\`+ext+`$`), name, original)
+		}
+	})
+
+	t.Run("names are unique", func(t *testing.T) {
+		first, err := svc.SafeFilename("photo.png")
+		require.NoError(t, err)
+		second, err := svc.SafeFilename("photo.png")
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("base without slug characters falls back", func(t *testing.T) {
+		name, err := svc.SafeFilename("日本.png")
+		require.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^file-[0-9a-f]{8}\.png$`), name)
+	})
+
+	t.Run("path traversal is rejected", func(t *testing.T) {
+		for _, original := range []string{"../../etc/passwd.png", `..\..\boot.png`, "uploads/photo.png"} {
+			_, err := svc.SafeFilename(original)
+			assert.ErrorIs(t, err, repository.ErrInvalidInput, original)
+		}
+	})
+
+	t.Run("disallowed extension is rejected", func(t *testing.T) {
+		for _, original := range []string{"script.php", "noextension", "archive.png.exe"} {
+			_, err := svc.SafeFilename(original)
+			assert.ErrorIs(t, err, repository.ErrInvalidInput, original)
+		}
+	})
+}
+
+func TestNewUploadService_ExtensionList(t *testing.T) {
+	svc := NewUploadService(&config.Config{UploadAllowedExtensions: " PDF, .Png ,"})
+
+	_, err := svc.SafeFilename("paper.pdf")
+	assert.NoError(t, err)
+	_, err = svc.SafeFilename("photo.png")
+	assert.NoError(t, err)
+	_, err = svc.SafeFilename("photo.jpg")
+	assert.ErrorIs(t, err, repository.ErrInvalidInput)
+}
+
+func TestUploadService_Save_ConfiguredExtensions(t *testing.T) {
+	dir := t.TempDir()
+	svc := NewUploadService(&config.Config{
+		UploadPath:              dir,
+		MaxUploadSize:           10 << 20,
+		UploadAllowedExtensions: ".png,.svg",
+	})
+
+	t.Run("newly allowed extension is stored", func(t *testing.T) {
+		result, err := svc.Save("logo.svg", strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg"/>`))
+		require.NoError(t, err)
+		assert.Regexp(t, `\.svg$`, result.Filename)
+		assert.Empty(t, result.ThumbnailURL)
+	})
+
+	t.Run("removed extension is rejected", func(t *testing.T) {
+		_, err := svc.Save("paper.pdf", strings.NewReader("%PDF-1.4 minimal"))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+}
+
+// encodePNG returns a blank PNG image of the given size
+func encodePNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestUploadService_ValidateImage(t *testing.T) {
+	svc := newTestUploadService()
+
+	t.Run("valid png", func(t *testing.T) {
+		err := svc.ValidateImage("photo.png", bytes.NewReader(encodePNG(t, 64, 48)))
+		assert.NoError(t, err)
+	})
+
+	t.Run("text file renamed to png", func(t *testing.T) {
+		err := svc.ValidateImage("photo.png", strings.NewReader("just some text, not an image"))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+
+	t.Run("content does not match extension", func(t *testing.T) {
+		err := svc.ValidateImage("photo.jpg", bytes.NewReader(encodePNG(t, 8, 8)))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+
+	t.Run("oversized image", func(t *testing.T) {
+		err := svc.ValidateImage("photo.png", bytes.NewReader(encodePNG(t, DefaultMaxImageDimension+1, 1)))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+
+		err = svc.ValidateImage("photo.png", bytes.NewReader(encodePNG(t, 1, DefaultMaxImageDimension+1)))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+
+	t.Run("non-image extension", func(t *testing.T) {
+		err := svc.ValidateImage("paper.pdf", bytes.NewReader(encodePNG(t, 8, 8)))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+}
+
+// newSaveTestService creates an upload service storing files in a temporary directory
+func newSaveTestService(t *testing.T) (*UploadService, string) {
+	dir := t.TempDir()
+	svc := NewUploadService(&config.Config{
+		UploadPath:              dir,
+		MaxUploadSize:           10 << 20,
+		UploadAllowedExtensions: config.DefaultUploadAllowedExtensions + ",.pdf",
+		UploadThumbnailWidth:    300,
+		UploadThumbnailHeight:   300,
+	})
+	return svc, dir
+}
+
+// decodeFileConfig reads the dimensions of an image file
+func decodeFileConfig(t *testing.T, path string) image.Config {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestUploadService_Save_Thumbnail(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+
+	result, err := svc.Save("Group Photo.png", bytes.NewReader(encodePNG(t, 900, 600)))
+	require.NoError(t, err)
+
+	assert.Equal(t, UploadURLPrefix+result.Filename, result.URL)
+	require.NotEmpty(t, result.ThumbnailURL)
+	assert.NotEqual(t, result.URL, result.ThumbnailURL)
+
+	original := decodeFileConfig(t, filepath.Join(dir, result.Filename))
+	assert.Equal(t, 900, original.Width)
+	assert.Equal(t, 600, original.Height)
+
+	// Aspect ratio is kept within the 300x300 box
+	thumb := decodeFileConfig(t, filepath.Join(dir, strings.TrimPrefix(result.ThumbnailURL, UploadURLPrefix)))
+	assert.Equal(t, 300, thumb.Width)
+	assert.Equal(t, 200, thumb.Height)
+}
+
+func TestUploadService_Save_SmallImageNotUpscaled(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+
+	result, err := svc.Save("icon.png", bytes.NewReader(encodePNG(t, 64, 64)))
+	require.NoError(t, err)
+
+	assert.Equal(t, result.URL, result.ThumbnailURL)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUploadService_Save_PDFSkipsThumbnail(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+
+	result, err := svc.Save("paper.pdf", strings.NewReader("%PDF-1.4 minimal"))
+	require.NoError(t, err)
+
+	assert.Empty(t, result.ThumbnailURL)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, result.Filename, entries[0].Name())
+}
+
+func TestUploadService_Save_ThumbnailsDisabled(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+	svc.thumbnailWidth = 0
+
+	result, err := svc.Save("photo.png", bytes.NewReader(encodePNG(t, 900, 600)))
+	require.NoError(t, err)
+
+	assert.Empty(t, result.ThumbnailURL)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUploadService_Save_ThumbnailFailure(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+
+	// The header validates, but the truncated pixel data cannot be decoded for the thumbnail
+	data := encodePNG(t, 900, 600)
+	_, err := svc.Save("photo.png", bytes.NewReader(data[:64]))
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the original is removed when its thumbnail fails")
+}
+
+func TestUploadService_Save_Rejected(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+
+	t.Run("too large", func(t *testing.T) {
+		svc.maxUploadSize = 16
+		t.Cleanup(func() { svc.maxUploadSize = 10 << 20 })
+
+		_, err := svc.Save("paper.pdf", strings.NewReader(strings.Repeat("x", 17)))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+
+	t.Run("invalid image", func(t *testing.T) {
+		_, err := svc.Save("photo.png", strings.NewReader("not an image"))
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+	})
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFitWithin(t *testing.T) {
+	tests := []struct {
+		w, h, maxW, maxH int
+		wantW, wantH     int
+	}{
+		{900, 600, 300, 300, 300, 200},
+		{600, 900, 300, 300, 200, 300},
+		{100, 50, 300, 300, 100, 50},
+		{1000, 1, 300, 300, 300, 1},
+	}
+
+	for _, tt := range tests {
+		w, h := fitWithin(tt.w, tt.h, tt.maxW, tt.maxH)
+		assert.Equal(t, tt.wantW, w)
+		assert.Equal(t, tt.wantH, h)
+	}
+}
+
+func TestUploadService_GC(t *testing.T) {
+	setup := func(t *testing.T) (*UploadService, string) {
+		svc, dir := newSaveTestService(t)
+		for _, name := range []string{
+			"kept.png", "kept.thumb.png", "also-kept.jpg",
+			"orphan.png", "orphan.thumb.png", "old.pdf", "logo.svg", ".gitkeep",
+		} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644))
+		}
+		return svc, dir
+	}
+	referenced := []string{UploadURLPrefix + "kept.png", "also-kept.jpg", "https://example.com/photo.png"}
+
+	listFiles := func(t *testing.T, dir string) []string {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+
+	t.Run("removes only orphaned images", func(t *testing.T) {
+		svc, dir := setup(t)
+
+		removed, err := svc.GC(context.Background(), referenced, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, removed)
+		assert.ElementsMatch(t, []string{".gitkeep", "also-kept.jpg", "kept.png", "kept.thumb.png", "old.pdf", "logo.svg"},
+			listFiles(t, dir), "unreferenced non-image uploads are kept")
+	})
+
+	t.Run("dry run removes nothing", func(t *testing.T) {
+		svc, dir := setup(t)
+		before := listFiles(t, dir)
+
+		removed, err := svc.GC(context.Background(), referenced, true)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, removed)
+		assert.Equal(t, before, listFiles(t, dir))
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		svc, dir := setup(t)
+		before := listFiles(t, dir)
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := svc.GC(cancelled, referenced, false)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, before, listFiles(t, dir))
+	})
+}
+
+// countingReader records how many bytes have been read from it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestUploadService_Save_SniffLimit(t *testing.T) {
+	svc, dir := newSaveTestService(t)
+	svc.thumbnailWidth = 0
+
+	// Random pixels keep the PNG well above the sniff limit after compression
+	img := image.NewGray(image.Rect(0, 0, 256, 256))
+	_, err := rand.Read(img.Pix)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	data := buf.Bytes()
+
+	t.Run("sniffed bytes are written with the rest of the file", func(t *testing.T) {
+		svc.sniffLimit = 1024
+		require.Greater(t, len(data), svc.sniffLimit)
+
+		result, err := svc.Save("noise.png", bytes.NewReader(data))
+		require.NoError(t, err)
+
+		stored, err := os.ReadFile(filepath.Join(dir, result.Filename))
+		require.NoError(t, err)
+		assert.Equal(t, data, stored)
+	})
+
+	t.Run("header beyond the limit is rejected without reading further", func(t *testing.T) {
+		svc.sniffLimit = 16
+		src := &countingReader{r: bytes.NewReader(data)}
+
+		_, err := svc.Save("noise.png", src)
+		assert.ErrorIs(t, err, repository.ErrInvalidInput)
+		assert.LessOrEqual(t, src.n, svc.sniffLimit)
+	})
+
+	t.Run("non-images are not sniffed", func(t *testing.T) {
+		svc.sniffLimit = 4
+		content := "%PDF-1.4 " + strings.Repeat("x", 100)
+
+		result, err := svc.Save("paper.pdf", strings.NewReader(content))
+		require.NoError(t, err)
+
+		stored, err := os.ReadFile(filepath.Join(dir, result.Filename))
+		require.NoError(t, err)
+		assert.Equal(t, content, string(stored))
+	})
+}
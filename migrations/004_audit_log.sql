@@ -0,0 +1,22 @@
+-- Audit log for content changes
+-- Records which admin user created, updated, or deleted which entity and when
+
+-- Enable foreign key constraints
+PRAGMA foreign_keys = ON;
+
+-- Audit log table: one row per content change
+-- user_id is NULL for system changes, or when the acting user has since been deleted
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER,
+    action TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Index for listing the most recent changes
+CREATE INDEX idx_audit_log_created ON audit_log(created_at DESC);
+-- Index for the change history of a single entity
+CREATE INDEX idx_audit_log_entity ON audit_log(entity, entity_id);
@@ -0,0 +1,5 @@
+-- Index for the news archive
+-- Supports looking up published news by effective date (published_at, falling back to created_at)
+
+-- Expression index matching the COALESCE used by NewsRepository.GetByMonth
+CREATE INDEX idx_news_effective_date ON news(is_published, COALESCE(published_at, created_at));
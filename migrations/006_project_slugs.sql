@@ -0,0 +1,7 @@
+-- Project slugs
+-- Optional URL-friendly identifier so project pages can use /projects/<slug> instead of the ID
+
+ALTER TABLE projects ADD COLUMN slug TEXT;
+
+-- Slugs are unique when set; projects without a slug (NULL) are not constrained
+CREATE UNIQUE INDEX idx_projects_slug ON projects(slug);
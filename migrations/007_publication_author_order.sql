@@ -0,0 +1,6 @@
+-- Authorship order for linked publication authors
+-- Publications list their authors in a meaningful order (first author, ...), which the
+-- junction table could not represent; authors were listed by member display order instead.
+
+-- 0-based position of the member in the publication's author list
+ALTER TABLE publication_authors ADD COLUMN author_order INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,18 @@
+-- Case-insensitive homepage section keys
+-- Keys are now stored lowercase so that 'Overview' and 'overview' refer to the same section.
+-- Existing rows whose keys differ only in case are merged first, keeping the most recently
+-- updated one (the lowest id on a tie), so that lowercasing cannot violate the unique index.
+
+DELETE FROM homepage_sections
+WHERE id NOT IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY lower(trim(section_key))
+            ORDER BY updated_at DESC, id ASC
+        ) AS keep_rank
+        FROM homepage_sections
+    )
+    WHERE keep_rank = 1
+);
+
+UPDATE homepage_sections SET section_key = lower(trim(section_key));
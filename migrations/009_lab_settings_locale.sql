@@ -0,0 +1,10 @@
+-- Per-locale lab settings
+-- A setting may now have one value per locale (e.g. 'fr' or 'pt-br') for bilingual sites.
+-- The empty locale holds the default value used when no translation exists; every
+-- existing setting becomes that default.
+
+ALTER TABLE lab_settings ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+
+-- Keys are now unique per locale rather than globally
+DROP INDEX idx_lab_settings_key;
+CREATE UNIQUE INDEX idx_lab_settings_key ON lab_settings(setting_key, locale);
@@ -0,0 +1,5 @@
+-- Track when each user last logged in
+-- Used to find inactive accounts during security reviews. NULL means the user has
+-- never logged in since the column was added.
+
+ALTER TABLE users ADD COLUMN last_login_at DATETIME;
@@ -0,0 +1,22 @@
+-- Case-insensitive user emails
+-- Emails are now stored trimmed and lowercase so that lookups can use idx_users_email and
+-- 'Admin@example.com' and 'admin@example.com' cannot be two accounts. Existing accounts
+-- whose emails differ only in case are merged first, keeping the most recently logged-in
+-- one (the lowest id on a tie), so that lowercasing cannot violate the unique index.
+-- Audit entries of the removed accounts are kept, attributed to no user.
+
+PRAGMA foreign_keys = ON;
+
+DELETE FROM users
+WHERE id NOT IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (
+            PARTITION BY lower(trim(email))
+            ORDER BY last_login_at IS NULL, last_login_at DESC, id ASC
+        ) AS keep_rank
+        FROM users
+    )
+    WHERE keep_rank = 1
+);
+
+UPDATE users SET email = lower(trim(email));
@@ -3,6 +3,7 @@ package helpers
 
 import (
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/nekoteoj/lab-cms/internal/pkg/migrations"
@@ -81,6 +82,35 @@ func IndexExists(t *testing.T, db *sql.DB, indexName string) bool {
 	return exists
 }
 
+// ExplainUsesIndex reports whether SQLite's plan for query (run with args) uses the named index.
+// It runs EXPLAIN QUERY PLAN and looks for the index in the plan details, so regression tests
+// can catch query changes that silently fall back to a full table scan.
+func ExplainUsesIndex(t *testing.T, db *sql.DB, query, indexName string, args ...any) bool {
+	rows, err := db.Query("EXPLAIN QUERY PLAN "+query, args...)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+		plan = append(plan, detail)
+	}
+	require.NoError(t, rows.Err())
+
+	for _, detail := range plan {
+		for _, field := range strings.Fields(detail) {
+			if field == indexName {
+				return true
+			}
+		}
+	}
+
+	t.Logf("query plan does not use %s: %s", indexName, strings.Join(plan, "; "))
+	return false
+}
+
 // ColumnExists checks if a column exists in a table.
 func ColumnExists(t *testing.T, db *sql.DB, tableName, columnName string) bool {
 	rows, err := db.Query(
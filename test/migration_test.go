@@ -187,3 +187,174 @@ func TestRunner_RollbackOnFailure(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, count, "second migration should not be recorded")
 }
+
+func TestRunner_DryRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "001_first.sql"), []byte("CREATE TABLE first (id INTEGER PRIMARY KEY)"), 0644))
+
+	db, err := sql.Open("sqlite", ":memory:?_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+
+	runner := migrations.NewRunner(db, tmpDir)
+
+	t.Run("fresh database reports all migrations", func(t *testing.T) {
+		pending, err := runner.DryRun()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, 1, pending[0].Version)
+		require.False(t, helpers.TableExists(t, db, "schema_migrations"), "dry run must not create the migrations table")
+		require.False(t, helpers.TableExists(t, db, "first"))
+	})
+
+	require.NoError(t, runner.Run())
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "002_second.sql"), []byte("CREATE TABLE second (id INTEGER PRIMARY KEY)"), 0644))
+
+	t.Run("only pending migrations are reported", func(t *testing.T) {
+		pending, err := runner.DryRun()
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, 2, pending[0].Version)
+		require.Equal(t, "second", pending[0].Name)
+		require.Contains(t, pending[0].SQL, "CREATE TABLE second")
+
+		var count int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+		require.Equal(t, 1, count, "dry run must not record migrations")
+		require.False(t, helpers.TableExists(t, db, "second"))
+	})
+}
+
+func TestRunner_DetectGaps(t *testing.T) {
+	newRunner := func(t *testing.T, files ...string) *migrations.Runner {
+		tmpDir := t.TempDir()
+		for _, name := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte("SELECT 1"), 0644))
+		}
+		return migrations.NewRunner(nil, tmpDir)
+	}
+
+	t.Run("contiguous sequence", func(t *testing.T) {
+		gaps, err := newRunner(t, "001_a.sql", "002_b.sql", "003_c.sql").DetectGaps()
+		require.NoError(t, err)
+		require.Empty(t, gaps)
+	})
+
+	t.Run("missing version is reported", func(t *testing.T) {
+		gaps, err := newRunner(t, "001_a.sql", "003_c.sql", "006_f.sql").DetectGaps()
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 4, 5}, gaps)
+	})
+
+	t.Run("missing first migration", func(t *testing.T) {
+		gaps, err := newRunner(t, "002_b.sql").DetectGaps()
+		require.NoError(t, err)
+		require.Equal(t, []int{1}, gaps)
+	})
+
+	t.Run("repository migrations have no gaps", func(t *testing.T) {
+		gaps, err := migrations.NewRunner(nil, "../migrations").DetectGaps()
+		require.NoError(t, err)
+		require.Empty(t, gaps)
+	})
+}
+
+func TestMigration_HomepageSectionKeyCase(t *testing.T) {
+	// Apply everything before 008, then add rows that only differ in key case
+	tmpDir := t.TempDir()
+	files, err := filepath.Glob("../migrations/*.sql")
+	require.NoError(t, err)
+	copyMigration := func(path string) {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, filepath.Base(path)), content, 0644))
+	}
+	for _, path := range files {
+		if filepath.Base(path) < "008" {
+			copyMigration(path)
+		}
+	}
+
+	db, err := sql.Open("sqlite", ":memory:?_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	runner := migrations.NewRunner(db, tmpDir)
+	require.NoError(t, runner.Run())
+
+	_, err = db.Exec(`
+		INSERT INTO homepage_sections (section_key, title, content, updated_at) VALUES
+			('Overview', 'Old', 'c', '2024-01-01 00:00:00'),
+			('overview', 'New', 'c', '2025-01-01 00:00:00'),
+			('Contact', 'Contact', 'c', '2024-01-01 00:00:00')
+	`)
+	require.NoError(t, err)
+
+	copyMigration("../migrations/008_homepage_section_key_case.sql")
+	require.NoError(t, runner.Run())
+
+	rows, err := db.Query("SELECT section_key, title FROM homepage_sections ORDER BY section_key")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	got := map[string]string{}
+	for rows.Next() {
+		var key, title string
+		require.NoError(t, rows.Scan(&key, &title))
+		got[key] = title
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, map[string]string{"contact": "Contact", "overview": "New"}, got,
+		"keys are lowercased and the most recently updated duplicate is kept")
+}
+
+func TestMigration_UserEmailCase(t *testing.T) {
+	// Apply everything before 011, then add accounts whose emails only differ in case
+	tmpDir := t.TempDir()
+	files, err := filepath.Glob("../migrations/*.sql")
+	require.NoError(t, err)
+	copyMigration := func(path string) {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, filepath.Base(path)), content, 0644))
+	}
+	for _, path := range files {
+		if filepath.Base(path) < "011" {
+			copyMigration(path)
+		}
+	}
+
+	db, err := sql.Open("sqlite", ":memory:?_fk=1")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	runner := migrations.NewRunner(db, tmpDir)
+	require.NoError(t, runner.Run())
+
+	_, err = db.Exec(`
+		INSERT INTO users (email, password_hash, last_login_at) VALUES
+			('Admin@Example.com', 'unused', NULL),
+			('admin@example.com', 'active', '2025-01-01 00:00:00'),
+			(' Editor@Example.com', 'editor', NULL)
+	`)
+	require.NoError(t, err)
+
+	copyMigration("../migrations/011_user_email_case.sql")
+	require.NoError(t, runner.Run())
+
+	rows, err := db.Query("SELECT email, password_hash FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	got := map[string]string{}
+	for rows.Next() {
+		var email, hash string
+		require.NoError(t, rows.Scan(&email, &hash))
+		got[email] = hash
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, map[string]string{"admin@example.com": "active", "editor@example.com": "editor"}, got,
+		"emails are normalized and the most recently logged-in duplicate is kept")
+}
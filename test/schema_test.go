@@ -23,6 +23,7 @@ func TestSchema_AllTablesExist(t *testing.T) {
 		{"project_members"},
 		{"publication_authors"},
 		{"project_publications"},
+		{"audit_log"},
 		{"schema_migrations"},
 	}
 
@@ -42,6 +43,7 @@ func TestSchema_UsersTableStructure(t *testing.T) {
 	require.Contains(t, columns, "email")
 	require.Contains(t, columns, "password_hash")
 	require.Contains(t, columns, "role")
+	require.Contains(t, columns, "last_login_at")
 	require.Contains(t, columns, "created_at")
 	require.Contains(t, columns, "updated_at")
 }
@@ -122,6 +124,7 @@ func TestSchema_LabSettingsTableStructure(t *testing.T) {
 	require.Contains(t, columns, "id")
 	require.Contains(t, columns, "setting_key")
 	require.Contains(t, columns, "setting_value")
+	require.Contains(t, columns, "locale")
 	require.Contains(t, columns, "created_at")
 	require.Contains(t, columns, "updated_at")
 }
@@ -143,6 +146,13 @@ func TestSchema_LabSettingsUniqueConstraint(t *testing.T) {
 	)
 	require.Error(t, err, "should error on duplicate setting_key")
 	require.Contains(t, err.Error(), "UNIQUE")
+
+	// The same key is allowed once per locale
+	_, err = db.Exec(
+		"INSERT INTO lab_settings (setting_key, setting_value, locale) VALUES (?, ?, ?)",
+		"test_key", "valeur", "fr",
+	)
+	require.NoError(t, err, "should allow the same setting_key in another locale")
 }
 
 func TestSchema_LabSettingsDefaultValues(t *testing.T) {
@@ -180,7 +190,7 @@ func TestSchema_JunctionTableStructures(t *testing.T) {
 		},
 		{
 			table:   "publication_authors",
-			columns: []string{"publication_id", "member_id"},
+			columns: []string{"publication_id", "member_id", "author_order"},
 		},
 		{
 			table:   "project_publications",
@@ -213,6 +223,9 @@ func TestSchema_AllIndexesExist(t *testing.T) {
 		{"idx_homepage_section_key"},
 		{"idx_homepage_display_order"},
 		{"idx_lab_settings_key"},
+		{"idx_audit_log_created"},
+		{"idx_audit_log_entity"},
+		{"idx_news_effective_date"},
 	}
 
 	for _, tt := range tests {